@@ -24,6 +24,11 @@ func (app *block) List() ([]hash.Hash, error) {
 	return app.blockRepository.List()
 }
 
+// ListPage returns a page of hashes after the cursor, and the cursor of the next page
+func (app *block) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.blockRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves a block by hash
 func (app *block) Retrieve(hash hash.Hash) (blocks.Block, error) {
 	return app.blockRepository.Retrieve(hash)
@@ -23,6 +23,11 @@ func (app *chain) List() ([]*uuid.UUID, error) {
 	return app.chainRepository.List()
 }
 
+// ListPage returns a page of chain ids after the cursor, and the cursor of the next page
+func (app *chain) ListPage(cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	return app.chainRepository.ListPage(cursor, amount)
+}
+
 // List list the chain ids
 func (app *chain) Retrieve(id *uuid.UUID) (chains.Chain, error) {
 	return app.chainRepository.Retrieve(id)
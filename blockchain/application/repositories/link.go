@@ -24,6 +24,11 @@ func (app *link) List() ([]hash.Hash, error) {
 	return app.linkRepository.List()
 }
 
+// ListPage returns a page of hashes after the cursor, and the cursor of the next page
+func (app *link) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.linkRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves a link by hash
 func (app *link) Retrieve(hash hash.Hash) (links.Link, error) {
 	return app.linkRepository.Retrieve(hash)
@@ -24,6 +24,11 @@ func (app *minedBlock) List() ([]hash.Hash, error) {
 	return app.mineBlockRepository.List()
 }
 
+// ListPage returns a page of hashes after the cursor, and the cursor of the next page
+func (app *minedBlock) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.mineBlockRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves a mined block by hash
 func (app *minedBlock) Retrieve(hash hash.Hash) (mined_block.Block, error) {
 	return app.mineBlockRepository.Retrieve(hash)
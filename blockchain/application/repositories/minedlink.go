@@ -24,6 +24,11 @@ func (app *minedLink) List() ([]hash.Hash, error) {
 	return app.minedLinkRepository.List()
 }
 
+// ListPage returns a page of hashes after the cursor, and the cursor of the next page
+func (app *minedLink) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.minedLinkRepository.ListPage(cursor, amount)
+}
+
 // Head returns the head mined link
 func (app *minedLink) Head() (mined_link.Link, error) {
 	return app.minedLinkRepository.Head()
@@ -28,6 +28,41 @@ func NewApplication(
 	)
 }
 
+// NewBlock creates a new block application instance
+func NewBlock(
+	blockRepository blocks.Repository,
+) Block {
+	return createBlock(blockRepository)
+}
+
+// NewMinedBlock creates a new mined block application instance
+func NewMinedBlock(
+	minedBlockRepository mined_block.Repository,
+) MinedBlock {
+	return createMinedBlock(minedBlockRepository)
+}
+
+// NewLink creates a new link application instance
+func NewLink(
+	linkRepository links.Repository,
+) Link {
+	return createLink(linkRepository)
+}
+
+// NewMinedLink creates a new mined link application instance
+func NewMinedLink(
+	minedLinkRepository mined_link.Repository,
+) MinedLink {
+	return createMinedLink(minedLinkRepository)
+}
+
+// NewChain creates a new chain application instance
+func NewChain(
+	chainRepository chains.Repository,
+) Chain {
+	return createChain(chainRepository)
+}
+
 // RemoteBuilder represents a remote application builder
 type RemoteBuilder interface {
 	Create() RemoteBuilder
@@ -47,24 +82,28 @@ type Application interface {
 // Block represents a block application
 type Block interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(hash hash.Hash) (blocks.Block, error)
 }
 
 // MinedBlock represents the mined block application
 type MinedBlock interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(hash hash.Hash) (mined_block.Block, error)
 }
 
 // Link represents the link application
 type Link interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(hash hash.Hash) (links.Link, error)
 }
 
 // MinedLink represents the mined link application
 type MinedLink interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Head() (mined_link.Link, error)
 	Retrieve(hash hash.Hash) (mined_link.Link, error)
 }
@@ -72,5 +111,6 @@ type MinedLink interface {
 // Chain represents a chain application
 type Chain interface {
 	List() ([]*uuid.UUID, error)
+	ListPage(cursor string, amount uint) ([]*uuid.UUID, string, error)
 	Retrieve(id *uuid.UUID) (chains.Chain, error)
 }
@@ -6,6 +6,7 @@ type application struct {
 	link       Link
 	minedLink  MinedLink
 	chain      Chain
+	gossip     Gossip
 }
 
 func createApplication(
@@ -14,6 +15,7 @@ func createApplication(
 	link Link,
 	minedLink MinedLink,
 	chain Chain,
+	gossip Gossip,
 ) Application {
 	out := application{
 		block:      block,
@@ -21,6 +23,7 @@ func createApplication(
 		link:       link,
 		minedLink:  minedLink,
 		chain:      chain,
+		gossip:     gossip,
 	}
 
 	return &out
@@ -50,3 +53,8 @@ func (obj application) MinedLink() MinedLink {
 func (obj application) Chain() Chain {
 	return obj.chain
 }
+
+// Gossip returns the gossip application
+func (obj application) Gossip() Gossip {
+	return obj.gossip
+}
@@ -1,55 +1,116 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
 	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	mined_link "github.com/deepvalue-network/software/blockchain/domain/links/mined"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
 	uuid "github.com/satori/go.uuid"
 )
 
+// orphanBranch represents the mined links of a chain that were replaced by a heavier fork, from the fork point
+type orphanBranch struct {
+	forkHeight uint
+	list       []mined_link.Link
+}
+
+// insertion represents the entries saved while inserting mined links, and the head they replaced; the mined links,
+// links and blocks that were already stored are not part of it
+type insertion struct {
+	head       mined_link.Link
+	minedLinks []mined_link.Link
+	links      []links.Link
+	blocks     []blocks.Block
+}
+
 type chain struct {
 	chainService        chains.Service
+	chainValidator      chains.Validator
 	chainBuilder        chains.Builder
 	genesisBuilder      genesis.Builder
 	blockApp            Block
+	blockRepository     repositories.Block
 	minedBlockApp       MinedBlock
 	minedLinkApp        MinedLink
+	linkService         links.Service
+	linkRepository      repositories.Link
+	minedLinkService    mined_link.Service
 	minedLinkRepository repositories.MinedLink
 	remoteAppBuilder    repositories.RemoteBuilder
 	chainRepository     repositories.Chain
+	syncRounds          observability.Counter
+	syncErrors          observability.Counter
+	syncDuration        observability.Histogram
+	logger              observability.Logger
+	bootstrapPeers      []peers.Peer
+	orphanDepth         uint
+	orphans             map[string][]orphanBranch
+	orphansMutex        sync.Mutex
+	headMutex           sync.Mutex
 }
 
 func createChain(
 	chainService chains.Service,
+	chainValidator chains.Validator,
 	chainBuilder chains.Builder,
 	genesisBuilder genesis.Builder,
 	blockApp Block,
+	blockRepository repositories.Block,
 	minedBlockApp MinedBlock,
 	minedLinkApp MinedLink,
+	linkService links.Service,
+	linkRepository repositories.Link,
+	minedLinkService mined_link.Service,
 	minedLinkRepository repositories.MinedLink,
 	remoteAppBuilder repositories.RemoteBuilder,
 	chainRepository repositories.Chain,
+	syncRounds observability.Counter,
+	syncErrors observability.Counter,
+	syncDuration observability.Histogram,
+	logger observability.Logger,
+	bootstrapPeers []peers.Peer,
+	orphanDepth uint,
 ) Chain {
 	out := chain{
 		chainService:        chainService,
+		chainValidator:      chainValidator,
 		chainBuilder:        chainBuilder,
 		genesisBuilder:      genesisBuilder,
 		blockApp:            blockApp,
+		blockRepository:     blockRepository,
 		minedBlockApp:       minedBlockApp,
 		minedLinkApp:        minedLinkApp,
+		linkService:         linkService,
+		linkRepository:      linkRepository,
+		minedLinkService:    minedLinkService,
 		minedLinkRepository: minedLinkRepository,
 		remoteAppBuilder:    remoteAppBuilder,
 		chainRepository:     chainRepository,
+		syncRounds:          syncRounds,
+		syncErrors:          syncErrors,
+		syncDuration:        syncDuration,
+		logger:              logger,
+		bootstrapPeers:      bootstrapPeers,
+		orphanDepth:         orphanDepth,
+		orphans:             map[string][]orphanBranch{},
 	}
 	return &out
 }
 
 // Update updates a chain by id
-func (app *chain) Update(id *uuid.UUID) error {
+func (app *chain) Update(ctx context.Context, id *uuid.UUID) error {
 	// retrieve the chain:
 	chain, err := app.chainRepository.Retrieve(id)
 	if err != nil {
@@ -63,14 +124,28 @@ func (app *chain) Update(id *uuid.UUID) error {
 	// mine the blocks:
 	baseDifficulty := genesis.BlockBaseDifficulty()
 	incrPerHash := genesis.BlockIncreasePerHashDifficulty()
-	_, err = app.minedBlockApp.MineList(genesisMiningValue, baseDifficulty, incrPerHash)
+	_, err = app.minedBlockApp.MineList(ctx, genesisMiningValue, baseDifficulty, incrPerHash)
+	if err != nil {
+		return err
+	}
+
+	// mine the links, at the difficulty retargeted using the mined links of the chain:
+	minedLinks, err := app.minedLinks(chain)
 	if err != nil {
 		return err
 	}
 
-	// mine the links:
-	genesisLinkDiff := genesis.LinkDifficulty()
-	_, err = app.minedLinkApp.MineList(genesisMiningValue, genesisLinkDiff)
+	linkDiff := mined_link.CalculateDifficulty(genesis, minedLinks)
+	_, err = app.minedLinkApp.MineList(ctx, genesisMiningValue, linkDiff)
+	if err != nil {
+		return err
+	}
+
+	// the head of the chain may have been moved by a sync or a received mined link while mining:
+	app.headMutex.Lock()
+	defer app.headMutex.Unlock()
+
+	chain, err = app.chainRepository.Retrieve(id)
 	if err != nil {
 		return err
 	}
@@ -88,7 +163,94 @@ func (app *chain) Update(id *uuid.UUID) error {
 	}
 
 	// update the chain:
-	return app.chainService.Update(chain, updated)
+	err = app.chainService.Update(chain, updated)
+	if err != nil {
+		return err
+	}
+
+	app.keepOrphans(updated, nil)
+	return nil
+}
+
+// Extend extends the chain whose head, or root, is the previous mined link of the given mined link.  The extended chain
+// is validated before the mined link is saved, then it is saved.  False is returned if the mined link is already stored.
+// The errors caused by an invalid mined link, or by one that does not extend a chain, are rejections
+func (app *chain) Extend(minedLink mined_link.Link) (bool, error) {
+	app.headMutex.Lock()
+	defer app.headMutex.Unlock()
+
+	_, err := app.minedLinkRepository.Retrieve(minedLink.Hash())
+	if err == nil {
+		return false, nil
+	}
+
+	// find the chain the mined link extends:
+	local, err := app.extendedChain(minedLink)
+	if err != nil {
+		return false, err
+	}
+
+	// build then validate the extended chain, whose previous mined links are already stored:
+	updated, err := app.chainBuilder.Create().WithOriginal(local).WithHead(minedLink).Now()
+	if err != nil {
+		return false, createRejection(err)
+	}
+
+	err = app.chainValidator.Execute(updated)
+	if err != nil {
+		return false, createRejection(err)
+	}
+
+	// save the mined link, then the extended chain:
+	inserted, err := app.insert([]mined_link.Link{minedLink})
+	if err != nil {
+		return false, err
+	}
+
+	err = app.chainService.Update(local, updated)
+	if err != nil {
+		app.rollback(inserted)
+		return false, err
+	}
+
+	app.keepOrphans(updated, nil)
+	return true, nil
+}
+
+// extendedChain returns the chain whose head, or root if it has no head, is the previous mined link of the mined link
+func (app *chain) extendedChain(minedLink mined_link.Link) (chains.Chain, error) {
+	chainIDs, err := app.chainRepository.List()
+	if err != nil {
+		return nil, err
+	}
+
+	isKnown := false
+	prevHash := minedLink.Link().PrevMinedLink()
+	for _, oneChainID := range chainIDs {
+		chain, err := app.chainRepository.Retrieve(oneChainID)
+		if err != nil {
+			return nil, err
+		}
+
+		if tipHash(chain).Compare(prevHash) {
+			return chain, nil
+		}
+
+		if chain.Root().Hash().Compare(prevHash) {
+			isKnown = true
+		}
+	}
+
+	if !isKnown {
+		_, err := app.minedLinkRepository.Retrieve(prevHash)
+		if err != nil {
+			str := fmt.Sprintf("the previous mined link (hash: %s) of the mined link (hash: %s) is unknown", prevHash.String(), minedLink.Hash().String())
+			return nil, createRejectionWithUnknownPrevious(errors.New(str))
+		}
+	}
+
+	str := fmt.Sprintf("the mined link (hash: %s) does not extend the head of any chain", minedLink.Hash().String())
+	return nil, createRejection(errors.New(str))
 }
 
 // Delete deletes a chain by id
@@ -108,6 +270,8 @@ func (app *chain) Create(
 	blockBaseDifficulty uint,
 	blockIncreaseDiffPerHash float64,
 	linkDifficulty uint,
+	targetLinkInterval time.Duration,
+	retargetWindow uint,
 	initialHashes []hash.Hash,
 ) (chains.Chain, error) {
 	// build the genesis instance:
@@ -116,6 +280,8 @@ func (app *chain) Create(
 		WithBlockBaseDifficulty(blockBaseDifficulty).
 		WithBlockIncreasePerHashDifficulty(blockIncreaseDiffPerHash).
 		WithLinkDifficulty(linkDifficulty).
+		WithTargetLinkInterval(targetLinkInterval).
+		WithRetargetWindow(retargetWindow).
 		Now()
 
 	if err != nil {
@@ -130,13 +296,18 @@ func (app *chain) Create(
 
 	// mine the root block:
 	blockHash := root.Tree().Head()
-	minedRoot, err := app.minedBlockApp.Mine(miningValue, blockBaseDifficulty, blockIncreaseDiffPerHash, blockHash)
+	minedRoot, err := app.minedBlockApp.Mine(context.Background(), miningValue, blockBaseDifficulty, blockIncreaseDiffPerHash, blockHash)
 	if err != nil {
 		return nil, err
 	}
 
 	// build the chain:
-	chain, err := app.chainBuilder.Create().WithGenesis(gen).WithRoot(minedRoot).Now()
+	builder := app.chainBuilder.Create().WithGenesis(gen).WithRoot(minedRoot)
+	if id != nil {
+		builder.WithID(id)
+	}
+
+	chain, err := builder.Now()
 	if err != nil {
 		return nil, err
 	}
@@ -154,36 +325,53 @@ func (app *chain) Create(
 func (app *chain) Sync(waitPeriod time.Duration) {
 	for {
 		// sync the chains:
-		err := app.syncOnce()
-		if err != nil {
-			// log the error:
-		}
+		app.SyncOnce()
 
 		// wait:
 		time.Sleep(waitPeriod)
 	}
 }
 
-// syncOnce sync chains once
-func (app *chain) syncOnce() error {
-	// retrieve the chain ids:
-	chainIDs, err := app.chainRepository.List()
-	if err != nil {
-		return err
+// SyncOnce sync the chains once; the chains that fail to sync are logged and skipped.  Nothing is synced without a
+// remote builder
+func (app *chain) SyncOnce() error {
+	if app.remoteAppBuilder == nil {
+		return nil
 	}
 
-	// for each chain, sync:
-	for _, oneChainID := range chainIDs {
-		err := app.syncByID(oneChainID)
+	beginsOn := time.Now().UTC()
+	defer func() {
+		app.syncRounds.Inc()
+		app.syncDuration.Observe(time.Now().UTC().Sub(beginsOn).Seconds())
+	}()
+
+	// sync the chains, one page of ids at a time:
+	cursor := ""
+	for {
+		chainIDs, next, err := app.chainRepository.ListPage(cursor, syncPageSize)
 		if err != nil {
-			// log the error:
+			app.syncErrors.Inc()
+			app.logger.Error("the chains could not be listed", "cursor", cursor, "error", err)
+			return err
 		}
-	}
 
-	return nil
+		for _, oneChainID := range chainIDs {
+			err := app.syncByID(oneChainID)
+			if err != nil {
+				app.syncErrors.Inc()
+				app.logger.Error("the chain could not be synced", "chain", oneChainID.String(), "error", err)
+			}
+		}
+
+		if next == "" {
+			return nil
+		}
+
+		cursor = next
+	}
 }
 
-// syncByID sync chain by ID
+// syncByID sync chain by ID; the peers that fail to sync are logged and skipped
 func (app *chain) syncByID(chainID *uuid.UUID) error {
 	// retrieve the chain:
 	chain, err := app.chainRepository.Retrieve(chainID)
@@ -192,43 +380,47 @@ func (app *chain) syncByID(chainID *uuid.UUID) error {
 	}
 
 	// fetch the peers:
-	peers := chain.Peers()
+	chainPeers := chain.Peers()
 
 	// if the peers has been recently synced, skip them:
 	now := time.Now().UTC()
-	syncInterval := peers.SyncInterval()
+	syncInterval := chainPeers.SyncInterval()
 	after := now.Add(syncInterval * -1)
-	if peers.HasLastSync() {
-		lastSync := peers.LastSync()
+	if chainPeers.HasLastSync() {
+		lastSync := chainPeers.LastSync()
 		if lastSync.After(after) {
 			return nil
 		}
 	}
 
-	// sync the peers:
-	list := peers.All()
+	// sync the peers of the chain, then the bootstrap peers:
+	list := append(append([]peers.Peer{}, chainPeers.All()...), app.bootstrapPeers...)
 	for _, onePeer := range list {
-		// update the chain by peer:
-		err := app.syncChainByPeer(chain, onePeer)
+		// update the chain by peer, the next peers are compared to the updated chain:
+		updated, err := app.syncChainByPeer(chain, onePeer)
 		if err != nil {
-			return err
+			app.syncErrors.Inc()
+			app.logger.Error("the chain could not be synced with the peer", "chain", chainID.String(), "peer", onePeer.Content().String(), "error", err)
+			continue
 		}
+
+		chain = updated
 	}
 
 	return nil
 }
 
-// syncChainByPeer sync a chain by peer
-func (app *chain) syncChainByPeer(localChain chains.Chain, ins peers.Peer) error {
+// syncChainByPeer sync a chain by peer, then returns the local chain, updated if needed
+func (app *chain) syncChainByPeer(localChain chains.Chain, ins peers.Peer) (chains.Chain, error) {
 	remoteApp, err := app.remoteAppBuilder.Create().WithPeer(ins).Now()
 	if err != nil {
 		// remove the peer from the peers list:
 		err := localChain.Peers().Delete(ins)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return err
+		return localChain, nil
 	}
 
 	// fetch the chainID
@@ -237,13 +429,412 @@ func (app *chain) syncChainByPeer(localChain chains.Chain, ins peers.Peer) error
 	// retrieve the chain of the remote peer:
 	remoteChain, err := remoteApp.Chain().Retrieve(localChainID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// update the chain if needed:
-	return app.updateFromRemote(localChain, remoteChain)
+	return app.updateFromRemote(localChain, remoteChain, remoteApp)
 }
 
-func (app *chain) updateFromRemote(local chains.Chain, remote chains.Chain) error {
-	return nil
+// updateFromRemote replaces the head of the local chain by the head of the remote chain, if the remote chain is
+// heavier.  The remote mined links are walked back to the common ancestor and the missing ones are downloaded without
+// locking the head.  The head is then locked, and the missing mined links are only saved if the local chain did not
+// move meanwhile, before the updated chain is validated and saved.  The orphaned local mined links are kept for the
+// orphan depth
+func (app *chain) updateFromRemote(local chains.Chain, remote chains.Chain, remoteApp repositories.Application) (chains.Chain, error) {
+	if !local.Genesis().Hash().Compare(remote.Genesis().Hash()) || !local.Root().Hash().Compare(remote.Root().Hash()) {
+		str := fmt.Sprintf("the remote chain (id: %s) does not share the genesis and root of the local chain", local.ID().String())
+		return nil, errors.New(str)
+	}
+
+	if !remote.HasHead() {
+		return local, nil
+	}
+
+	if local.HasHead() && local.Head().Hash().Compare(remote.Head().Hash()) {
+		return local, nil
+	}
+
+	// retrieve the local mined links:
+	localList, err := app.minedLinks(local)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := map[string]int{}
+	for index, oneMinedLink := range localList {
+		positions[oneMinedLink.Hash().String()] = index
+	}
+
+	// walk the remote mined links back to the common ancestor, downloading the ones that are not stored locally:
+	rootHash := local.Root().Hash()
+	ancestor := -1
+	missing := []mined_link.Link{}
+	current := remote.Head()
+	for {
+		if index, ok := positions[current.Hash().String()]; ok {
+			ancestor = index
+			break
+		}
+
+		if uint(len(missing)) >= remote.Height() {
+			str := fmt.Sprintf("the remote chain (id: %s) contains more mined links than its height (%d)", local.ID().String(), remote.Height())
+			return nil, errors.New(str)
+		}
+
+		missing = append([]mined_link.Link{current}, missing...)
+		prevHash := current.Link().PrevMinedLink()
+		if prevHash.Compare(rootHash) {
+			break
+		}
+
+		prev, err := app.minedLinkRepository.Retrieve(prevHash)
+		if err != nil {
+			prev, err = remoteApp.MinedLink().Retrieve(prevHash)
+			if err != nil {
+				str := fmt.Sprintf("the remote mined link (hash: %s) of the chain (id: %s) could not be retrieved: %s", prevHash.String(), local.ID().String(), err.Error())
+				return nil, errors.New(str)
+			}
+		}
+
+		current = prev
+	}
+
+	// the remote head is already part of the local chain:
+	if len(missing) <= 0 {
+		return local, nil
+	}
+
+	remoteList := append(append([]mined_link.Link{}, localList[:ancestor+1]...), missing...)
+	if !isHeavier(local.Genesis(), remoteList, localList) {
+		return local, nil
+	}
+
+	// the head of a chain is only moved by one sync, mining or received mined link at a time:
+	app.headMutex.Lock()
+	defer app.headMutex.Unlock()
+
+	// the remote chain was compared to the local chain before it was locked, so it is compared again on the next sync
+	// if the local chain moved meanwhile:
+	latest, err := app.chainRepository.Retrieve(local.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if !tipHash(latest).Compare(tipHash(local)) {
+		app.logger.Info("the chain moved while it was synced", "chain", local.ID().String())
+		return latest, nil
+	}
+
+	// save the missing mined links:
+	inserted, err := app.insert(missing)
+	if err != nil {
+		return nil, err
+	}
+
+	// build the updated chain, from the root to the remote head:
+	updated, err := app.chainBuilder.Create().
+		WithID(local.ID()).
+		WithPeers(local.Peers()).
+		WithGenesis(local.Genesis()).
+		WithRoot(local.Root()).
+		CreatedOn(local.CreatedOn()).
+		Now()
+
+	if err != nil {
+		app.rollback(inserted)
+		return nil, err
+	}
+
+	for _, oneMinedLink := range remoteList {
+		updated, err = app.chainBuilder.Create().WithOriginal(updated).WithHead(oneMinedLink).CreatedOn(local.CreatedOn()).Now()
+		if err != nil {
+			app.rollback(inserted)
+			return nil, err
+		}
+	}
+
+	// validate then save the updated chain, which swaps its head:
+	err = app.minedLinkService.UpdateHead(updated.Head())
+	if err != nil {
+		app.rollback(inserted)
+		return nil, err
+	}
+
+	err = app.chainService.Update(local, updated)
+	if err != nil {
+		app.rollback(inserted)
+		return nil, err
+	}
+
+	// keep the orphaned local mined links:
+	var orphaned *orphanBranch
+	if ancestor+1 < len(localList) {
+		orphaned = &orphanBranch{
+			forkHeight: uint(ancestor + 1),
+			list:       localList[ancestor+1:],
+		}
+	}
+
+	app.keepOrphans(updated, orphaned)
+	return updated, nil
+}
+
+// minedLinks returns the mined links of a chain, from its root to its head
+func (app *chain) minedLinks(chain chains.Chain) ([]mined_link.Link, error) {
+	out := []mined_link.Link{}
+	if !chain.HasHead() {
+		return out, nil
+	}
+
+	rootHash := chain.Root().Hash()
+	current := chain.Head()
+	for {
+		out = append([]mined_link.Link{current}, out...)
+		prevHash := current.Link().PrevMinedLink()
+		if prevHash.Compare(rootHash) {
+			return out, nil
+		}
+
+		prev, err := app.minedLinkRepository.Retrieve(prevHash)
+		if err != nil {
+			str := fmt.Sprintf("the mined link (hash: %s) of the chain (id: %s) could not be retrieved: %s", prevHash.String(), chain.ID().String(), err.Error())
+			return nil, errors.New(str)
+		}
+
+		current = prev
+	}
+}
+
+// insert saves the mined links that are not already stored; on failure, the saved entries are deleted
+func (app *chain) insert(list []mined_link.Link) (*insertion, error) {
+	out := insertion{
+		minedLinks: []mined_link.Link{},
+		links:      []links.Link{},
+		blocks:     []blocks.Block{},
+	}
+
+	head, err := app.minedLinkRepository.Head()
+	if err == nil {
+		out.head = head
+	}
+
+	for _, oneMinedLink := range list {
+		_, err := app.minedLinkRepository.Retrieve(oneMinedLink.Hash())
+		if err == nil {
+			continue
+		}
+
+		// the link and block of the mined link are saved with it, if they are not already stored:
+		link := oneMinedLink.Link()
+		_, err = app.linkRepository.Retrieve(link.Hash())
+		if err != nil {
+			out.links = append(out.links, link)
+		}
+
+		block := link.NextBlock()
+		_, err = app.blockRepository.Retrieve(block.Tree().Head())
+		if err != nil {
+			out.blocks = append(out.blocks, block)
+		}
+
+		out.minedLinks = append(out.minedLinks, oneMinedLink)
+		err = app.minedLinkService.Insert(oneMinedLink)
+		if err != nil {
+			app.rollback(&out)
+			return nil, err
+		}
+	}
+
+	return &out, nil
+}
+
+// rollback deletes the inserted mined links, links and blocks that are stored, then points the head back to the one
+// they replaced.  The head is left empty if there was none
+func (app *chain) rollback(inserted *insertion) {
+	for i := len(inserted.minedLinks) - 1; i >= 0; i-- {
+		minedLink := inserted.minedLinks[i]
+		_, err := app.minedLinkRepository.Retrieve(minedLink.Hash())
+		if err != nil {
+			continue
+		}
+
+		err = app.minedLinkService.Delete(minedLink)
+		if err != nil {
+			app.logger.Error("the inserted mined link could not be deleted", "hash", minedLink.Hash().String(), "error", err)
+		}
+	}
+
+	for i := len(inserted.links) - 1; i >= 0; i-- {
+		link := inserted.links[i]
+		_, err := app.linkRepository.Retrieve(link.Hash())
+		if err != nil {
+			continue
+		}
+
+		err = app.linkService.Delete(link)
+		if err != nil {
+			app.logger.Error("the inserted link could not be deleted", "hash", link.Hash().String(), "error", err)
+		}
+	}
+
+	for i := len(inserted.blocks) - 1; i >= 0; i-- {
+		blockHash := inserted.blocks[i].Tree().Head()
+		_, err := app.blockRepository.Retrieve(blockHash)
+		if err != nil {
+			continue
+		}
+
+		err = app.blockApp.Delete(blockHash)
+		if err != nil {
+			app.logger.Error("the inserted block could not be deleted", "hash", blockHash.String(), "error", err)
+		}
+	}
+
+	if inserted.head != nil {
+		err := app.minedLinkService.UpdateHead(inserted.head)
+		if err != nil {
+			app.logger.Error("the head could not be restored", "hash", inserted.head.Hash().String(), "error", err)
+		}
+	}
+}
+
+// keepOrphans adds the orphaned branch to the ones of the chain, then prunes the branches whose fork point is deeper
+// than the orphan depth.  The mined links, links and blocks are shared across forks, so the entries still referenced
+// by the chain or by the kept branches are never pruned
+func (app *chain) keepOrphans(chain chains.Chain, orphaned *orphanBranch) {
+	app.orphansMutex.Lock()
+	defer app.orphansMutex.Unlock()
+
+	keyname := chain.ID().String()
+	branches := app.orphans[keyname]
+	if orphaned != nil {
+		branches = append(branches, *orphaned)
+	}
+
+	kept := []orphanBranch{}
+	expired := []orphanBranch{}
+	height := chain.Height()
+	for _, oneBranch := range branches {
+		if oneBranch.forkHeight+app.orphanDepth >= height {
+			kept = append(kept, oneBranch)
+			continue
+		}
+
+		expired = append(expired, oneBranch)
+	}
+
+	if len(expired) <= 0 {
+		app.orphans[keyname] = kept
+		return
+	}
+
+	// the expired branches are kept until the mined links of the chain can be retrieved:
+	mainList, err := app.minedLinks(chain)
+	if err != nil {
+		app.logger.Error("the orphaned mined links could not be pruned", "chain", keyname, "error", err)
+		app.orphans[keyname] = branches
+		return
+	}
+
+	referenced := map[string]bool{}
+	reference(referenced, mainList)
+	for _, oneBranch := range kept {
+		reference(referenced, oneBranch.list)
+	}
+
+	// prune the expired branches from their tip, so that no delete cascades to the following mined links:
+	for _, oneBranch := range expired {
+		for i := len(oneBranch.list) - 1; i >= 0; i-- {
+			app.prune(keyname, oneBranch.list[i], referenced)
+		}
+	}
+
+	app.orphans[keyname] = kept
+}
+
+// prune deletes an orphaned mined link, then its link, unless they are referenced
+func (app *chain) prune(keyname string, minedLink mined_link.Link, referenced map[string]bool) {
+	// the mined link, or a link that follows it, is still referenced:
+	minedLinkHash := minedLink.Hash()
+	if referenced[minedLinkHash.String()] {
+		return
+	}
+
+	// the mined link may already have been deleted by a previous prune:
+	_, err := app.minedLinkRepository.Retrieve(minedLinkHash)
+	if err == nil {
+		err = app.minedLinkService.Delete(minedLink)
+		if err != nil {
+			app.logger.Error("the orphaned mined link could not be deleted", "chain", keyname, "hash", minedLinkHash.String(), "error", err)
+			return
+		}
+	}
+
+	// the link is kept if it is mined by a referenced mined link:
+	link := minedLink.Link()
+	linkHash := link.Hash()
+	if referenced[linkHash.String()] {
+		return
+	}
+
+	_, err = app.linkRepository.Retrieve(linkHash)
+	if err != nil {
+		return
+	}
+
+	err = app.linkService.Delete(link)
+	if err != nil {
+		app.logger.Error("the orphaned link could not be deleted", "chain", keyname, "hash", linkHash.String(), "error", err)
+	}
+}
+
+// reference adds the hashes of the mined links, of their links and of their previous mined links to the referenced ones
+func reference(referenced map[string]bool, list []mined_link.Link) {
+	for _, oneMinedLink := range list {
+		link := oneMinedLink.Link()
+		referenced[oneMinedLink.Hash().String()] = true
+		referenced[link.Hash().String()] = true
+		referenced[link.PrevMinedLink().String()] = true
+	}
+}
+
+// tipHash returns the hash of the head of a chain, or of its root if it has no head
+func tipHash(chain chains.Chain) hash.Hash {
+	if chain.HasHead() {
+		return chain.Head().Hash()
+	}
+
+	return chain.Root().Hash()
+}
+
+// isHeavier returns true if the candidate mined links are heavier than the current ones: their accumulated work is
+// compared first, then their total hashes, then their height
+func isHeavier(gen genesis.Genesis, candidate []mined_link.Link, current []mined_link.Link) bool {
+	candidateWork, candidateHashes := weigh(gen, candidate)
+	currentWork, currentHashes := weigh(gen, current)
+	if candidateWork != currentWork {
+		return candidateWork > currentWork
+	}
+
+	if candidateHashes != currentHashes {
+		return candidateHashes > currentHashes
+	}
+
+	return len(candidate) > len(current)
+}
+
+// weigh returns the accumulated work, the expected amount of tries at the retargeted difficulty of each mined link,
+// and the total hashes of the mined links
+func weigh(gen genesis.Genesis, list []mined_link.Link) (float64, uint) {
+	work := float64(0)
+	amountHashes := uint(0)
+	difficulty := mined_link.CalculateDifficulty(gen, []mined_link.Link{})
+	for index, oneMinedLink := range list {
+		difficulty = mined_link.NextDifficulty(gen, difficulty, list[:index])
+		work += math.Pow(16, difficulty)
+		amountHashes += uint(len(oneMinedLink.Link().NextBlock().Hashes()))
+	}
+
+	return work, amountHashes
 }
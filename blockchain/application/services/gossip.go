@@ -0,0 +1,344 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	mined_block "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	mined_link "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+type gossip struct {
+	publisher            Publisher
+	chainApp             Chain
+	blockService         blocks.Service
+	blockRepository      repositories.Block
+	linkRepository       links.Repository
+	minedBlockService    mined_block.Service
+	minedBlockValidator  mined_block.Validator
+	minedBlockRepository repositories.MinedBlock
+	chainRepository      repositories.Chain
+	received             observability.Counter
+	duplicates           observability.Counter
+	announceErrors       observability.Counter
+	logger               observability.Logger
+	bootstrapPeers       []peers.Peer
+	maxSeen              int
+	seen                 map[string]struct{}
+	seenOrder            []string
+	seenMutex            sync.Mutex
+	maxPending           int
+	pending              []pendingMinedBlock
+	pendingMutex         sync.Mutex
+	isSyncing            bool
+	syncMutex            sync.Mutex
+}
+
+type pendingMinedBlock struct {
+	minedBlock mined_block.Block
+	isBlockNew bool
+}
+
+func createGossip(
+	publisher Publisher,
+	chainApp Chain,
+	blockService blocks.Service,
+	blockRepository repositories.Block,
+	linkRepository links.Repository,
+	minedBlockService mined_block.Service,
+	minedBlockValidator mined_block.Validator,
+	minedBlockRepository repositories.MinedBlock,
+	chainRepository repositories.Chain,
+	received observability.Counter,
+	duplicates observability.Counter,
+	announceErrors observability.Counter,
+	logger observability.Logger,
+	bootstrapPeers []peers.Peer,
+	maxSeen int,
+	maxPending int,
+) Gossip {
+	out := gossip{
+		publisher:            publisher,
+		chainApp:             chainApp,
+		blockService:         blockService,
+		blockRepository:      blockRepository,
+		linkRepository:       linkRepository,
+		minedBlockService:    minedBlockService,
+		minedBlockValidator:  minedBlockValidator,
+		minedBlockRepository: minedBlockRepository,
+		chainRepository:      chainRepository,
+		received:             received,
+		duplicates:           duplicates,
+		announceErrors:       announceErrors,
+		logger:               logger,
+		bootstrapPeers:       bootstrapPeers,
+		maxSeen:              maxSeen,
+		seen:                 map[string]struct{}{},
+		seenOrder:            []string{},
+		maxPending:           maxPending,
+		pending:              []pendingMinedBlock{},
+		isSyncing:            false,
+	}
+
+	return &out
+}
+
+// ReceiveMinedBlock validates a mined block against the genesis of the local chains, saves it, then re-announces it to
+// the peers.  False is returned if the mined block was already known.  The received mined blocks no link points to are
+// deleted once more than the max pending ones are received
+func (app *gossip) ReceiveMinedBlock(minedBlock mined_block.Block) (bool, error) {
+	app.received.Inc()
+	if app.isSeen(minedBlock.Hash()) {
+		app.duplicates.Inc()
+		return false, nil
+	}
+
+	_, err := app.minedBlockRepository.Retrieve(minedBlock.Hash())
+	if err == nil {
+		app.duplicates.Inc()
+		app.markSeen(minedBlock.Hash())
+		return false, nil
+	}
+
+	err = app.validateMinedBlock(minedBlock)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = app.blockRepository.Retrieve(minedBlock.Block().Tree().Head())
+	isBlockNew := err != nil
+	err = app.minedBlockService.Insert(minedBlock)
+	if err != nil {
+		return false, err
+	}
+
+	app.keepPending(minedBlock, isBlockNew)
+	app.AnnounceMinedBlock(minedBlock)
+	return true, nil
+}
+
+// ReceiveMinedLink extends the chain the mined link points to, then re-announces it to the peers.  False is returned
+// if the mined link was already known.  A mined link whose previous mined link is unknown is rejected, and the chains
+// are synced with their peers in the background, since the sender is ahead of the local chain
+func (app *gossip) ReceiveMinedLink(minedLink mined_link.Link) (bool, error) {
+	app.received.Inc()
+	if app.isSeen(minedLink.Hash()) {
+		app.duplicates.Inc()
+		return false, nil
+	}
+
+	isNew, err := app.chainApp.Extend(minedLink)
+	if err != nil {
+		if rej, ok := err.(*rejection); ok && rej.IsUnknownPrevious() {
+			app.scheduleSync()
+		}
+
+		return false, err
+	}
+
+	if !isNew {
+		app.duplicates.Inc()
+		app.markSeen(minedLink.Hash())
+		return false, nil
+	}
+
+	app.AnnounceMinedLink(minedLink)
+	return true, nil
+}
+
+// AnnounceMinedBlock announces a mined block to the peers in the background, unless it was already announced
+func (app *gossip) AnnounceMinedBlock(minedBlock mined_block.Block) {
+	if !app.markSeen(minedBlock.Hash()) {
+		return
+	}
+
+	app.announce(minedBlock.Hash(), func(peer peers.Peer) error {
+		return app.publisher.MinedBlock(peer, minedBlock)
+	})
+}
+
+// AnnounceMinedLink announces a mined link to the peers in the background, unless it was already announced
+func (app *gossip) AnnounceMinedLink(minedLink mined_link.Link) {
+	if !app.markSeen(minedLink.Hash()) {
+		return
+	}
+
+	app.announce(minedLink.Hash(), func(peer peers.Peer) error {
+		return app.publisher.MinedLink(peer, minedLink)
+	})
+}
+
+// validateMinedBlock validates the mined block against the genesis of every local chain, until one is satisfied
+func (app *gossip) validateMinedBlock(minedBlock mined_block.Block) error {
+	chainIDs, err := app.chainRepository.List()
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, oneChainID := range chainIDs {
+		chain, err := app.chainRepository.Retrieve(oneChainID)
+		if err != nil {
+			return err
+		}
+
+		lastErr = app.minedBlockValidator.Execute(chain.Genesis(), minedBlock)
+		if lastErr == nil {
+			return nil
+		}
+	}
+
+	if lastErr != nil {
+		return createRejection(lastErr)
+	}
+
+	str := fmt.Sprintf("the mined block (hash: %s) cannot be validated since there is no chain", minedBlock.Hash().String())
+	return createRejection(errors.New(str))
+}
+
+// scheduleSync syncs the chains with their peers in the background, unless a scheduled sync is still running
+func (app *gossip) scheduleSync() {
+	app.syncMutex.Lock()
+	defer app.syncMutex.Unlock()
+	if app.isSyncing {
+		return
+	}
+
+	app.isSyncing = true
+	go func() {
+		err := app.chainApp.SyncOnce()
+		if err != nil {
+			app.logger.Error("the scheduled sync failed", "error", err)
+		}
+
+		app.syncMutex.Lock()
+		defer app.syncMutex.Unlock()
+		app.isSyncing = false
+	}()
+}
+
+// announce publishes to every peer, in the background; the peers of the chains and the bootstrap peers are contacted
+// once per server
+func (app *gossip) announce(hsh hash.Hash, publish func(peer peers.Peer) error) {
+	if app.publisher == nil {
+		return
+	}
+
+	list, err := app.peers()
+	if err != nil {
+		app.announceErrors.Inc()
+		app.logger.Error("the peers could not be listed", "hash", hsh.String(), "error", err)
+		return
+	}
+
+	for _, onePeer := range list {
+		go func(peer peers.Peer) {
+			err := publish(peer)
+			if err != nil {
+				app.announceErrors.Inc()
+				app.logger.Debug("the announcement failed", "hash", hsh.String(), "peer", peer.Content().String(), "error", err)
+			}
+		}(onePeer)
+	}
+}
+
+// peers returns the peers of the chains, then the bootstrap peers, without duplicate servers
+func (app *gossip) peers() ([]peers.Peer, error) {
+	chainIDs, err := app.chainRepository.List()
+	if err != nil {
+		return nil, err
+	}
+
+	list := []peers.Peer{}
+	for _, oneChainID := range chainIDs {
+		chain, err := app.chainRepository.Retrieve(oneChainID)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, chain.Peers().All()...)
+	}
+
+	out := []peers.Peer{}
+	servers := map[string]bool{}
+	for _, onePeer := range append(list, app.bootstrapPeers...) {
+		server := onePeer.Content().String()
+		if servers[server] {
+			continue
+		}
+
+		servers[server] = true
+		out = append(out, onePeer)
+	}
+
+	return out, nil
+}
+
+// keepPending adds a received mined block to the pending ones, then deletes the oldest pending ones no link points to
+// while there are more than the max.  The block of a deleted mined block is deleted too, unless it was stored before
+func (app *gossip) keepPending(minedBlock mined_block.Block, isBlockNew bool) {
+	app.pendingMutex.Lock()
+	defer app.pendingMutex.Unlock()
+
+	app.pending = append(app.pending, pendingMinedBlock{
+		minedBlock: minedBlock,
+		isBlockNew: isBlockNew,
+	})
+
+	for len(app.pending) > app.maxPending {
+		oldest := app.pending[0]
+		app.pending = app.pending[1:]
+
+		block := oldest.minedBlock.Block()
+		_, err := app.linkRepository.RetrieveByBlockHash(block.Tree().Head())
+		if err == nil {
+			continue
+		}
+
+		// deleting the block cascades to its mined block:
+		if oldest.isBlockNew {
+			err = app.blockService.Delete(block)
+		} else {
+			err = app.minedBlockService.Delete(oldest.minedBlock)
+		}
+
+		if err != nil {
+			app.logger.Error("the pending mined block could not be deleted", "hash", oldest.minedBlock.Hash().String(), "error", err)
+		}
+	}
+}
+
+func (app *gossip) isSeen(hsh hash.Hash) bool {
+	app.seenMutex.Lock()
+	defer app.seenMutex.Unlock()
+
+	_, ok := app.seen[hsh.String()]
+	return ok
+}
+
+// markSeen adds the hash to the seen ones, forgetting the oldest one when full, and returns false if it was already seen
+func (app *gossip) markSeen(hsh hash.Hash) bool {
+	app.seenMutex.Lock()
+	defer app.seenMutex.Unlock()
+
+	keyname := hsh.String()
+	if _, ok := app.seen[keyname]; ok {
+		return false
+	}
+
+	if len(app.seenOrder) >= app.maxSeen {
+		delete(app.seen, app.seenOrder[0])
+		app.seenOrder = app.seenOrder[1:]
+	}
+
+	app.seen[keyname] = struct{}{}
+	app.seenOrder = append(app.seenOrder, keyname)
+	return true
+}
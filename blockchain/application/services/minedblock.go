@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
+
 	"github.com/deepvalue-network/software/blockchain/application/repositories"
 	mined_block "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
 type minedBlock struct {
@@ -12,6 +15,7 @@ type minedBlock struct {
 	mineBlockRepository repositories.MinedBlock
 	blockRepository     repositories.Block
 	minerApp            Miner
+	miningDuration      observability.Histogram
 }
 
 func createMinedBlock(
@@ -20,6 +24,7 @@ func createMinedBlock(
 	mineBlockRepository repositories.MinedBlock,
 	blockRepository repositories.Block,
 	minerApp Miner,
+	miningDuration observability.Histogram,
 ) MinedBlock {
 	out := minedBlock{
 		mineBlockBuilder:    mineBlockBuilder,
@@ -27,13 +32,14 @@ func createMinedBlock(
 		mineBlockRepository: mineBlockRepository,
 		blockRepository:     blockRepository,
 		minerApp:            minerApp,
+		miningDuration:      miningDuration,
 	}
 
 	return &out
 }
 
 // Mine mines a block by block hash
-func (app *minedBlock) Mine(miningValue uint8, baseDifficulty uint, incrPerHash float64, blockHash hash.Hash) (mined_block.Block, error) {
+func (app *minedBlock) Mine(ctx context.Context, miningValue uint8, baseDifficulty uint, incrPerHash float64, blockHash hash.Hash) (mined_block.Block, error) {
 	// retrieve the block:
 	block, err := app.blockRepository.Retrieve(blockHash)
 	if err != nil {
@@ -46,11 +52,15 @@ func (app *minedBlock) Mine(miningValue uint8, baseDifficulty uint, incrPerHash
 
 	// mine the block:
 	hash := block.Tree().Head()
-	results, _, err := app.minerApp.Mine(miningValue, difficulty, hash)
+	results, elapsed, err := app.minerApp.Mine(ctx, miningValue, difficulty, hash)
 	if err != nil {
 		return nil, err
 	}
 
+	if elapsed != nil {
+		app.miningDuration.Observe(elapsed.Seconds())
+	}
+
 	minedBlock, err := app.mineBlockBuilder.Create().WithBlock(block).WithResults(results).Now()
 	if err != nil {
 		return nil, err
@@ -65,7 +75,7 @@ func (app *minedBlock) Mine(miningValue uint8, baseDifficulty uint, incrPerHash
 }
 
 // MineList mine the list of block that needs to be mined
-func (app *minedBlock) MineList(miningValue uint8, baseDifficulty uint, incrPerHash float64) ([]mined_block.Block, error) {
+func (app *minedBlock) MineList(ctx context.Context, miningValue uint8, baseDifficulty uint, incrPerHash float64) ([]mined_block.Block, error) {
 	blockHashes, err := app.blockRepository.List()
 	if err != nil {
 		return nil, err
@@ -73,7 +83,7 @@ func (app *minedBlock) MineList(miningValue uint8, baseDifficulty uint, incrPerH
 
 	out := []mined_block.Block{}
 	for _, oneBlockHash := range blockHashes {
-		minedBlock, err := app.Mine(miningValue, baseDifficulty, incrPerHash, oneBlockHash)
+		minedBlock, err := app.Mine(ctx, miningValue, baseDifficulty, incrPerHash, oneBlockHash)
 		if err != nil {
 			return nil, err
 		}
@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
+
 	"github.com/deepvalue-network/software/blockchain/application/repositories"
 	mined_link "github.com/deepvalue-network/software/blockchain/domain/links/mined"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
 type minedLink struct {
@@ -12,6 +15,7 @@ type minedLink struct {
 	linkRepository      repositories.Link
 	minedLinkRepository repositories.MinedLink
 	minerApp            Miner
+	miningDuration      observability.Histogram
 }
 
 func createMinedLink(
@@ -20,6 +24,7 @@ func createMinedLink(
 	linkRepository repositories.Link,
 	minedLinkRepository repositories.MinedLink,
 	minerApp Miner,
+	miningDuration observability.Histogram,
 ) MinedLink {
 	out := minedLink{
 		minedLinkService:    minedLinkService,
@@ -27,24 +32,29 @@ func createMinedLink(
 		linkRepository:      linkRepository,
 		minedLinkRepository: minedLinkRepository,
 		minerApp:            minerApp,
+		miningDuration:      miningDuration,
 	}
 
 	return &out
 }
 
 // Mine mines a link by hash
-func (app *minedLink) Mine(miningValue uint8, difficulty uint, linkHash hash.Hash) (mined_link.Link, error) {
+func (app *minedLink) Mine(ctx context.Context, miningValue uint8, difficulty float64, linkHash hash.Hash) (mined_link.Link, error) {
 	link, err := app.linkRepository.Retrieve(linkHash)
 	if err != nil {
 		return nil, err
 	}
 
 	hash := link.Hash()
-	results, _, err := app.minerApp.Mine(miningValue, difficulty, hash)
+	results, elapsed, err := app.minerApp.Mine(ctx, miningValue, difficulty, hash)
 	if err != nil {
 		return nil, err
 	}
 
+	if elapsed != nil {
+		app.miningDuration.Observe(elapsed.Seconds())
+	}
+
 	minedLink, err := app.minedLinkBuilder.Create().WithLink(link).WithResults(results).Now()
 	if err != nil {
 		return nil, err
@@ -59,7 +69,7 @@ func (app *minedLink) Mine(miningValue uint8, difficulty uint, linkHash hash.Has
 }
 
 // MineList mines all the remaining link that needs to be mined
-func (app *minedLink) MineList(miningValue uint8, difficulty uint) ([]mined_link.Link, error) {
+func (app *minedLink) MineList(ctx context.Context, miningValue uint8, difficulty float64) ([]mined_link.Link, error) {
 	hashes, err := app.linkRepository.List()
 	if err != nil {
 		return nil, err
@@ -67,7 +77,7 @@ func (app *minedLink) MineList(miningValue uint8, difficulty uint) ([]mined_link
 
 	out := []mined_link.Link{}
 	for _, oneHash := range hashes {
-		minedLink, err := app.Mine(miningValue, difficulty, oneHash)
+		minedLink, err := app.Mine(ctx, miningValue, difficulty, oneHash)
 		if err != nil {
 			return nil, err
 		}
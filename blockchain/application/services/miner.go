@@ -1,108 +1,176 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"math"
 	"strconv"
-	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
 type miner struct {
-	hashAdapter hash.Adapter
+	hashAdapter      hash.Adapter
+	workers          uint64
+	progressInterval time.Duration
+	hashes           observability.Counter
+	hashRate         observability.Histogram
+	logger           observability.Logger
 }
 
 func createMiner(
 	hashAdapter hash.Adapter,
+	workers uint64,
+	progressInterval time.Duration,
+	hashes observability.Counter,
+	hashRate observability.Histogram,
+	logger observability.Logger,
 ) *miner {
 	out := miner{
-		hashAdapter: hashAdapter,
+		hashAdapter:      hashAdapter,
+		workers:          workers,
+		progressInterval: progressInterval,
+		hashes:           hashes,
+		hashRate:         hashRate,
+		logger:           logger,
 	}
 
 	return &out
 }
 
 // Test excutes a mining test using the given difficulty
-func (app *miner) Test(difficulty uint) (string, *time.Duration, error) {
+func (app *miner) Test(difficulty float64) (string, *time.Duration, error) {
 	data := strconv.Itoa(time.Now().UTC().Nanosecond())
-	hsh, err := app.hashAdapter.FromBytes([]byte(data))
+	hsh, err := app.hashAdapter.Hash([]byte(data))
 
 	if err != nil {
 		return "", nil, err
 	}
 
-	return app.Mine(genesis.DefaultMiningValue, difficulty, *hsh)
+	return app.Mine(context.Background(), genesis.DefaultMiningValue, difficulty, *hsh)
 }
 
-// Mine executes mining using the given hash
-func (app *miner) Mine(miningValue uint8, difficulty uint, hash hash.Hash) (string, *time.Duration, error) {
+// Mine executes mining using the given hash.  The nonces are spread over the workers until one of them finds results
+// whose hash is mined at the given difficulty, or the context is done
+func (app *miner) Mine(ctx context.Context, miningValue uint8, difficulty float64, hsh hash.Hash) (string, *time.Duration, error) {
 	// fetch the current time:
 	beginsOn := time.Now().UTC()
 
-	// create the requested prefix:
-	requestedPrefix, err := app.prefix(miningValue, difficulty)
-	if err != nil {
-		return "", nil, err
+	// start the workers:
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	amount := uint64(0)
+	found := make(chan string, app.workers)
+	failed := make(chan error, app.workers)
+	wg := new(sync.WaitGroup)
+	for i := uint64(0); i < app.workers; i++ {
+		wg.Add(1)
+		go func(first uint64) {
+			defer wg.Done()
+			results, err := app.work(ctx, miningValue, difficulty, hsh, first, &amount)
+			if err != nil {
+				failed <- err
+				return
+			}
+
+			found <- results
+		}(i)
 	}
 
-	// execute the mining:
-	results, err := app.mineRecursively(
-		requestedPrefix,
-		hash,
-		"",
-	)
+	// wait for the results, while reporting the progress:
+	ticker := time.NewTicker(app.progressInterval)
+	defer ticker.Stop()
+
+	results := ""
+	var err error
+	for results == "" && err == nil {
+		select {
+		case results = <-found:
+		case err = <-failed:
+		case <-ticker.C:
+			elapsed := time.Now().UTC().Sub(beginsOn)
+			app.logger.Debug("mining in progress", "hash", hsh.String(), "difficulty", difficulty, "hashes", atomic.LoadUint64(&amount), "hash_rate", rate(atomic.LoadUint64(&amount), elapsed))
+		}
+	}
 
+	// stop the other workers:
+	cancel()
+	wg.Wait()
+
+	elapsed := time.Now().UTC().Sub(beginsOn)
+	hashRate := rate(atomic.LoadUint64(&amount), elapsed)
+	app.hashRate.Observe(hashRate)
 	if err != nil {
-		return "", nil, nil
+		return "", nil, err
 	}
 
-	endsOn := time.Now().UTC()
-	elapsed := endsOn.Sub(beginsOn)
+	app.logger.Debug("mining completed", "hash", hsh.String(), "difficulty", difficulty, "hashes", atomic.LoadUint64(&amount), "hash_rate", hashRate)
 	return results, &elapsed, nil
 }
 
-func (app *miner) mineRecursively(
-	requestedPrefix string,
+// work tries the nonces from first, stepping by the amount of workers, and returns the first one whose hash is mined
+// at the difficulty.  The tried hashes are added to the amount by batch
+func (app *miner) work(
+	ctx context.Context,
+	miningValue uint8,
+	difficulty float64,
 	hsh hash.Hash,
-	baseStr string,
+	first uint64,
+	amount *uint64,
 ) (string, error) {
-	str := ""
-	for i := uint(0); i <= maxMiningValue; i++ {
-		str = fmt.Sprintf("%s%s", baseStr, []byte(strconv.Itoa(int(i))))
-		res, err := app.hashAdapter.FromMultiBytes([][]byte{
-			[]byte(str),
-			hsh.Bytes(),
+	// the results are hashed with the algorithm of the mined hash, which is the one of its chain:
+	hashAdapter := hash.NewAdapterFromHash(hsh)
+	hashBytes := hsh.Bytes()
+	counted := uint64(0)
+	flush := func() {
+		atomic.AddUint64(amount, counted)
+		app.hashes.Add(counted)
+		counted = 0
+	}
+
+	for nonce := first; ; nonce += app.workers {
+		if counted >= miningBatchSize {
+			flush()
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+		}
+
+		results := strconv.FormatUint(nonce, 10)
+		res, err := hashAdapter.FromMultiBytes([][]byte{
+			[]byte(results),
+			hashBytes,
 		})
 
 		if err != nil {
+			flush()
 			return "", err
 		}
 
-		if strings.HasPrefix(res.String(), requestedPrefix) {
-			return str, nil
+		counted++
+		if genesis.IsMined(*res, miningValue, difficulty) {
+			flush()
+			return results, nil
 		}
-	}
 
-	for i := 0; i < maxMiningTries; i++ {
-		results, err := app.mineRecursively(requestedPrefix, hsh, str)
-		if err != nil {
-			continue
+		if nonce > math.MaxUint64-app.workers {
+			flush()
+			return "", errors.New("the mining was impossible")
 		}
-
-		return results, nil
 	}
-
-	return "", errors.New("the mining was impossible")
 }
 
-func (app *miner) prefix(miningValue uint8, difficulty uint) (string, error) {
-	output := ""
-	for i := 0; i < int(difficulty); i++ {
-		output = fmt.Sprintf("%s%d", output, miningValue)
+// rate returns the amount of hashes per second
+func rate(amount uint64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
 	}
 
-	return output, nil
+	return float64(amount) / elapsed.Seconds()
 }
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func TestMiner_Mine_Success(t *testing.T) {
+	hashAdapter := hash.NewAdapter()
+	hsh, err := hashAdapter.Hash([]byte("some data"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	results, elapsed, err := NewMiner().Mine(context.Background(), 2, 2, *hsh)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if elapsed == nil {
+		t.Errorf("the elapsed duration was expected to be valid")
+		return
+	}
+
+	// the results must be verifiable the same way the validators do:
+	resultsHash, err := hashAdapter.FromMultiBytes([][]byte{
+		[]byte(results),
+		hsh.Bytes(),
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !strings.HasPrefix(resultsHash.String(), "22") {
+		t.Errorf("the results hash (%s) was expected to be prefixed by the mining value", resultsHash.String())
+		return
+	}
+}
+
+func TestMiner_Mine_withFractionalDifficulty_Success(t *testing.T) {
+	hashAdapter := hash.NewAdapter()
+	hsh, err := hashAdapter.Hash([]byte("some data"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	results, _, err := NewMiner().Mine(context.Background(), 2, 1.75, *hsh)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	resultsHash, err := hashAdapter.FromMultiBytes([][]byte{
+		[]byte(results),
+		hsh.Bytes(),
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !genesis.IsMined(*resultsHash, 2, 1.75) {
+		t.Errorf("the results hash (%s) was expected to be mined at the fractional difficulty", resultsHash.String())
+		return
+	}
+}
+
+func TestMiner_Mine_withCancelledContext_returnsError(t *testing.T) {
+	hsh, err := hash.NewAdapter().Hash([]byte("some data"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	// the difficulty can never be reached:
+	_, _, err = NewMiner().Mine(ctx, 2, 64, *hsh)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
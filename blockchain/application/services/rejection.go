@@ -0,0 +1,34 @@
+package services
+
+// rejection represents the error returned when a received instance is invalid, or does not extend a known instance
+type rejection struct {
+	err       error
+	isUnknown bool
+}
+
+func createRejection(err error) error {
+	return createRejectionInternally(err, false)
+}
+
+func createRejectionWithUnknownPrevious(err error) error {
+	return createRejectionInternally(err, true)
+}
+
+func createRejectionInternally(err error, isUnknown bool) error {
+	out := rejection{
+		err:       err,
+		isUnknown: isUnknown,
+	}
+
+	return &out
+}
+
+// Error returns the error message
+func (obj *rejection) Error() string {
+	return obj.err.Error()
+}
+
+// IsUnknownPrevious returns true if the received instance was rejected because its previous instance is unknown
+func (obj *rejection) IsUnknownPrevious() bool {
+	return obj.isUnknown
+}
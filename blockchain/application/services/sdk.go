@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"runtime"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
@@ -8,21 +10,65 @@ import (
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
 	mined_block "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
 	"github.com/deepvalue-network/software/blockchain/domain/links"
 	mined_link "github.com/deepvalue-network/software/blockchain/domain/links/mined"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
-// maxMiningValue represents the max mining value before adding another miner number to the slice
-const maxMiningValue = 2147483647
+// miningBatchSize represents the amount of hashes a mining worker tries before reporting them, and checking if it is cancelled
+const miningBatchSize = 4096
 
-// maxMiningTries represents the max mining characters to try before abandonning
-const maxMiningTries = 2147483647
+// miningProgressInterval represents the interval between the mining progress reports
+const miningProgressInterval = time.Second * 10
 
 // maxDifficulty represents the max difficulty a block can have
 const maxDifficulty = 127
 
+// syncPageSize represents the amount of chain ids listed at once by a sync round
+const syncPageSize = 100
+
+const blockMiningSecondsMetric = "blockchain_block_mining_seconds"
+
+const linkMiningSecondsMetric = "blockchain_link_mining_seconds"
+
+const miningHashesMetric = "blockchain_mining_hashes_total"
+
+const miningHashRateMetric = "blockchain_mining_hashes_per_second"
+
+const syncRoundsMetric = "blockchain_sync_rounds_total"
+
+const syncErrorsMetric = "blockchain_sync_errors_total"
+
+const syncSecondsMetric = "blockchain_sync_seconds"
+
+const gossipReceivedMetric = "blockchain_gossip_received_total"
+
+const gossipDuplicatesMetric = "blockchain_gossip_duplicates_total"
+
+const gossipAnnounceErrorsMetric = "blockchain_gossip_announce_errors_total"
+
+// maxSeenHashes represents the amount of mined block and mined link hashes the gossip remembers, to de-duplicate them
+const maxSeenHashes = 4096
+
+// maxPendingMinedBlocks represents the amount of received mined blocks the gossip keeps while no link points to them
+const maxPendingMinedBlocks = 256
+
+// miningBuckets represents the upper bounds of the mining duration buckets, in seconds
+var miningBuckets = []float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 900}
+
+// hashRateBuckets represents the upper bounds of the mining hash rate buckets, in hashes per second
+var hashRateBuckets = []float64{1e3, 1e4, 1e5, 1e6, 1e7, 1e8}
+
+// IsRejected returns true if the error was returned because a received instance is invalid, or does not extend a known
+// instance, false if the instance could not be processed
+func IsRejected(err error) bool {
+	_, ok := err.(*rejection)
+	return ok
+}
+
 // NewApplication creates a new application instance
 func NewApplication(
 	block Block,
@@ -30,6 +76,7 @@ func NewApplication(
 	link Link,
 	minedLink MinedLink,
 	chain Chain,
+	gossip Gossip,
 ) Application {
 	return createApplication(
 		block,
@@ -37,32 +84,97 @@ func NewApplication(
 		link,
 		minedLink,
 		chain,
+		gossip,
+	)
+}
+
+// NewGossip creates a new gossip application instance; the received mined blocks and mined links are announced to the
+// peers of the chains and the bootstrap peers, using the publisher.  Without a publisher, nothing is announced
+func NewGossip(
+	publisher Publisher,
+	chainApp Chain,
+	blockService blocks.Service,
+	blockRepository repositories.Block,
+	linkRepository links.Repository,
+	minedBlockService mined_block.Service,
+	minedBlockRepository repositories.MinedBlock,
+	chainRepository repositories.Chain,
+	bootstrapPeers []peers.Peer,
+) Gossip {
+	minedBlockValidator := mined_block.NewValidator()
+	registry := observability.DefaultRegistry()
+	received := registry.Counter(gossipReceivedMetric, "The amount of mined blocks and mined links received from the peers")
+	duplicates := registry.Counter(gossipDuplicatesMetric, "The amount of received mined blocks and mined links that were already known")
+	announceErrors := registry.Counter(gossipAnnounceErrorsMetric, "The amount of failed announcements to the peers")
+	logger := observability.DefaultLogger().With("component", "gossip")
+	return createGossip(
+		publisher,
+		chainApp,
+		blockService,
+		blockRepository,
+		linkRepository,
+		minedBlockService,
+		minedBlockValidator,
+		minedBlockRepository,
+		chainRepository,
+		received,
+		duplicates,
+		announceErrors,
+		logger,
+		bootstrapPeers,
+		maxSeenHashes,
+		maxPendingMinedBlocks,
 	)
 }
 
-// NewChain creates a new chain application instance
+// NewChain creates a new chain application instance; the chains are synced with their own peers and the bootstrap peers.
+// The mined links orphaned by a heavier remote fork are kept until the chain grows orphanDepth links past the fork point,
+// then pruned with their links unless the chain still references them
 func NewChain(
 	peerSyncInterval time.Duration,
 	remoteAppBuilder repositories.RemoteBuilder,
 	chainService chains.Service,
+	chainValidator chains.Validator,
 	blockApp Block,
+	blockRepository repositories.Block,
 	minedBlockApp MinedBlock,
 	minedLinkApp MinedLink,
+	linkService links.Service,
+	linkRepository repositories.Link,
+	minedLinkService mined_link.Service,
 	minedLinkRepository repositories.MinedLink,
 	chainRepositoryApp repositories.Chain,
+	bootstrapPeers []peers.Peer,
+	orphanDepth uint,
 ) Chain {
 	chainBuilder := chains.NewBuilder(peerSyncInterval)
 	genesisBuilder := genesis.NewBuilder()
+	registry := observability.DefaultRegistry()
+	syncRounds := registry.Counter(syncRoundsMetric, "The amount of chain sync rounds")
+	syncErrors := registry.Counter(syncErrorsMetric, "The amount of failed chain syncs")
+	syncDuration := registry.Histogram(syncSecondsMetric, "The duration of the chain sync rounds", observability.DefaultBuckets)
+	logger := observability.DefaultLogger().With("component", "chain_sync")
 	return createChain(
 		chainService,
+		chainValidator,
 		chainBuilder,
 		genesisBuilder,
 		blockApp,
+		blockRepository,
 		minedBlockApp,
 		minedLinkApp,
+		linkService,
+		linkRepository,
+		minedLinkService,
 		minedLinkRepository,
 		remoteAppBuilder,
 		chainRepositoryApp,
+		syncRounds,
+		syncErrors,
+		syncDuration,
+		logger,
+		bootstrapPeers,
+		orphanDepth,
 	)
 }
 
@@ -74,7 +186,8 @@ func NewMinedLink(
 	minerApp Miner,
 ) MinedLink {
 	minedLinkBuilder := mined_link.NewBuilder()
-	return createMinedLink(minedLinkService, minedLinkBuilder, linkRepositoryApp, minedLinkRepositoryApp, minerApp)
+	miningDuration := observability.DefaultRegistry().Histogram(linkMiningSecondsMetric, "The duration of the link mining", miningBuckets)
+	return createMinedLink(minedLinkService, minedLinkBuilder, linkRepositoryApp, minedLinkRepositoryApp, minerApp, miningDuration)
 }
 
 // NewLink creates a new link application instance
@@ -102,12 +215,14 @@ func NewMinedBlock(
 	minerApp Miner,
 ) MinedBlock {
 	mineBlockBuilder := mined_block.NewBuilder()
+	miningDuration := observability.DefaultRegistry().Histogram(blockMiningSecondsMetric, "The duration of the block mining", miningBuckets)
 	return createMinedBlock(
 		mineBlockBuilder,
 		mineBlockService,
 		mineBlockRepositoryApp,
 		blockRepositoryApp,
 		minerApp,
+		miningDuration,
 	)
 }
 
@@ -124,10 +239,15 @@ func NewBlock(
 	)
 }
 
-// NewMiner creates a new miner application instance
+// NewMiner creates a new miner application instance; the mining is spread over GOMAXPROCS workers
 func NewMiner() Miner {
 	hashAdapter := hash.NewAdapter()
-	return createMiner(hashAdapter)
+	workers := uint64(runtime.GOMAXPROCS(0))
+	registry := observability.DefaultRegistry()
+	hashes := registry.Counter(miningHashesMetric, "The amount of hashes tried by the miner")
+	hashRate := registry.Histogram(miningHashRateMetric, "The hash rate of the mining runs", hashRateBuckets)
+	logger := observability.DefaultLogger().With("component", "miner")
+	return createMiner(hashAdapter, workers, miningProgressInterval, hashes, hashRate, logger)
 }
 
 // Application represents the blockchain application
@@ -137,12 +257,13 @@ type Application interface {
 	Link() Link
 	MinedLink() MinedLink
 	Chain() Chain
+	Gossip() Gossip
 }
 
 // Miner represents a miner application
 type Miner interface {
-	Test(difficulty uint) (string, *time.Duration, error)
-	Mine(miningValue uint8, difficulty uint, hash hash.Hash) (string, *time.Duration, error)
+	Test(difficulty float64) (string, *time.Duration, error)
+	Mine(ctx context.Context, miningValue uint8, difficulty float64, hash hash.Hash) (string, *time.Duration, error)
 }
 
 // Block represents a block application
@@ -153,8 +274,8 @@ type Block interface {
 
 // MinedBlock represents the mined block application
 type MinedBlock interface {
-	Mine(miningValue uint8, baseDifficulty uint, incrPerHash float64, blockHash hash.Hash) (mined_block.Block, error)
-	MineList(miningValue uint8, baseDifficulty uint, incrPerHash float64) ([]mined_block.Block, error)
+	Mine(ctx context.Context, miningValue uint8, baseDifficulty uint, incrPerHash float64, blockHash hash.Hash) (mined_block.Block, error)
+	MineList(ctx context.Context, miningValue uint8, baseDifficulty uint, incrPerHash float64) ([]mined_block.Block, error)
 	Delete(hash hash.Hash) error
 }
 
@@ -166,22 +287,41 @@ type Link interface {
 
 // MinedLink represents the mined link application
 type MinedLink interface {
-	Mine(miningValue uint8, difficulty uint, linkHash hash.Hash) (mined_link.Link, error)
-	MineList(miningValue uint8, difficulty uint) ([]mined_link.Link, error)
+	Mine(ctx context.Context, miningValue uint8, difficulty float64, linkHash hash.Hash) (mined_link.Link, error)
+	MineList(ctx context.Context, miningValue uint8, difficulty float64) ([]mined_link.Link, error)
 	Delete(hash hash.Hash) error
 }
 
 // Chain represents a chain application
 type Chain interface {
-	Update(id *uuid.UUID) error
+	Update(ctx context.Context, id *uuid.UUID) error
+	Extend(minedLink mined_link.Link) (bool, error)
 	Delete(id *uuid.UUID) error
 	Sync(waitPeriod time.Duration)
+	SyncOnce() error
 	Create(
 		id *uuid.UUID,
 		miningValue uint8,
 		blockBaseDifficulty uint,
 		blockIncreaseDiffPerHash float64,
 		linkDifficulty uint,
+		targetLinkInterval time.Duration,
+		retargetWindow uint,
 		initialHashes []hash.Hash,
 	) (chains.Chain, error)
 }
+
+// Gossip represents the gossip application, which receives the mined blocks and mined links pushed by the peers, and
+// announces them to the other peers
+type Gossip interface {
+	ReceiveMinedBlock(minedBlock mined_block.Block) (bool, error)
+	ReceiveMinedLink(minedLink mined_link.Link) (bool, error)
+	AnnounceMinedBlock(minedBlock mined_block.Block)
+	AnnounceMinedLink(minedLink mined_link.Link)
+}
+
+// Publisher represents a publisher, which pushes mined blocks and mined links to a peer
+type Publisher interface {
+	MinedBlock(peer peers.Peer, minedBlock mined_block.Block) error
+	MinedLink(peer peers.Peer, minedLink mined_link.Link) error
+}
@@ -0,0 +1,35 @@
+// Command migrate rewrites a blockchain data directory so that every file is sharded by hash or id, and at the latest
+// version of its hydrated pointer.
+//
+// It must run while the node is stopped:
+//
+//	go run github.com/deepvalue-network/software/blockchain/cmd/migrate -dir ./data
+//
+// It does not migrate the mined links hashed before their whole creation time was part of their hash: their hash is
+// part of the link following them, whose mining results would then be invalid. A data directory holding such mined
+// links must be removed, and its chain synced from a peer or mined again.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/deepvalue-network/software/blockchain/infrastructure/disks"
+)
+
+func main() {
+	dir := flag.String("dir", "", "the blockchain data directory")
+	flag.Parse()
+
+	if *dir == "" {
+		log.Fatal("the data directory is mandatory")
+	}
+
+	amount, err := disks.Migrate(*dir)
+	if err != nil {
+		log.Fatalf("the migration failed after %d moved or upgraded files: %s", amount, err.Error())
+	}
+
+	fmt.Printf("%d files moved or upgraded\n", amount)
+}
@@ -0,0 +1,72 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hashtree"
+)
+
+func TestBlock_proveHashes_Success(t *testing.T) {
+	for _, oneAlgorithm := range []hash.Algorithm{hash.SHA512, hash.SHA256} {
+		hashAdapter, err := hash.NewAdapterWithAlgorithm(oneAlgorithm)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes := []hash.Hash{}
+		for _, oneData := range []string{"first hash", "second hash", "third hash"} {
+			hsh, err := hashAdapter.Hash([]byte(oneData))
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			hashes = append(hashes, *hsh)
+		}
+
+		block, err := NewBuilder().Create().WithHashes(hashes).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		head := block.Tree().Head()
+		for _, oneHash := range block.Hashes() {
+			proof, err := block.Tree().Proof(oneHash)
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			if !hashtree.VerifyProofFromHash(head, oneHash, proof) {
+				t.Errorf("the proof of the hash (%s) was expected to be valid against the head of its block", oneHash.String())
+				return
+			}
+
+			// the block leaves are the hashes themselves, not the hashes of their bytes:
+			if hashtree.VerifyProof(head, oneHash.Bytes(), proof) {
+				t.Errorf("the proof of the hash (%s) was expected to be invalid when verified as block data", oneHash.String())
+				return
+			}
+		}
+
+		other, err := hashAdapter.Hash([]byte("not in the block"))
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		proof, err := block.Tree().Proof(hashes[0])
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if hashtree.VerifyProofFromHash(head, *other, proof) {
+			t.Errorf("the proof was expected to be invalid when used with an hash that is not in the block")
+			return
+		}
+	}
+}
@@ -44,12 +44,7 @@ func (app *builder) Now() (Block, error) {
 		return nil, errors.New("the hashes are mandatory in order to buiild a Block instance")
 	}
 
-	blocks := [][]byte{}
-	for _, oneHash := range app.hashes {
-		blocks = append(blocks, oneHash.Bytes())
-	}
-
-	tree, err := app.hashTreeBuilder.Create().WithBlocks(blocks).Now()
+	tree, err := app.hashTreeBuilder.Create().WithHashes(app.hashes).Now()
 	if err != nil {
 		return nil, err
 	}
@@ -10,20 +10,16 @@ import (
 )
 
 type builder struct {
-	hashAdapter hash.Adapter
-	block       blocks.Block
-	results     string
-	createdOn   *time.Time
+	block     blocks.Block
+	results   string
+	createdOn *time.Time
 }
 
-func createBuilder(
-	hashAdapter hash.Adapter,
-) Builder {
+func createBuilder() Builder {
 	out := builder{
-		hashAdapter: hashAdapter,
-		block:       nil,
-		results:     "",
-		createdOn:   nil,
+		block:     nil,
+		results:   "",
+		createdOn: nil,
 	}
 
 	return &out
@@ -31,7 +27,7 @@ func createBuilder(
 
 // Create initializes the builder
 func (app *builder) Create() Builder {
-	return createBuilder(app.hashAdapter)
+	return createBuilder()
 }
 
 // WithBlock adds a block to the builder
@@ -67,8 +63,10 @@ func (app *builder) Now() (Block, error) {
 		app.createdOn = &createdOn
 	}
 
-	hash, err := app.hashAdapter.FromMultiBytes([][]byte{
-		app.block.Tree().Head().Bytes(),
+	// the mined block is hashed with the algorithm of its block:
+	head := app.block.Tree().Head()
+	hash, err := hash.NewAdapterFromHash(head).FromMultiBytes([][]byte{
+		head.Bytes(),
 		[]byte(app.results),
 		[]byte(strconv.Itoa(app.createdOn.Second())),
 	})
@@ -1,20 +1,18 @@
 package mined
 
-import "github.com/deepvalue-network/software/libs/hash"
+import (
+	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/libs/hash"
+)
 
-func calculateDifficulty(baseDifficulty uint, incrPerHash float64, amountHashes int) uint {
-	sum := float64(0)
-	base := float64(baseDifficulty)
-	for i := 0; i < int(amountHashes); i++ {
-		sum += incrPerHash
-	}
-
-	return uint(sum + base)
+func calculateDifficulty(baseDifficulty uint, incrPerHash float64, amountHashes int) float64 {
+	sum := float64(baseDifficulty) + incrPerHash*float64(amountHashes)
+	return genesis.RoundDifficulty(sum)
 }
 
-func minerHash(results string, hash hash.Hash, hashAdapter hash.Adapter) (*hash.Hash, error) {
-	return hashAdapter.FromMultiBytes([][]byte{
+func minerHash(results string, hsh hash.Hash) (*hash.Hash, error) {
+	return hash.NewAdapterFromHash(hsh).FromMultiBytes([][]byte{
 		[]byte(results),
-		hash.Bytes(),
+		hsh.Bytes(),
 	})
 }
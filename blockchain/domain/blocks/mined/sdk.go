@@ -10,18 +10,17 @@ import (
 
 // NewValidator creates a new validator instance
 func NewValidator() Validator {
-	hashAdapter := hash.NewAdapter()
-	return createValidator(hashAdapter)
+	return createValidator()
 }
 
 // NewBuilder creates a new builder instance
 func NewBuilder() Builder {
-	hashAdapter := hash.NewAdapter()
-	return createBuilder(hashAdapter)
+	return createBuilder()
 }
 
-// CalculateDifficulty calculates the difficulty
-func CalculateDifficulty(baseDifficulty uint, incrPerHash float64, amountHashes int) uint {
+// CalculateDifficulty calculates the difficulty of a block, from the amount of its hashes; the fraction added per hash is
+// kept, and enforced by the threshold of genesis.IsMined
+func CalculateDifficulty(baseDifficulty uint, incrPerHash float64, amountHashes int) float64 {
 	return calculateDifficulty(baseDifficulty, incrPerHash, amountHashes)
 }
 
@@ -55,6 +54,7 @@ type Block interface {
 // Repository represents a block repository
 type Repository interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(minedBlockHash hash.Hash) (Block, error)
 	RetrieveByBlockHash(blockHash hash.Hash) (Block, error)
 }
@@ -3,22 +3,14 @@ package mined
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
-	"github.com/deepvalue-network/software/libs/hash"
 )
 
-type validator struct {
-	hashAdapter hash.Adapter
-}
+type validator struct{}
 
-func createValidator(
-	hashAdapter hash.Adapter,
-) Validator {
-	out := validator{
-		hashAdapter: hashAdapter,
-	}
+func createValidator() Validator {
+	out := validator{}
 
 	return &out
 }
@@ -29,26 +21,19 @@ func (app *validator) Execute(gen genesis.Genesis, block Block) error {
 	baseDiff := gen.BlockBaseDifficulty()
 	incrPerHashDiff := gen.BlockIncreasePerHashDifficulty()
 	hashes := block.Block().Hashes()
-	diff := int(calculateDifficulty(baseDiff, incrPerHashDiff, len(hashes)))
-
-	// create the difficulty prefix:
-	prefix := ""
-	miningValue := gen.MiningValue()
-	for i := 0; i < diff; i++ {
-		prefix = fmt.Sprintf("%s%d", prefix, miningValue)
-	}
+	diff := calculateDifficulty(baseDiff, incrPerHashDiff, len(hashes))
 
 	// hash the results:
 	results := block.Results()
 	blockhash := block.Block().Tree().Head()
-	resultsHash, err := minerHash(results, blockhash, app.hashAdapter)
+	resultsHash, err := minerHash(results, blockhash)
 	if err != nil {
 		return err
 	}
 
 	// make sure the results contains the right diffiulty:
-	if !strings.HasPrefix(resultsHash.String(), prefix) {
-		str := fmt.Sprintf("the prefix (%s) was expected on the result hash (hash: %s)", prefix, resultsHash.String())
+	if !genesis.IsMined(*resultsHash, gen.MiningValue(), diff) {
+		str := fmt.Sprintf("the result hash (hash: %s) was expected to be mined at a difficulty of %g", resultsHash.String(), diff)
 		return errors.New(str)
 	}
 
@@ -32,6 +32,7 @@ type Block interface {
 // Repository represents a block repository
 type Repository interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(blockHash hash.Hash) (Block, error)
 }
 
@@ -5,17 +5,17 @@ import "github.com/deepvalue-network/software/libs/hash"
 // CreateBlockForTests creates a new block instance for tests
 func CreateBlockForTests() Block {
 	hashAdapter := hash.NewAdapter()
-	firstHash, err := hashAdapter.FromBytes([]byte("first hash"))
+	firstHash, err := hashAdapter.Hash([]byte("first hash"))
 	if err != nil {
 		panic(err)
 	}
 
-	secondHash, err := hashAdapter.FromBytes([]byte("second hash"))
+	secondHash, err := hashAdapter.Hash([]byte("second hash"))
 	if err != nil {
 		panic(err)
 	}
 
-	thirdHash, err := hashAdapter.FromBytes([]byte("third hash"))
+	thirdHash, err := hashAdapter.Hash([]byte("third hash"))
 	if err != nil {
 		panic(err)
 	}
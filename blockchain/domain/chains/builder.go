@@ -2,6 +2,7 @@ package chains
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
@@ -136,13 +137,21 @@ func (app *builder) Now() (Chain, error) {
 		return nil, errors.New("the root mined block is mandatory in order to build a new Chain instance")
 	}
 
-	peers, err := app.peersBuilder.Create().WithSyncDuration(app.peerSyncInterval).Now()
-	if err != nil {
-		return nil, err
+	// the blocks and links of the chain are hashed with the algorithm of its genesis, from its root:
+	rootHash := app.root.Hash()
+	if rootHash.Algorithm() != app.gen.HashAlgorithm() {
+		str := fmt.Sprintf("the root mined block (hash: %s) must be hashed with the algorithm (%s) of the genesis", rootHash.String(), app.gen.HashAlgorithm().String())
+		return nil, errors.New(str)
 	}
 
-	if app.peers != nil {
-		peers.Merge(app.peers)
+	peers := app.peers
+	if peers == nil {
+		created, err := app.peersBuilder.Create().WithSyncDuration(app.peerSyncInterval).Now()
+		if err != nil {
+			return nil, err
+		}
+
+		peers = created
 	}
 
 	totalHashes := uint(len(app.root.Block().Hashes()))
@@ -69,6 +69,7 @@ type Chain interface {
 // Repository represents a chain repository
 type Repository interface {
 	List() ([]*uuid.UUID, error)
+	ListPage(cursor string, amount uint) ([]*uuid.UUID, string, error)
 	Retrieve(id *uuid.UUID) (Chain, error)
 }
 
@@ -41,7 +41,7 @@ func (app *validator) Execute(chain Chain) error {
 		return nil
 	}
 
-	if chain.Genesis().Hash().Compare(retChain.Genesis().Hash()) {
+	if !chain.Genesis().Hash().Compare(retChain.Genesis().Hash()) {
 		str := fmt.Sprintf(
 			"the given chain (ID: %s) contains a Genesis (hash: %s) that was updated from its previously stored version (genesis hash: %s)",
 			chainID.String(),
@@ -59,14 +59,13 @@ func (app *validator) Execute(chain Chain) error {
 	}
 
 	// fetch the counted totalHashes and height:
-	rootBlock := rootMinedBlock.Block()
-	countedTotalHashes := uint(len(rootBlock.Hashes()))
-	countedHeight := uint(1)
+	countedTotalHashes := uint(len(rootMinedBlock.Block().Hashes()))
+	countedHeight := uint(0)
 
 	// validate the mined link:
 	if chain.HasHead() {
 		head := chain.Head()
-		linkTotalHashes, linkHeight, err := app.minedLinkValidator.Execute(genesis, head, rootBlock)
+		linkTotalHashes, linkHeight, err := app.minedLinkValidator.Execute(genesis, head, rootMinedBlock)
 		if err != nil {
 			return err
 		}
@@ -3,27 +3,30 @@ package genesis
 import (
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/deepvalue-network/software/libs/hash"
 )
 
 type builder struct {
-	hashAdapter     hash.Adapter
 	miningValue     uint8
 	blockBaseDiff   uint
 	incrPerHashDiff float64
 	linkDiff        uint
+	hashAlgorithm   hash.Algorithm
+	targetInterval  time.Duration
+	retargetWindow  uint
 }
 
-func createBuilder(
-	hashAdapter hash.Adapter,
-) Builder {
+func createBuilder() Builder {
 	out := builder{
-		hashAdapter:     hashAdapter,
 		miningValue:     DefaultMiningValue,
 		blockBaseDiff:   0,
 		incrPerHashDiff: 0.0,
 		linkDiff:        0,
+		hashAlgorithm:   hash.DefaultAlgorithm,
+		targetInterval:  0,
+		retargetWindow:  0,
 	}
 
 	return &out
@@ -31,7 +34,7 @@ func createBuilder(
 
 // Create initializes the builder
 func (app *builder) Create() Builder {
-	return createBuilder(app.hashAdapter)
+	return createBuilder()
 }
 
 // WithMiningValue adds a mining value to the builder
@@ -58,6 +61,24 @@ func (app *builder) WithLinkDifficulty(linkDiff uint) Builder {
 	return app
 }
 
+// WithHashAlgorithm adds the hash algorithm used by the chain to the builder
+func (app *builder) WithHashAlgorithm(hashAlgorithm hash.Algorithm) Builder {
+	app.hashAlgorithm = hashAlgorithm
+	return app
+}
+
+// WithTargetLinkInterval adds the interval targeted between two mined links to the builder
+func (app *builder) WithTargetLinkInterval(targetInterval time.Duration) Builder {
+	app.targetInterval = targetInterval
+	return app
+}
+
+// WithRetargetWindow adds the amount of mined links between two link difficulty retargets to the builder
+func (app *builder) WithRetargetWindow(retargetWindow uint) Builder {
+	app.retargetWindow = retargetWindow
+	return app
+}
+
 // Now builds a new Genesis instance
 func (app *builder) Now() (Genesis, error) {
 	if app.blockBaseDiff == 0 {
@@ -76,17 +97,48 @@ func (app *builder) Now() (Genesis, error) {
 		return nil, errors.New("the mining value must be a number between 0 and 9")
 	}
 
-	hash, err := app.hashAdapter.FromMultiBytes([][]byte{
+	if app.retargetWindow == 1 {
+		return nil, errors.New("the retarget window must contain at least two (2) mined links")
+	}
+
+	if app.retargetWindow > 0 && app.targetInterval <= 0 {
+		return nil, errors.New("the target link interval is mandatory in order to retarget the link difficulty")
+	}
+
+	// the genesis is hashed with the algorithm of its chain:
+	hashAdapter, err := hash.NewAdapterWithAlgorithm(app.hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	data := [][]byte{
 		[]byte(strconv.Itoa(int(app.blockBaseDiff))),
 		[]byte(strconv.FormatFloat(float64(app.incrPerHashDiff), 'f', -1, 64)),
 		[]byte(strconv.Itoa(int(app.linkDiff))),
 		[]byte(strconv.Itoa(int(app.miningValue))),
-	})
+	}
+
+	// the algorithm is only hashed when it is not the default one, so that the genesis of the default algorithm keep their hash:
+	if app.hashAlgorithm != hash.DefaultAlgorithm {
+		data = append(data, []byte(app.hashAlgorithm.String()))
+	}
+
+	// the retarget is only hashed when enabled, so that the genesis without retarget keep their hash:
+	targetInterval := time.Duration(0)
+	if app.retargetWindow > 0 {
+		targetInterval = app.targetInterval
+		data = append(
+			data,
+			[]byte(strconv.Itoa(int(app.retargetWindow))),
+			[]byte(strconv.FormatInt(int64(app.targetInterval), 10)),
+		)
+	}
 
+	hsh, err := hashAdapter.FromMultiBytes(data)
 	if err != nil {
 		return nil, err
 	}
 
-	return createGenesis(*hash, app.miningValue, app.blockBaseDiff, app.incrPerHashDiff, app.linkDiff), nil
+	return createGenesis(*hsh, app.miningValue, app.blockBaseDiff, app.incrPerHashDiff, app.linkDiff, app.hashAlgorithm, targetInterval, app.retargetWindow), nil
 
 }
@@ -0,0 +1,81 @@
+package genesis
+
+import (
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func TestBuilder_withDefaultHashAlgorithm_Success(t *testing.T) {
+	gen, err := NewBuilder().Create().
+		WithBlockBaseDifficulty(2).
+		WithBlockIncreasePerHashDifficulty(0.03).
+		WithLinkDifficulty(8).
+		WithMiningValue(DefaultMiningValue).
+		WithHashAlgorithm(hash.DefaultAlgorithm).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if gen.HashAlgorithm() != hash.DefaultAlgorithm {
+		t.Errorf("the hash algorithm was expected to be %s, %s returned", hash.DefaultAlgorithm.String(), gen.HashAlgorithm().String())
+		return
+	}
+}
+
+func TestBuilder_withHashAlgorithm_Success(t *testing.T) {
+	for _, oneAlgorithm := range []hash.Algorithm{hash.SHA512, hash.SHA256, hash.BLAKE2b} {
+		gen, err := NewBuilder().Create().
+			WithBlockBaseDifficulty(2).
+			WithBlockIncreasePerHashDifficulty(0.03).
+			WithLinkDifficulty(8).
+			WithMiningValue(DefaultMiningValue).
+			WithHashAlgorithm(oneAlgorithm).
+			Now()
+
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if gen.Hash().Algorithm() != oneAlgorithm {
+			t.Errorf("the genesis was expected to be hashed with %s, %s returned", oneAlgorithm.String(), gen.Hash().Algorithm().String())
+			return
+		}
+	}
+}
+
+func TestBuilder_withDefaultHashAlgorithm_keepsHash_Success(t *testing.T) {
+	gen, err := NewBuilder().Create().
+		WithBlockBaseDifficulty(2).
+		WithBlockIncreasePerHashDifficulty(0.03).
+		WithLinkDifficulty(8).
+		WithMiningValue(DefaultMiningValue).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the genesis of the default algorithm must hash the same as before the algorithm was recorded:
+	expected, err := hash.NewAdapter().FromMultiBytes([][]byte{
+		[]byte("2"),
+		[]byte("0.03"),
+		[]byte("8"),
+		[]byte("0"),
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !gen.Hash().Compare(*expected) {
+		t.Errorf("the genesis hash was expected to be %s, %s returned", expected.String(), gen.Hash().String())
+		return
+	}
+}
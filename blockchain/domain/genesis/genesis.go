@@ -1,13 +1,20 @@
 package genesis
 
-import "github.com/deepvalue-network/software/libs/hash"
+import (
+	"time"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
 
 type genesis struct {
 	hash                           hash.Hash
-	miningValue                    uint8   `hydro:"MiningValue, MiningValue"`
-	blockBaseDifficulty            uint    `hydro:"BlockBaseDifficulty, BlockBaseDifficulty"`
-	blockIncreasePerHashDifficulty float64 `hydro:"BlockIncreasePerHashDifficulty, BlockIncreasePerHashDifficulty"`
-	linkDifficulty                 uint    `hydro:"LinkDifficulty, LinkDifficulty"`
+	miningValue                    uint8          `hydro:"MiningValue, MiningValue"`
+	blockBaseDifficulty            uint           `hydro:"BlockBaseDifficulty, BlockBaseDifficulty"`
+	blockIncreasePerHashDifficulty float64        `hydro:"BlockIncreasePerHashDifficulty, BlockIncreasePerHashDifficulty"`
+	linkDifficulty                 uint           `hydro:"LinkDifficulty, LinkDifficulty"`
+	hashAlgorithm                  hash.Algorithm `hydro:"HashAlgorithm, HashAlgorithm"`
+	targetLinkInterval             time.Duration  `hydro:"TargetLinkInterval, TargetLinkInterval"`
+	retargetWindow                 uint           `hydro:"RetargetWindow, RetargetWindow"`
 }
 
 func createGenesis(
@@ -16,6 +23,9 @@ func createGenesis(
 	blockBaseDifficulty uint,
 	blockIncreasePerHashDifficulty float64,
 	linkDifficulty uint,
+	hashAlgorithm hash.Algorithm,
+	targetLinkInterval time.Duration,
+	retargetWindow uint,
 ) Genesis {
 	out := genesis{
 		hash:                           hash,
@@ -23,6 +33,9 @@ func createGenesis(
 		blockBaseDifficulty:            blockBaseDifficulty,
 		blockIncreasePerHashDifficulty: blockIncreasePerHashDifficulty,
 		linkDifficulty:                 linkDifficulty,
+		hashAlgorithm:                  hashAlgorithm,
+		targetLinkInterval:             targetLinkInterval,
+		retargetWindow:                 retargetWindow,
 	}
 
 	return &out
@@ -52,3 +65,18 @@ func (obj *genesis) BlockIncreasePerHashDifficulty() float64 {
 func (obj *genesis) LinkDifficulty() uint {
 	return obj.linkDifficulty
 }
+
+// HashAlgorithm returns the hash algorithm used by the chain
+func (obj *genesis) HashAlgorithm() hash.Algorithm {
+	return obj.hashAlgorithm
+}
+
+// TargetLinkInterval returns the interval the link difficulty is retargeted to reach between two mined links
+func (obj *genesis) TargetLinkInterval() time.Duration {
+	return obj.targetLinkInterval
+}
+
+// RetargetWindow returns the amount of mined links between two link difficulty retargets, zero if never retargeted
+func (obj *genesis) RetargetWindow() uint {
+	return obj.retargetWindow
+}
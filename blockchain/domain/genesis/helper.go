@@ -0,0 +1,51 @@
+package genesis
+
+import (
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func isMined(resultsHash hash.Hash, miningValue uint8, difficulty float64) bool {
+	if difficulty < 0 {
+		difficulty = 0
+	}
+
+	// the whole part of the difficulty is the amount of mining values prefixing the digits:
+	digits := hex.EncodeToString(resultsHash.Bytes())
+	whole := int(math.Floor(difficulty))
+	if whole > len(digits) {
+		return false
+	}
+
+	prefix := strings.Repeat(strconv.Itoa(int(miningValue)), whole)
+	if !strings.HasPrefix(digits, prefix) {
+		return false
+	}
+
+	// the fraction of the difficulty is a threshold on the following digits, under which 16^-fraction of them fall:
+	fraction := difficulty - float64(whole)
+	if fraction <= 0 {
+		return true
+	}
+
+	end := whole + thresholdDigits
+	if end > len(digits) {
+		return false
+	}
+
+	value, err := strconv.ParseUint(digits[whole:end], 16, 64)
+	if err != nil {
+		return false
+	}
+
+	threshold := math.Pow(16, float64(thresholdDigits)-fraction)
+	return float64(value) < threshold
+}
+
+func roundDifficulty(difficulty float64) float64 {
+	return math.Round(difficulty*difficultyPrecision) / difficultyPrecision
+}
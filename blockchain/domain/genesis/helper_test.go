@@ -0,0 +1,62 @@
+package genesis
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func TestIsMined_thresholds_Success(t *testing.T) {
+	cases := []struct {
+		name       string
+		digits     string
+		difficulty float64
+		expected   bool
+	}{
+		{name: "zero difficulty", digits: "0f", difficulty: 0, expected: true},
+		{name: "negative difficulty", digits: "0f", difficulty: -1, expected: true},
+		{name: "whole prefix", digits: "22", difficulty: 2, expected: true},
+		{name: "whole prefix too short", digits: "21", difficulty: 2, expected: false},
+		{name: "fraction under the threshold", digits: "223fff", difficulty: 2.5, expected: true},
+		{name: "fraction on the threshold", digits: "224000", difficulty: 2.5, expected: false},
+		{name: "smallest fraction, under the threshold", digits: "22ffed", difficulty: 2.0001, expected: true},
+		{name: "smallest fraction, over the threshold", digits: "22ffee", difficulty: 2.0001, expected: false},
+		{name: "largest fraction, under the threshold", digits: "221001", difficulty: 2.9999, expected: true},
+		{name: "largest fraction, over the threshold", digits: "221002", difficulty: 2.9999, expected: false},
+		{name: "fraction digits past the hash", digits: strings.Repeat("2", 128), difficulty: 126.5, expected: false},
+		{name: "prefix past the hash", digits: strings.Repeat("2", 128), difficulty: 129, expected: false},
+	}
+
+	for _, oneCase := range cases {
+		resultsHash := createHashForTests(t, oneCase.digits)
+		if resultsHash == nil {
+			return
+		}
+
+		mined := isMined(*resultsHash, 2, oneCase.difficulty)
+		if mined != oneCase.expected {
+			t.Errorf("%s: the hash (%s) was expected to be mined (%t) at a difficulty of %g, returned: %t", oneCase.name, resultsHash.String(), oneCase.expected, oneCase.difficulty, mined)
+			return
+		}
+	}
+}
+
+// createHashForTests creates an hash whose hexadecimal digits start with the given ones, padded with f
+func createHashForTests(t *testing.T, digits string) *hash.Hash {
+	padded := digits + strings.Repeat("f", 128-len(digits))
+	digest, err := hex.DecodeString(padded)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	out, err := hash.NewAdapter().Wrap(digest)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return out
+}
@@ -1,16 +1,35 @@
 package genesis
 
 import (
+	"time"
+
 	"github.com/deepvalue-network/software/libs/hash"
 )
 
 // DefaultMiningValue represents the default mining value
 const DefaultMiningValue = 0x0
 
+// thresholdDigits represents the amount of hexadecimal digits compared to the threshold of a fractional difficulty
+const thresholdDigits = 4
+
+// difficultyPrecision represents the precision the difficulties are rounded to, so that they compute the same everywhere
+const difficultyPrecision = 1000
+
 // NewBuilder creates a new builder instance
 func NewBuilder() Builder {
-	hashAdapter := hash.NewAdapter()
-	return createBuilder(hashAdapter)
+	return createBuilder()
+}
+
+// IsMined returns true if the results hash was mined at the given difficulty.  The hexadecimal digits of the hash must
+// begin by as many mining values as the whole part of the difficulty, then its fraction is enforced by a numeric
+// threshold on the following digits, so that each step of difficulty multiplies the expected work by 16
+func IsMined(resultsHash hash.Hash, miningValue uint8, difficulty float64) bool {
+	return isMined(resultsHash, miningValue, difficulty)
+}
+
+// RoundDifficulty rounds a computed difficulty to the precision enforced by the validators
+func RoundDifficulty(difficulty float64) float64 {
+	return roundDifficulty(difficulty)
 }
 
 // NewPointer returns a genesis pointer
@@ -25,6 +44,9 @@ type Builder interface {
 	WithBlockBaseDifficulty(blockBaseDiff uint) Builder
 	WithBlockIncreasePerHashDifficulty(incrPerHashDiff float64) Builder
 	WithLinkDifficulty(linkDiff uint) Builder
+	WithHashAlgorithm(hashAlgorithm hash.Algorithm) Builder
+	WithTargetLinkInterval(targetInterval time.Duration) Builder
+	WithRetargetWindow(retargetWindow uint) Builder
 	Now() (Genesis, error)
 }
 
@@ -35,4 +57,7 @@ type Genesis interface {
 	BlockBaseDifficulty() uint
 	BlockIncreasePerHashDifficulty() float64
 	LinkDifficulty() uint
+	HashAlgorithm() hash.Algorithm
+	TargetLinkInterval() time.Duration
+	RetargetWindow() uint
 }
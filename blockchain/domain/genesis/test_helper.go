@@ -1,17 +1,23 @@
 package genesis
 
+import "time"
+
 // CreateGenesisForTests creates a new genesis instance for tests
 func CreateGenesisForTests() Genesis {
 	blockBaseDiff := uint(2)
 	incrPerHashDiff := float64(0.03)
 	linkDiff := uint(8)
 	miningValue := uint8(DefaultMiningValue)
+	targetLinkInterval := time.Second * 30
+	retargetWindow := uint(4)
 
 	ins, err := NewBuilder().Create().
 		WithBlockBaseDifficulty(blockBaseDiff).
 		WithBlockIncreasePerHashDifficulty(incrPerHashDiff).
 		WithLinkDifficulty(linkDiff).
 		WithMiningValue(miningValue).
+		WithTargetLinkInterval(targetLinkInterval).
+		WithRetargetWindow(retargetWindow).
 		Now()
 
 	if err != nil {
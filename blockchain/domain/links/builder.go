@@ -2,6 +2,7 @@ package links
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
@@ -9,17 +10,13 @@ import (
 )
 
 type builder struct {
-	hashAdapter   hash.Adapter
 	index         uint
 	prevMinedLink *hash.Hash
 	nextBlock     blocks.Block
 }
 
-func createBuilder(
-	hashAdapter hash.Adapter,
-) Builder {
+func createBuilder() Builder {
 	out := builder{
-		hashAdapter:   hashAdapter,
 		index:         0,
 		prevMinedLink: nil,
 		nextBlock:     nil,
@@ -30,7 +27,7 @@ func createBuilder(
 
 // Create initializes the builder
 func (app *builder) Create() Builder {
-	return createBuilder(app.hashAdapter)
+	return createBuilder()
 }
 
 // WithIndex adds an index to the builder
@@ -61,10 +58,17 @@ func (app *builder) Now() (Link, error) {
 		return nil, errors.New("the next block is mandatory in order to build a Link instance")
 	}
 
-	hash, err := app.hashAdapter.FromMultiBytes([][]byte{
+	// the link is hashed with the algorithm of its chain, so its next block must use it:
+	head := app.nextBlock.Tree().Head()
+	if head.Algorithm() != app.prevMinedLink.Algorithm() {
+		str := fmt.Sprintf("the next block (hash: %s) must be hashed with the algorithm (%s) of the previous mined link (hash: %s)", head.String(), app.prevMinedLink.Algorithm().String(), app.prevMinedLink.String())
+		return nil, errors.New(str)
+	}
+
+	hash, err := hash.NewAdapterFromHash(*app.prevMinedLink).FromMultiBytes([][]byte{
 		[]byte(strconv.Itoa(int(app.index))),
 		app.prevMinedLink.Bytes(),
-		app.nextBlock.Tree().Head().Bytes(),
+		head.Bytes(),
 	})
 
 	if err != nil {
@@ -10,20 +10,16 @@ import (
 )
 
 type builder struct {
-	hashAdapter hash.Adapter
-	link        links.Link
-	results     string
-	createdOn   *time.Time
+	link      links.Link
+	results   string
+	createdOn *time.Time
 }
 
-func createBuilder(
-	hashAdapter hash.Adapter,
-) Builder {
+func createBuilder() Builder {
 	out := builder{
-		hashAdapter: hashAdapter,
-		link:        nil,
-		results:     "",
-		createdOn:   nil,
+		link:      nil,
+		results:   "",
+		createdOn: nil,
 	}
 
 	return &out
@@ -31,7 +27,7 @@ func createBuilder(
 
 // Create initializes the builder
 func (app *builder) Create() Builder {
-	return createBuilder(app.hashAdapter)
+	return createBuilder()
 }
 
 // Create adds a link to the builder
@@ -67,15 +63,19 @@ func (app *builder) Now() (Link, error) {
 		app.createdOn = &createdOn
 	}
 
-	hash, err := app.hashAdapter.FromMultiBytes([][]byte{
-		app.link.Hash().Bytes(),
+	// the whole creation time is hashed, at the precision it is persisted with, since it retargets the difficulty:
+	createdOn := app.createdOn.Truncate(createdOnPrecision)
+	// the mined link is hashed with the algorithm of its link:
+	linkHash := app.link.Hash()
+	hash, err := hash.NewAdapterFromHash(linkHash).FromMultiBytes([][]byte{
+		linkHash.Bytes(),
 		[]byte(app.results),
-		[]byte(strconv.Itoa(app.createdOn.Second())),
+		[]byte(strconv.FormatInt(createdOn.UnixNano()/int64(createdOnPrecision), 10)),
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return createLink(*hash, app.link, app.results, *app.createdOn), nil
+	return createLink(*hash, app.link, app.results, createdOn), nil
 }
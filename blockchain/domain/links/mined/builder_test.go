@@ -0,0 +1,104 @@
+package mined
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func TestBuilder_hashesCreatedOn_Success(t *testing.T) {
+	link := links.CreateLinkForTests()
+	createdOn := time.Date(2020, time.January, 1, 10, 20, 30, 400500600, time.UTC)
+	first, err := NewBuilder().Create().WithLink(link).WithResults("0").CreatedOn(createdOn).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !first.CreatedOn().Equal(createdOn.Truncate(time.Millisecond)) {
+		t.Errorf("the creation time was expected to be truncated to the millisecond, %s returned", first.CreatedOn().String())
+		return
+	}
+
+	// the same second of another minute must not hash the same:
+	second, err := NewBuilder().Create().WithLink(link).WithResults("0").CreatedOn(createdOn.Add(time.Minute)).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if first.Hash().Compare(second.Hash()) {
+		t.Errorf("the mined links created on different minutes were expected to have different hashes")
+		return
+	}
+
+	// the persisted creation time must hash the same:
+	rebuilt, err := NewBuilder().Create().WithLink(link).WithResults("0").CreatedOn(first.CreatedOn()).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !first.Hash().Compare(rebuilt.Hash()) {
+		t.Errorf("the mined link rebuilt from its creation time was expected to have the same hash")
+		return
+	}
+}
+
+func TestBuilder_withHashAlgorithm_Success(t *testing.T) {
+	hashAdapter, err := hash.NewAdapterWithAlgorithm(hash.SHA256)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hsh, err := hashAdapter.Hash([]byte("some data"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	block, err := blocks.NewBuilder().Create().WithHashes([]hash.Hash{*hsh}).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	root, err := block_mined.NewBuilder().Create().WithBlock(block).WithResults("0").Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	rootHash := root.Hash()
+	link, err := links.NewBuilder().Create().WithPreviousMinedLink(rootHash).WithNextBlock(block).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	minedLink, err := NewBuilder().Create().WithLink(link).WithResults("0").Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// every hash of the chain must be hashed with the algorithm of its blocks:
+	for _, oneHash := range []hash.Hash{root.Hash(), link.Hash(), minedLink.Hash()} {
+		if oneHash.Algorithm() != hash.SHA256 {
+			t.Errorf("the hash (%s) was expected to be hashed with %s", oneHash.String(), hash.SHA256.String())
+			return
+		}
+	}
+
+	// a link cannot mix the algorithms of its previous mined link and next block:
+	_, err = links.NewBuilder().Create().WithPreviousMinedLink(rootHash).WithNextBlock(blocks.CreateBlockForTests()).Now()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -1,10 +1,51 @@
 package mined
 
-import "github.com/deepvalue-network/software/libs/hash"
+import (
+	"math"
 
-func minerHash(results string, hash hash.Hash, hashAdapter hash.Adapter) (*hash.Hash, error) {
-	return hashAdapter.FromMultiBytes([][]byte{
+	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func calculateDifficulty(gen genesis.Genesis, previous []Link) float64 {
+	difficulty := float64(gen.LinkDifficulty())
+	window := int(gen.RetargetWindow())
+	if window <= 0 {
+		return difficulty
+	}
+
+	for end := window; end <= len(previous); end += window {
+		difficulty = retarget(gen, difficulty, previous[end-window:end])
+	}
+
+	return difficulty
+}
+
+func nextDifficulty(gen genesis.Genesis, difficulty float64, previous []Link) float64 {
+	window := int(gen.RetargetWindow())
+	if window <= 0 || len(previous) <= 0 || len(previous)%window != 0 {
+		return difficulty
+	}
+
+	return retarget(gen, difficulty, previous[len(previous)-window:])
+}
+
+// retarget retargets the difficulty by the ratio of the targeted and actual intervals of the mined links of a window
+func retarget(gen genesis.Genesis, difficulty float64, window []Link) float64 {
+	targeted := gen.TargetLinkInterval().Seconds() * float64(len(window)-1)
+	actual := window[len(window)-1].CreatedOn().Sub(window[0].CreatedOn()).Seconds()
+	ratio := maxRetargetRatio
+	if actual > 0 {
+		ratio = math.Min(math.Max(targeted/actual, 1/maxRetargetRatio), maxRetargetRatio)
+	}
+
+	// each step of difficulty multiplies the expected work by 16:
+	return genesis.RoundDifficulty(math.Max(difficulty+math.Log(ratio)/math.Log(16), minDifficulty))
+}
+
+func minerHash(results string, hsh hash.Hash) (*hash.Hash, error) {
+	return hash.NewAdapterFromHash(hsh).FromMultiBytes([][]byte{
 		[]byte(results),
-		hash.Bytes(),
+		hsh.Bytes(),
 	})
 }
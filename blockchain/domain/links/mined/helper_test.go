@@ -0,0 +1,100 @@
+package mined
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+)
+
+func TestCalculateDifficulty_retargets_Success(t *testing.T) {
+	cases := []struct {
+		name           string
+		linkDifficulty uint
+		window         uint
+		intervals      []time.Duration
+		expected       float64
+	}{
+		{name: "no retarget window", linkDifficulty: 4, window: 0, intervals: []time.Duration{time.Second, time.Second}, expected: 4},
+		{name: "no previous mined link", linkDifficulty: 4, window: 3, intervals: []time.Duration{}, expected: 4},
+		{name: "incomplete window", linkDifficulty: 4, window: 3, intervals: []time.Duration{time.Second}, expected: 4},
+		{name: "on target", linkDifficulty: 4, window: 3, intervals: []time.Duration{10 * time.Second, 10 * time.Second}, expected: 4},
+		{name: "twice faster", linkDifficulty: 4, window: 3, intervals: []time.Duration{5 * time.Second, 5 * time.Second}, expected: 4.25},
+		{name: "twice slower", linkDifficulty: 4, window: 3, intervals: []time.Duration{20 * time.Second, 20 * time.Second}, expected: 3.75},
+		{name: "faster than the clamp", linkDifficulty: 4, window: 3, intervals: []time.Duration{time.Second, time.Second}, expected: 4.5},
+		{name: "same timestamps", linkDifficulty: 4, window: 3, intervals: []time.Duration{0, 0}, expected: 4.5},
+		{name: "slower than the clamp", linkDifficulty: 4, window: 3, intervals: []time.Duration{time.Hour, time.Hour}, expected: 3.5},
+		{name: "under the minimum", linkDifficulty: 1, window: 3, intervals: []time.Duration{time.Hour, time.Hour}, expected: minDifficulty},
+		{
+			name:           "two windows, the last one incomplete",
+			linkDifficulty: 4,
+			window:         3,
+			intervals:      []time.Duration{time.Second, time.Second, time.Second, time.Second, time.Second, time.Second},
+			expected:       5,
+		},
+	}
+
+	for _, oneCase := range cases {
+		gen, err := genesis.NewBuilder().Create().
+			WithMiningValue(genesis.DefaultMiningValue).
+			WithBlockBaseDifficulty(1).
+			WithBlockIncreasePerHashDifficulty(0.0001).
+			WithLinkDifficulty(oneCase.linkDifficulty).
+			WithTargetLinkInterval(10 * time.Second).
+			WithRetargetWindow(oneCase.window).
+			Now()
+
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		previous := createMinedLinksForTests(t, oneCase.intervals)
+		if previous == nil {
+			return
+		}
+
+		difficulty := calculateDifficulty(gen, previous)
+		if difficulty != oneCase.expected {
+			t.Errorf("%s: the difficulty was expected to be %g, %g returned", oneCase.name, oneCase.expected, difficulty)
+			return
+		}
+
+		// carrying the difficulty forward must retarget the same way:
+		carried := calculateDifficulty(gen, []Link{})
+		for index := 0; index <= len(previous); index++ {
+			carried = nextDifficulty(gen, carried, previous[:index])
+		}
+
+		if carried != difficulty {
+			t.Errorf("%s: the carried difficulty was expected to be %g, %g returned", oneCase.name, difficulty, carried)
+			return
+		}
+	}
+}
+
+// createMinedLinksForTests creates the mined links created after the given intervals, the first one created now
+func createMinedLinksForTests(t *testing.T, intervals []time.Duration) []Link {
+	createdOn := time.Now().UTC()
+	if len(intervals) <= 0 {
+		return []Link{}
+	}
+
+	out := []Link{}
+	for index := 0; index <= len(intervals); index++ {
+		if index > 0 {
+			createdOn = createdOn.Add(intervals[index-1])
+		}
+
+		ins, err := NewBuilder().Create().WithLink(links.CreateLinkForTests()).WithResults("0").CreatedOn(createdOn).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return nil
+		}
+
+		out = append(out, ins)
+	}
+
+	return out
+}
@@ -3,22 +3,49 @@ package mined
 import (
 	"time"
 
-	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
 	"github.com/deepvalue-network/software/blockchain/domain/links"
 	"github.com/deepvalue-network/software/libs/hash"
 )
 
+// maxRetargetRatio represents the max ratio the expected work of the mined links can be multiplied or divided by, on
+// each retarget
+const maxRetargetRatio = 4.0
+
+// minDifficulty represents the min difficulty a mined link can be retargeted to
+const minDifficulty = 1.0
+
+// maxFutureDrift represents how far in the future a mined link can be created, compared to the validating clock
+const maxFutureDrift = time.Hour * 2
+
+// createdOnPrecision represents the precision of the creation time of a mined link, which is hashed and persisted.
+// The mined links hashed before the whole creation time was part of their hash cannot be migrated: their hash is part
+// of the following link, whose mining results are then invalid, so their chains must be synced or mined again
+const createdOnPrecision = time.Millisecond
+
+// CalculateDifficulty calculates the difficulty of the mined link following the given mined links, ordered from the one
+// following the root.  The link difficulty of the genesis is retargeted after each complete retarget window, so that
+// the mined links are created at the target link interval
+func CalculateDifficulty(gen genesis.Genesis, previous []Link) float64 {
+	return calculateDifficulty(gen, previous)
+}
+
+// NextDifficulty calculates the difficulty of the mined link following the given mined links, from the difficulty of
+// the last one; it only retargets the last retarget window, so that the difficulties of a chain are calculated in order
+// without recalculating them from its root
+func NextDifficulty(gen genesis.Genesis, difficulty float64, previous []Link) float64 {
+	return nextDifficulty(gen, difficulty, previous)
+}
+
 // NewValidator creates a new validator instance
 func NewValidator(minedLinkRepository Repository) Validator {
-	hashAdapter := hash.NewAdapter()
-	return createValidator(hashAdapter, minedLinkRepository)
+	return createValidator(minedLinkRepository)
 }
 
 // NewBuilder creates a new builder instance
 func NewBuilder() Builder {
-	hashAdapter := hash.NewAdapter()
-	return createBuilder(hashAdapter)
+	return createBuilder()
 }
 
 // NewPointer creates a new pointer instance
@@ -28,7 +55,7 @@ func NewPointer() *link {
 
 // Validator represents a mined link validator
 type Validator interface {
-	Execute(gen genesis.Genesis, minedLink Link, root blocks.Block) (uint, uint, error)
+	Execute(gen genesis.Genesis, minedLink Link, root block_mined.Block) (uint, uint, error)
 }
 
 // Builder represenst the link builder
@@ -52,6 +79,7 @@ type Link interface {
 type Repository interface {
 	Head() (Link, error)
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(minedLinkHash hash.Hash) (Link, error)
 	RetrieveByLinkHash(linkHash hash.Hash) (Link, error)
 }
@@ -59,6 +87,7 @@ type Repository interface {
 // Service represents a link service
 type Service interface {
 	Insert(minedLink Link) error
+	UpdateHead(minedLink Link) error
 	Delete(minedLink Link) error
 	DeleteByLink(link links.Link) error
 }
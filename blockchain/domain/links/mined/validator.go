@@ -3,69 +3,89 @@ package mined
 import (
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
-	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
-	"github.com/deepvalue-network/software/libs/hash"
 )
 
 type validator struct {
-	hashAdapter         hash.Adapter
 	minedLinkRepository Repository
 }
 
 func createValidator(
-	hashAdapter hash.Adapter,
 	minedLinkRepository Repository,
 ) Validator {
 	out := validator{
-		hashAdapter:         hashAdapter,
 		minedLinkRepository: minedLinkRepository,
 	}
 
 	return &out
 }
 
-// Execute executes the validator
-func (app *validator) Execute(gen genesis.Genesis, minedLink Link, root blocks.Block) (uint, uint, error) {
-	// fetch the difficulty:
-	diff := int(gen.LinkDifficulty())
+// Execute executes the validator; the mined links are validated from the one following the root mined block, since the
+// difficulty of each mined link is retargeted using the ones before it
+func (app *validator) Execute(gen genesis.Genesis, minedLink Link, root block_mined.Block) (uint, uint, error) {
+	// retrieve the previous mined links, back to the root:
+	list := []Link{minedLink}
+	for {
+		prevMinedLinkHash := list[0].Link().PrevMinedLink()
+		if prevMinedLinkHash.Compare(root.Hash()) {
+			break
+		}
 
-	// create the difficulty prefix:
-	prefix := ""
-	miningValue := gen.MiningValue()
-	for i := 0; i < diff; i++ {
-		prefix = fmt.Sprintf("%s%d", prefix, miningValue)
+		prevMinedLink, err := app.minedLinkRepository.Retrieve(prevMinedLinkHash)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		list = append([]Link{prevMinedLink}, list...)
 	}
 
-	// hash the results:
-	results := minedLink.Results()
-	linkHash := minedLink.Link().Hash()
-	resultsHash, err := minerHash(results, linkHash, app.hashAdapter)
-	if err != nil {
-		return 0, 0, err
+	// validate the mined links in order, carrying their retargeted difficulty forward:
+	amountHashes := uint(0)
+	prevCreatedOn := root.CreatedOn()
+	diff := calculateDifficulty(gen, []Link{})
+	for index, oneMinedLink := range list {
+		diff = nextDifficulty(gen, diff, list[:index])
+		err := app.validate(gen, oneMinedLink, diff, prevCreatedOn)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		amountHashes += uint(len(oneMinedLink.Link().NextBlock().Hashes()))
+		prevCreatedOn = oneMinedLink.CreatedOn()
 	}
 
-	// make sure the results contains the right diffiulty:
-	if !strings.HasPrefix(resultsHash.String(), prefix) {
-		str := fmt.Sprintf("the prefix (%s) was expected on the result hash (hash: %s)", prefix, resultsHash.String())
-		return 0, 0, errors.New(str)
+	return amountHashes, uint(len(list)), nil
+}
+
+func (app *validator) validate(gen genesis.Genesis, minedLink Link, diff float64, prevCreatedOn time.Time) error {
+	// the timestamps the difficulty is retargeted with must be ordered, and not in the future:
+	createdOn := minedLink.CreatedOn()
+	if createdOn.Before(prevCreatedOn.Truncate(createdOnPrecision)) {
+		str := fmt.Sprintf("the mined link (hash: %s) was created (%s) before the mined link or block preceding it (%s)", minedLink.Hash().String(), createdOn.String(), prevCreatedOn.String())
+		return errors.New(str)
 	}
 
-	// execute the previous mined link:
-	prevMinedLinkHash := minedLink.Link().PrevMinedLink()
-	prevMinedLink, err := app.minedLinkRepository.Retrieve(prevMinedLinkHash)
-	if err != nil {
-		return 0, 0, err
+	if createdOn.After(time.Now().UTC().Add(maxFutureDrift)) {
+		str := fmt.Sprintf("the mined link (hash: %s) was created in the future (%s)", minedLink.Hash().String(), createdOn.String())
+		return errors.New(str)
 	}
 
-	prevLinkTotalHashes, prevLinkHeight, err := app.Execute(gen, prevMinedLink, root)
+	// hash the results:
+	results := minedLink.Results()
+	linkHash := minedLink.Link().Hash()
+	resultsHash, err := minerHash(results, linkHash)
 	if err != nil {
-		return 0, 0, err
+		return err
+	}
+
+	// make sure the results contains the right diffiulty:
+	if !genesis.IsMined(*resultsHash, gen.MiningValue(), diff) {
+		str := fmt.Sprintf("the result hash (hash: %s) was expected to be mined at a difficulty of %g", resultsHash.String(), diff)
+		return errors.New(str)
 	}
 
-	amountHashes := uint(len(minedLink.Link().NextBlock().Hashes())) + prevLinkTotalHashes
-	height := prevLinkHeight + 1
-	return amountHashes, height, nil
+	return nil
 }
@@ -0,0 +1,87 @@
+package mined
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+)
+
+func TestValidator_timestamps_Success(t *testing.T) {
+	gen, err := genesis.NewBuilder().Create().
+		WithMiningValue(genesis.DefaultMiningValue).
+		WithBlockBaseDifficulty(1).
+		WithBlockIncreasePerHashDifficulty(0.0001).
+		WithLinkDifficulty(1).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	root := block_mined.CreateBlockForTests()
+	link, err := links.NewBuilder().Create().WithPreviousMinedLink(root.Hash()).WithNextBlock(blocks.CreateBlockForTests()).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	results := mineForTests(t, gen, link)
+	if results == "" {
+		return
+	}
+
+	cases := []struct {
+		name      string
+		createdOn time.Time
+		isValid   bool
+	}{
+		{name: "same time as the root", createdOn: root.CreatedOn(), isValid: true},
+		{name: "after the root", createdOn: root.CreatedOn().Add(time.Second), isValid: true},
+		{name: "before the root", createdOn: root.CreatedOn().Add(time.Second * -1), isValid: false},
+		{name: "within the future drift", createdOn: time.Now().UTC().Add(maxFutureDrift - time.Minute), isValid: true},
+		{name: "past the future drift", createdOn: time.Now().UTC().Add(maxFutureDrift + time.Minute), isValid: false},
+	}
+
+	validator := createValidator(nil)
+	for _, oneCase := range cases {
+		minedLink, err := NewBuilder().Create().WithLink(link).WithResults(results).CreatedOn(oneCase.createdOn).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		_, _, err = validator.Execute(gen, minedLink, root)
+		if oneCase.isValid && err != nil {
+			t.Errorf("%s: the returned error was expected to be nil, error returned: %s", oneCase.name, err.Error())
+			return
+		}
+
+		if !oneCase.isValid && err == nil {
+			t.Errorf("%s: the error was expected to be valid, nil returned", oneCase.name)
+			return
+		}
+	}
+}
+
+// mineForTests returns the first results that mine the link at the difficulty of the genesis
+func mineForTests(t *testing.T, gen genesis.Genesis, link links.Link) string {
+	difficulty := calculateDifficulty(gen, []Link{})
+	for index := 0; ; index++ {
+		results := strconv.Itoa(index)
+		resultsHash, err := minerHash(results, link.Hash())
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return ""
+		}
+
+		if genesis.IsMined(*resultsHash, gen.MiningValue(), difficulty) {
+			return results
+		}
+	}
+}
@@ -7,8 +7,7 @@ import (
 
 // NewBuilder creates a new builder instance
 func NewBuilder() Builder {
-	hashAdapter := hash.NewAdapter()
-	return createBuilder(hashAdapter)
+	return createBuilder()
 }
 
 // NewPointer creates a new pointer instance
@@ -36,6 +35,7 @@ type Link interface {
 // Repository represents a link repository
 type Repository interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	Retrieve(linkHash hash.Hash) (Link, error)
 	RetrieveByBlockHash(blockHash hash.Hash) (Link, error)
 	RetrieveByMinedLinkHash(minedLinkHash hash.Hash) (Link, error)
@@ -7,7 +7,7 @@ import (
 
 // CreateLinkForTests creates link instance for tests
 func CreateLinkForTests() Link {
-	prevLinkHash, err := hash.NewAdapter().FromBytes([]byte("prev link hash"))
+	prevLinkHash, err := hash.NewAdapter().Hash([]byte("prev link hash"))
 	if err != nil {
 		panic(err)
 	}
@@ -24,6 +24,16 @@ func newBlock(
 		hashes = append(hashes, oneLeaf.Head())
 	}
 
+	// remove the leaves that only pad the tree to a power of two, since the tree pads them back:
+	filler, err := hash.NewAdapter().Hash(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(hashes) > 1 && hashes[len(hashes)-1].Compare(*filler) {
+		hashes = hashes[:len(hashes)-1]
+	}
+
 	return blocks.NewBuilder().
 		Create().
 		WithHashes(hashes).
@@ -96,21 +106,7 @@ func newPeers(
 	return builder.Now()
 }
 
-func newGenesis(
-	miningValue uint8,
-	blockBaseDifficulty uint,
-	blockIncreasePerHashDifficulty float64,
-	linkDifficulty uint,
-) (genesis.Genesis, error) {
-	return genesis.NewBuilder().Create().
-		WithMiningValue(miningValue).
-		WithBlockBaseDifficulty(blockBaseDifficulty).
-		WithBlockIncreasePerHashDifficulty(blockIncreasePerHashDifficulty).
-		WithLinkDifficulty(linkDifficulty).
-		Now()
-}
-
-func newChain(
+func (app *disk) newChain(
 	id *uuid.UUID,
 	peers peers.Peers,
 	root mined_block.Block,
@@ -118,7 +114,7 @@ func newChain(
 	createdOn time.Time,
 	head mined_link.Link,
 ) (chains.Chain, error) {
-	builder := chains.NewBuilder(internalPeerSyncInterval).
+	builder := chains.NewBuilder(app.peerSyncInterval).
 		Create().
 		WithID(id).
 		WithPeers(peers).
@@ -126,19 +122,33 @@ func newChain(
 		WithGenesis(gen).
 		CreatedOn(createdOn)
 
-	if head != nil {
-		builder.WithHead(head)
+	chain, err := builder.Now()
+	if err != nil {
+		return nil, err
+	}
+
+	if head == nil {
+		return chain, nil
 	}
 
-	if head != nil {
-		// retrieve previous version of chain:
-		prevChain, err := internalRepositoryChain.Retrieve(id)
+	// retrieve the mined links from the root to the head:
+	list := []mined_link.Link{head}
+	for {
+		prev, err := app.repositoryLinkMined.Retrieve(list[0].Link().PrevMinedLink())
 		if err != nil {
-			return nil, err
+			break
 		}
 
-		builder.WithOriginal(prevChain)
+		list = append([]mined_link.Link{prev}, list...)
 	}
 
-	return builder.Now()
+	// apply the mined links on the chain, in order to compute its height and amount of hashes:
+	for _, oneMinedLink := range list {
+		chain, err = chains.NewBuilder(app.peerSyncInterval).Create().WithOriginal(chain).WithHead(oneMinedLink).CreatedOn(createdOn).Now()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return chain, nil
 }
@@ -0,0 +1,388 @@
+package disks
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/libs/events"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	files_disks "github.com/deepvalue-network/software/libs/files/infrastructure/disks"
+	"github.com/deepvalue-network/software/libs/files/infrastructure/kvstores"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+type disk struct {
+	peerSyncInterval     time.Duration
+	eventManager         events.Manager
+	hydroAdapter         hydro.Adapter
+	store                kvstores.Store
+	repositoryBlock      blocks.Repository
+	repositoryBlockMined block_mined.Repository
+	repositoryLink       links.Repository
+	repositoryLinkMined  link_mined.Repository
+	repositoryChain      chains.Repository
+	serviceBlock         blocks.Service
+	serviceBlockMined    block_mined.Service
+	serviceLink          links.Service
+	serviceLinkMined     link_mined.Service
+	serviceChain         chains.Service
+	validatorChain       chains.Validator
+}
+
+func createDisk(
+	basePath string,
+	fileMode os.FileMode,
+	peerSyncInterval time.Duration,
+	format hydro.Format,
+	backend Backend,
+) (*disk, error) {
+	out := disk{
+		peerSyncInterval: peerSyncInterval,
+	}
+
+	// the hydrated entities resolve the instances they reference in the repositories of this disk:
+	hydroAdapter, err := createHydroAdapter(&out)
+	if err != nil {
+		return nil, err
+	}
+
+	out.hydroAdapter = hydroAdapter
+	newRepository, newService, err := out.initBackend(basePath, fileMode, format, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	// init events:
+	eventManager, err := out.initEventManager()
+	if err != nil {
+		return nil, err
+	}
+
+	out.eventManager = eventManager
+
+	// create the block repository:
+	blockPtr := new(EntityHydratedBlock)
+	blockBasePath := filepath.Join(basePath, blocksDirName)
+	repositoryFileBlock := newRepository(blockBasePath, blockPtr)
+	repositoryBlock := NewRepositoryBlock(repositoryFileBlock)
+
+	// create the mined block repository:
+	minedBlockPtr := new(EntityHydratedBlockMined)
+	minedBlockBasePath := filepath.Join(basePath, minedBlocksDirName)
+	pointerMinedBlockBasePath := filepath.Join(basePath, minedBlockPointersDirName)
+	repositoryFileMinedBlock := newRepository(minedBlockBasePath, minedBlockPtr)
+	repositoryPointerFileMinedBlock := newRepository(pointerMinedBlockBasePath, nil)
+	repositoryBlockMined := NewRepositoryBlockMined(repositoryFileMinedBlock, repositoryPointerFileMinedBlock)
+
+	// create the link repository:
+	linkPtr := new(EntityHydratedLink)
+	linkBasePath := filepath.Join(basePath, linksDirName)
+	blockPointerLinkBasePath := filepath.Join(basePath, blockLinkPointersDirName)
+	minedLinkPointerLinkBasePath := filepath.Join(basePath, minedLinkLinkPointersDirName)
+	repositoryFileLink := newRepository(linkBasePath, linkPtr)
+	repositoryBlockPointerFileLink := newRepository(blockPointerLinkBasePath, nil)
+	repositoryMinedLinkPointerFileLink := newRepository(minedLinkPointerLinkBasePath, nil)
+	linkRepository := NewRepositoryLink(repositoryFileLink, repositoryBlockPointerFileLink, repositoryMinedLinkPointerFileLink)
+
+	// create the link mined repository:
+	minedLinkPtr := new(EntityHydratedLinkMined)
+	minedLinkBasePath := filepath.Join(basePath, minedLinksDirName)
+	linkPointerMinedLinkBasePath := filepath.Join(basePath, linkMinedLinkPointersDirName)
+	headPointerMinedLinkBasePath := filepath.Join(basePath, headMinedLinkPointerDirName)
+	repositoryFileLinkMined := newRepository(minedLinkBasePath, minedLinkPtr)
+	linkPointerFileRepository := newRepository(linkPointerMinedLinkBasePath, nil)
+	headPointerFileRepository := newRepository(headPointerMinedLinkBasePath, nil)
+	minedLinkRepository := NewRepositoryLinkMined(repositoryFileLinkMined, linkPointerFileRepository, headPointerFileRepository, headFileName)
+
+	// create the chain repository:
+	chainLinkPtr := new(EntityHydratedChain)
+	chainBasePath := filepath.Join(basePath, chainsDirName)
+	repositoryFileChain := newRepository(chainBasePath, chainLinkPtr)
+	chainRepository := NewRepositoryChain(repositoryFileChain)
+
+	// repository assign:
+	out.repositoryBlock = repositoryBlock
+	out.repositoryBlockMined = repositoryBlockMined
+	out.repositoryLink = linkRepository
+	out.repositoryLinkMined = minedLinkRepository
+	out.repositoryChain = chainRepository
+
+	// create the block service:
+	blockFileService := newService(blockBasePath)
+	blockService := NewServiceBlock(eventManager, blockFileService)
+
+	// create the mined block service:
+	minedBlockFileService := newService(minedBlockBasePath)
+	minedBlockPointerFileService := newService(pointerMinedBlockBasePath)
+	minedBlockService := NewServiceBlockMined(eventManager, repositoryBlockMined, repositoryBlock, blockService, minedBlockFileService, minedBlockPointerFileService)
+
+	// create the link service:
+	linkFileService := newService(linkBasePath)
+	linkBlockPointerFileService := newService(blockPointerLinkBasePath)
+	linkMinedLinkPointerFileService := newService(minedLinkPointerLinkBasePath)
+	linkService := NewServiceLink(eventManager, linkRepository, repositoryBlock, blockService, linkFileService, linkBlockPointerFileService, linkMinedLinkPointerFileService)
+
+	// create the mined link service:
+	minedLinkFileService := newService(minedLinkBasePath)
+	minedLinkLinkPointerFileService := newService(linkPointerMinedLinkBasePath)
+	headPointerFileService := newService(headPointerMinedLinkBasePath)
+	minedLinkService := NewServiceLinkMined(eventManager, minedLinkRepository, linkRepository, linkService, minedLinkFileService, minedLinkLinkPointerFileService, headPointerFileService, headFileName)
+
+	// create the chain service:
+	chainFileService := newService(chainBasePath)
+	minedBlockValidator := block_mined.NewValidator()
+	minedLinkValidator := link_mined.NewValidator(minedLinkRepository)
+	chainValidator := chains.NewValidator(minedBlockValidator, minedLinkValidator, chainRepository)
+	chainService := NewServiceChain(eventManager, chainValidator, minedBlockService, minedLinkService, chainFileService)
+
+	// service assign:
+	out.serviceBlock = blockService
+	out.serviceBlockMined = minedBlockService
+	out.serviceLink = linkService
+	out.serviceLinkMined = minedLinkService
+	out.serviceChain = chainService
+
+	// validator assign:
+	out.validatorChain = chainValidator
+	return &out, nil
+}
+
+// DeadLetters returns the latest failed deliveries of the events of the disk
+func (app *disk) DeadLetters() []events.DeadLetter {
+	return app.eventManager.DeadLetters()
+}
+
+// RepositoryApplication creates a new repository application on the repositories of the disk
+func (app *disk) RepositoryApplication() repositories.Application {
+	return repositories.NewApplication(
+		repositories.NewBlock(app.repositoryBlock),
+		repositories.NewMinedBlock(app.repositoryBlockMined),
+		repositories.NewLink(app.repositoryLink),
+		repositories.NewMinedLink(app.repositoryLinkMined),
+		repositories.NewChain(app.repositoryChain),
+	)
+}
+
+// ServiceApplication creates a new service application on the repositories and services of the disk
+func (app *disk) ServiceApplication(
+	remoteBuilder repositories.RemoteBuilder,
+	publisher services.Publisher,
+	bootstrapPeers []peers.Peer,
+	orphanDepth uint,
+) services.Application {
+	repositoryApp := app.RepositoryApplication()
+	minerApp := services.NewMiner()
+	blockApp := services.NewBlock(app.repositoryBlock, app.serviceBlock)
+	minedBlockApp := services.NewMinedBlock(app.serviceBlockMined, repositoryApp.MinedBlock(), repositoryApp.Block(), minerApp)
+	linkApp := services.NewLink(app.serviceLink, repositoryApp.Block(), repositoryApp.Link(), repositoryApp.MinedLink())
+	minedLinkApp := services.NewMinedLink(app.serviceLinkMined, repositoryApp.Link(), repositoryApp.MinedLink(), minerApp)
+	chainApp := services.NewChain(
+		app.peerSyncInterval,
+		remoteBuilder,
+		app.serviceChain,
+		app.validatorChain,
+		blockApp,
+		repositoryApp.Block(),
+		minedBlockApp,
+		minedLinkApp,
+		app.serviceLink,
+		repositoryApp.Link(),
+		app.serviceLinkMined,
+		repositoryApp.MinedLink(),
+		repositoryApp.Chain(),
+		bootstrapPeers,
+		orphanDepth,
+	)
+
+	gossipApp := services.NewGossip(
+		publisher,
+		chainApp,
+		app.serviceBlock,
+		repositoryApp.Block(),
+		app.repositoryLink,
+		app.serviceBlockMined,
+		repositoryApp.MinedBlock(),
+		repositoryApp.Chain(),
+		bootstrapPeers,
+	)
+
+	return services.NewApplication(blockApp, minedBlockApp, linkApp, minedLinkApp, chainApp, gossipApp)
+}
+
+// Close closes the key-value store of the disk, when its backend is BackendKeyValue
+func (app *disk) Close() error {
+	if app.store == nil {
+		return nil
+	}
+
+	err := app.store.Close()
+	app.store = nil
+	return err
+}
+
+// initBackend returns the funcs that create the repositories and services of the given backend, by path
+func (app *disk) initBackend(
+	basePath string,
+	fileMode os.FileMode,
+	format hydro.Format,
+	backend Backend,
+) (func(path string, ptr interface{}) files.Repository, func(path string) files.Service, error) {
+	if backend == BackendKeyValue {
+		err := os.MkdirAll(basePath, fileMode)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		store, err := kvstores.NewStore(filepath.Join(basePath, storeFileName), fileMode)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		app.store = store
+		newRepository := func(path string, ptr interface{}) files.Repository {
+			return kvstores.NewRepository(app.hydroAdapter, store, filepath.Base(path), ptr)
+		}
+
+		newService := func(path string) files.Service {
+			return kvstores.NewService(app.hydroAdapter, store, filepath.Base(path), format)
+		}
+
+		return newRepository, newService, nil
+	}
+
+	newRepository := func(path string, ptr interface{}) files.Repository {
+		return files_disks.NewRepository(app.hydroAdapter, path, ptr)
+	}
+
+	newService := func(path string) files.Service {
+		return files_disks.NewServiceWithFormat(app.hydroAdapter, path, fileMode, format)
+	}
+
+	return newRepository, newService, nil
+}
+
+// createHydroAdapter creates the adapter of the hydrated entities, that resolve the instances they reference in the
+// repositories of the disk
+func createHydroAdapter(app *disk) (hydro.Adapter, error) {
+	// create bridges:
+	blockBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*blocks.Block)(nil)).
+		WithDehydratedConstructor(newBlock).
+		WithDehydratedPointer(blocks.NewPointer()).
+		WithHydratedPointer(new(EntityHydratedBlock)).
+		OnHydrate(blockOnHydrateEventFn).
+		OnDehydrate(blockOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	blockMinedBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*block_mined.Block)(nil)).
+		WithDehydratedConstructor(newBlockMined).
+		WithDehydratedPointer(block_mined.NewPointer()).
+		WithHydratedPointer(new(EntityHydratedBlockMined)).
+		OnHydrate(blockMinedOnHydrateEventFn).
+		OnDehydrate(app.blockMinedOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	linkBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*links.Link)(nil)).
+		WithDehydratedConstructor(newLink).
+		WithDehydratedPointer(links.NewPointer()).
+		WithHydratedPointer(new(EntityHydratedLink)).
+		OnHydrate(linkOnHydrateEventFn).
+		OnDehydrate(app.linkOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	minedLinkBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*link_mined.Link)(nil)).
+		WithDehydratedConstructor(newLinkMined).
+		WithDehydratedPointer(link_mined.NewPointer()).
+		WithHydratedPointer(new(EntityHydratedLinkMined)).
+		OnHydrate(linkMinedOnHydrateEventFn).
+		OnDehydrate(app.linkMinedOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	peerBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*peers.Peer)(nil)).
+		WithDehydratedConstructor(newPeer).
+		WithDehydratedPointer(peers.NewPeerPointer()).
+		WithHydratedPointer(createPeerForBridge()).
+		OnHydrate(peerOnHydrateEventFn).
+		OnDehydrate(peerOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	peersBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*peers.Peers)(nil)).
+		WithDehydratedConstructor(newPeers).
+		WithDehydratedPointer(peers.NewPointer()).
+		WithHydratedPointer(createPeersForBridge()).
+		OnHydrate(peersOnHydrateEventFn).
+		OnDehydrate(peersOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	genesisBridge, err := newGenesisBridge()
+	if err != nil {
+		return nil, err
+	}
+
+	chainBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*chains.Chain)(nil)).
+		WithDehydratedConstructor(app.newChain).
+		WithDehydratedPointer(chains.NewPointer()).
+		WithHydratedPointer(new(EntityHydratedChain)).
+		OnHydrate(chainOnHydrateEventFn).
+		OnDehydrate(app.chainOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// build the manager:
+	manager := hydro.NewManagerFactory().Create()
+
+	// register the bridges:
+	manager.Register(blockBridge)
+	manager.Register(blockMinedBridge)
+	manager.Register(linkBridge)
+	manager.Register(minedLinkBridge)
+	manager.Register(peerBridge)
+	manager.Register(peersBridge)
+	manager.Register(genesisBridge)
+	manager.Register(chainBridge)
+
+	// create the adapter:
+	return hydro.NewAdapterBuilder().Create().WithManager(manager).Now()
+}
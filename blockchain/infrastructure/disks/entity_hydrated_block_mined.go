@@ -36,7 +36,7 @@ func blockMinedOnHydrateEventFn(ins interface{}, fieldName string, structName st
 	return nil, nil
 }
 
-func blockMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
+func (app *disk) blockMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "Block" {
 		if strHash, ok := ins.(string); ok {
 			hsh, err := hash.NewAdapter().FromString(strHash)
@@ -44,7 +44,7 @@ func blockMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName
 				return nil, err
 			}
 
-			return internalRepositoryBlock.Retrieve(*hsh)
+			return app.repositoryBlock.Retrieve(*hsh)
 		}
 	}
 
@@ -22,26 +22,26 @@ func TestHydrate_blockMined_Success(t *testing.T) {
 	minedBlock := blocks_mined.CreateBlockForTests()
 
 	// save the block:
-	err := internalServiceBlockMined.Insert(minedBlock)
+	err := internalDisk.serviceBlockMined.Insert(minedBlock)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
 	// retrieve the block:
-	retMinedBlock, err := internalRepositoryBlockMined.Retrieve(minedBlock.Hash())
+	retMinedBlock, err := internalDisk.repositoryBlockMined.Retrieve(minedBlock.Hash())
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	hydrated, err := internalHydroAdapter.Hydrate(minedBlock)
+	hydrated, err := internalDisk.hydroAdapter.Hydrate(minedBlock)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	retHydrated, err := internalHydroAdapter.Hydrate(retMinedBlock)
+	retHydrated, err := internalDisk.hydroAdapter.Hydrate(retMinedBlock)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
@@ -67,14 +67,14 @@ func TestHydrate_blockMined_insert_deleteBlock_expectBlockMinedDeleted_Success(t
 	minedBlock := blocks_mined.CreateBlockForTests()
 
 	// save the mined block:
-	err := internalServiceBlockMined.Insert(minedBlock)
+	err := internalDisk.serviceBlockMined.Insert(minedBlock)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
 	// retrieve the mined block:
-	retFirstMinedBlock, err := internalRepositoryBlockMined.Retrieve(minedBlock.Hash())
+	retFirstMinedBlock, err := internalDisk.repositoryBlockMined.Retrieve(minedBlock.Hash())
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
@@ -87,14 +87,14 @@ func TestHydrate_blockMined_insert_deleteBlock_expectBlockMinedDeleted_Success(t
 	}
 
 	// delete the underlying block:
-	err = internalServiceBlock.Delete(minedBlock.Block())
+	err = internalDisk.serviceBlock.Delete(minedBlock.Block())
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
 	// retrieve the mined block again, expect an error:
-	_, err = internalRepositoryBlockMined.Retrieve(minedBlock.Hash())
+	_, err = internalDisk.repositoryBlockMined.Retrieve(minedBlock.Hash())
 	if err == nil {
 		t.Errorf("the retrieval of the mined block was expected to return an error, nil returned")
 		return
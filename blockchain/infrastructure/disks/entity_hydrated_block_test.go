@@ -1,12 +1,15 @@
 package disks
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	"github.com/deepvalue-network/software/libs/hydro"
 )
 
 func TestHydrate_block_Success(t *testing.T) {
@@ -22,26 +25,26 @@ func TestHydrate_block_Success(t *testing.T) {
 	block := blocks.CreateBlockForTests()
 
 	// save the block:
-	err := internalServiceBlock.Insert(block)
+	err := internalDisk.serviceBlock.Insert(block)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
 	// retrieve the block:
-	retBlock, err := internalRepositoryBlock.Retrieve(block.Tree().Head())
+	retBlock, err := internalDisk.repositoryBlock.Retrieve(block.Tree().Head())
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	hydrated, err := internalHydroAdapter.Hydrate(block)
+	hydrated, err := internalDisk.hydroAdapter.Hydrate(block)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	retHydrated, err := internalHydroAdapter.Hydrate(retBlock)
+	retHydrated, err := internalDisk.hydroAdapter.Hydrate(retBlock)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
@@ -53,3 +56,117 @@ func TestHydrate_block_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestHydrate_block_withBinaryFormat_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	InitWithFormat(basePath, 0777, time.Duration(time.Second), hydro.Binary)
+
+	// save the block:
+	block := blocks.CreateBlockForTests()
+	err := internalDisk.serviceBlock.Insert(block)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the file is binary:
+	head := block.Tree().Head().String()
+	data, err := ioutil.ReadFile(filepath.Join(basePath, blocksDirName, head[0:2], head[2:4], head))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if hydro.FormatOf(data) != hydro.Binary {
+		t.Errorf("the block file was expected to be binary")
+		return
+	}
+
+	// retrieve the block:
+	retBlock, err := internalDisk.repositoryBlock.Retrieve(block.Tree().Head())
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !block.Tree().Head().Compare(retBlock.Tree().Head()) {
+		t.Errorf("the retrieved block is different")
+		return
+	}
+
+	// the same block always produces the same bytes:
+	encoded, err := internalDisk.hydroAdapter.EncodeWithFormat(retBlock, hydro.Binary)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(encoded) != string(data) {
+		t.Errorf("the binary encoding was expected to be stable")
+		return
+	}
+}
+
+func TestHydrate_block_withKeyValueBackend_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	InitWithBackend(basePath, 0777, time.Duration(time.Second), hydro.Binary, BackendKeyValue)
+	defer internalDisk.store.Close()
+
+	// save the block:
+	block := blocks.CreateBlockForTests()
+	err := internalDisk.serviceBlock.Insert(block)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// everything is in the store:
+	if _, err := os.Stat(filepath.Join(basePath, blocksDirName)); !os.IsNotExist(err) {
+		t.Errorf("the blocks directory was not expected to be created")
+		return
+	}
+
+	hashes, err := internalDisk.repositoryBlock.List()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(hashes) != 1 || !hashes[0].Compare(block.Tree().Head()) {
+		t.Errorf("the listed hashes were expected to contain the block")
+		return
+	}
+
+	retBlock, err := internalDisk.repositoryBlock.Retrieve(block.Tree().Head())
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !block.Tree().Head().Compare(retBlock.Tree().Head()) {
+		t.Errorf("the retrieved block is different")
+		return
+	}
+
+	err = internalDisk.serviceBlock.Delete(block)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashes, _ = internalDisk.repositoryBlock.List()
+	if len(hashes) != 0 {
+		t.Errorf("the block was expected to be deleted")
+		return
+	}
+}
@@ -13,8 +13,8 @@ import (
 type EntityHydratedChain struct {
 	ID        string           `json:"id" hydro:"0"`
 	Peers     *HydratedPeers   `json:"peers" hydro:"1"`
-	Genesis   *HydratedGenesis `json:"genesis" hydro:"2"`
-	Root      string           `json:"root_block_mined_hash" hydro:"3"`
+	Genesis   *HydratedGenesis `json:"genesis" hydro:"3"`
+	Root      string           `json:"root_block_mined_hash" hydro:"2"`
 	CreatedOn string           `json:"created_on" hydro:"4"`
 	Head      string           `json:"head_mined_link_hash" hydro:"5"`
 }
@@ -59,7 +59,7 @@ func chainOnHydrateEventFn(ins interface{}, fieldName string, structName string)
 	return nil, nil
 }
 
-func chainOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
+func (app *disk) chainOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "ID" {
 		id, err := uuid.FromString(ins.(string))
 		if err != nil {
@@ -76,7 +76,7 @@ func chainOnDehydrateEventFn(ins interface{}, fieldName string, structName strin
 				return nil, err
 			}
 
-			return internalRepositoryBlock.Retrieve(*hsh)
+			return app.repositoryBlockMined.Retrieve(*hsh)
 		}
 	}
 
@@ -91,12 +91,17 @@ func chainOnDehydrateEventFn(ins interface{}, fieldName string, structName strin
 
 	if fieldName == "Head" {
 		if strHash, ok := ins.(string); ok {
+			// a chain without mined link has no head:
+			if strHash == "" {
+				return nil, nil
+			}
+
 			hsh, err := hash.NewAdapter().FromString(strHash)
 			if err != nil {
 				return nil, err
 			}
 
-			return internalRepositoryLinkMined.Retrieve(*hsh)
+			return app.repositoryLinkMined.Retrieve(*hsh)
 		}
 	}
 
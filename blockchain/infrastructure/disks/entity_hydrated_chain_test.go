@@ -23,7 +23,7 @@ func TestHydrate_chain_Success(t *testing.T) {
 
 	// save the root block:
 	root := chain.Root()
-	err := internalServiceBlockMined.Insert(root)
+	err := internalDisk.serviceBlockMined.Insert(root)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
@@ -32,7 +32,7 @@ func TestHydrate_chain_Success(t *testing.T) {
 	// save the head, if any:
 	if chain.HasHead() {
 		head := chain.Head()
-		err := internalServiceLinkMined.Insert(head)
+		err := internalDisk.serviceLinkMined.Insert(head)
 		if err != nil {
 			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 			return
@@ -40,5 +40,5 @@ func TestHydrate_chain_Success(t *testing.T) {
 	}
 
 	// execute:
-	hydro.VerifyAdapterUsingJSForTests(internalHydroAdapter, chain, t)
+	hydro.VerifyAdapterUsingJSForTests(internalDisk.hydroAdapter, chain, t)
 }
@@ -34,7 +34,7 @@ func linkOnHydrateEventFn(ins interface{}, fieldName string, structName string)
 	return nil, nil
 }
 
-func linkOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
+func (app *disk) linkOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "NextBlock" {
 		if strHash, ok := ins.(string); ok {
 			hsh, err := hash.NewAdapter().FromString(strHash)
@@ -42,7 +42,7 @@ func linkOnDehydrateEventFn(ins interface{}, fieldName string, structName string
 				return nil, err
 			}
 
-			return internalRepositoryBlock.Retrieve(*hsh)
+			return app.repositoryBlock.Retrieve(*hsh)
 		}
 	}
 
@@ -39,7 +39,7 @@ func linkMinedOnHydrateEventFn(ins interface{}, fieldName string, structName str
 	return nil, nil
 }
 
-func linkMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
+func (app *disk) linkMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "Link" {
 		if strHash, ok := ins.(string); ok {
 			hsh, err := hash.NewAdapter().FromString(strHash)
@@ -47,7 +47,7 @@ func linkMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName s
 				return nil, err
 			}
 
-			return internalRepositoryLink.Retrieve(*hsh)
+			return app.repositoryLink.Retrieve(*hsh)
 		}
 	}
 
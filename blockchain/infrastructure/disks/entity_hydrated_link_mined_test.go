@@ -22,26 +22,26 @@ func TestHydrate_linkMined_Success(t *testing.T) {
 	link := link_mined.CreateLinkForTests()
 
 	// save the link:
-	err := internalServiceLinkMined.Insert(link)
+	err := internalDisk.serviceLinkMined.Insert(link)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
 	// retrieve the link:
-	retLink, err := internalRepositoryLinkMined.Retrieve(link.Hash())
+	retLink, err := internalDisk.repositoryLinkMined.Retrieve(link.Hash())
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	hydrated, err := internalHydroAdapter.Hydrate(link)
+	hydrated, err := internalDisk.hydroAdapter.Hydrate(link)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	retHydrated, err := internalHydroAdapter.Hydrate(retLink)
+	retHydrated, err := internalDisk.hydroAdapter.Hydrate(retLink)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
@@ -53,3 +53,46 @@ func TestHydrate_linkMined_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestHydrate_linkMined_insert_deleteBlock_expectLinkAndLinkMinedDeleted_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+
+	// save the mined link:
+	minedLink := link_mined.CreateLinkForTests()
+	err := internalDisk.serviceLinkMined.Insert(minedLink)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// delete its block, which has not been mined:
+	err = internalDisk.serviceBlock.Delete(minedLink.Link().NextBlock())
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the link and the mined link are deleted by the cascade:
+	_, err = internalDisk.repositoryLink.Retrieve(minedLink.Link().Hash())
+	if err == nil {
+		t.Errorf("the link was expected to be deleted")
+		return
+	}
+
+	_, err = internalDisk.repositoryLinkMined.Retrieve(minedLink.Hash())
+	if err == nil {
+		t.Errorf("the mined link was expected to be deleted")
+		return
+	}
+
+	if len(DeadLetters()) != 0 {
+		t.Errorf("no dead letter was expected, %d returned", len(DeadLetters()))
+		return
+	}
+}
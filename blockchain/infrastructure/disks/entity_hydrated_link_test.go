@@ -22,26 +22,26 @@ func TestHydrate_link_Success(t *testing.T) {
 	link := links.CreateLinkForTests()
 
 	// save the link:
-	err := internalServiceLink.Insert(link)
+	err := internalDisk.serviceLink.Insert(link)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
 	// retrieve the link:
-	retLink, err := internalRepositoryLink.Retrieve(link.Hash())
+	retLink, err := internalDisk.repositoryLink.Retrieve(link.Hash())
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	hydrated, err := internalHydroAdapter.Hydrate(link)
+	hydrated, err := internalDisk.hydroAdapter.Hydrate(link)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
 	}
 
-	retHydrated, err := internalHydroAdapter.Hydrate(retLink)
+	retHydrated, err := internalDisk.hydroAdapter.Hydrate(retLink)
 	if err != nil {
 		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
 		return
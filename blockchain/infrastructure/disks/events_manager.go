@@ -9,15 +9,21 @@ import (
 	"github.com/deepvalue-network/software/libs/events"
 )
 
-func initEventManager() (events.Manager, error) {
+// initEventManager creates the manager of the delete cascades; a failing cascade aborts the delete that triggered it
+func (app *disk) initEventManager() (events.Manager, error) {
 
 	builder := events.NewBuilder()
 
 	// mined block:
-	minedBlockOnBlockDelete, err := builder.Create().WithIdentifier(EventBlockDelete).OnEnter(func(data interface{}, event events.Event) error {
+	minedBlockOnBlockDelete, err := builder.Create().WithIdentifier(EventBlockDelete).IsAborting().OnEnter(func(data interface{}, event events.Event) error {
 		if ins, ok := data.(blocks.Block); ok {
-			internalServiceBlockMined.DeleteByBlock(ins)
-			return nil
+			minedBlock, err := app.repositoryBlockMined.RetrieveByBlockHash(ins.Tree().Head())
+			if err != nil {
+				// the block has not been mined:
+				return nil
+			}
+
+			return app.serviceBlockMined.Delete(minedBlock)
 		}
 
 		return errors.New("the event data was expected to be a block instance")
@@ -28,10 +34,15 @@ func initEventManager() (events.Manager, error) {
 	}
 
 	// link:
-	linkOnBlockDelete, err := builder.Create().WithIdentifier(EventBlockDelete).OnEnter(func(data interface{}, event events.Event) error {
+	linkOnBlockDelete, err := builder.Create().WithIdentifier(EventBlockDelete).IsAborting().OnEnter(func(data interface{}, event events.Event) error {
 		if ins, ok := data.(blocks.Block); ok {
-			internalServiceLink.DeleteByBlock(ins)
-			return nil
+			link, err := app.repositoryLink.RetrieveByBlockHash(ins.Tree().Head())
+			if err != nil {
+				// the block is not linked:
+				return nil
+			}
+
+			return app.serviceLink.Delete(link)
 		}
 
 		return errors.New("the event data was expected to be a block instance")
@@ -41,10 +52,15 @@ func initEventManager() (events.Manager, error) {
 		return nil, err
 	}
 
-	linkOnMinedLinkDelete, err := builder.Create().WithIdentifier(EventLinkMinedDelete).OnEnter(func(data interface{}, event events.Event) error {
+	linkOnMinedLinkDelete, err := builder.Create().WithIdentifier(EventLinkMinedDelete).IsAborting().OnEnter(func(data interface{}, event events.Event) error {
 		if ins, ok := data.(mined_link.Link); ok {
-			internalServiceLink.DeleteByMinedLinkHash(ins.Hash())
-			return nil
+			link, err := app.repositoryLink.RetrieveByMinedLinkHash(ins.Hash())
+			if err != nil {
+				// no link follows the mined link:
+				return nil
+			}
+
+			return app.serviceLink.Delete(link)
 		}
 
 		return errors.New("the event data was expected to be a mined link instance")
@@ -54,10 +70,15 @@ func initEventManager() (events.Manager, error) {
 		return nil, err
 	}
 
-	minedLinkOnLinkDelete, err := builder.Create().WithIdentifier(EventLinkDelete).OnEnter(func(data interface{}, event events.Event) error {
+	minedLinkOnLinkDelete, err := builder.Create().WithIdentifier(EventLinkDelete).IsAborting().OnEnter(func(data interface{}, event events.Event) error {
 		if ins, ok := data.(links.Link); ok {
-			internalServiceLinkMined.DeleteByLink(ins)
-			return nil
+			minedLink, err := app.repositoryLinkMined.RetrieveByLinkHash(ins.Hash())
+			if err != nil {
+				// the link has not been mined:
+				return nil
+			}
+
+			return app.serviceLinkMined.Delete(minedLink)
 		}
 
 		return errors.New("the event data was expected to be a link instance")
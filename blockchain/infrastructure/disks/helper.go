@@ -0,0 +1,12 @@
+package disks
+
+import "github.com/deepvalue-network/software/libs/files/domain/files"
+
+// savePointer saves a pointer file, replacing the previous one if it exists
+func savePointer(fileService files.Service, exists bool, name string, target string) error {
+	if exists {
+		return fileService.Update(name, target)
+	}
+
+	return fileService.Insert(name, target)
+}
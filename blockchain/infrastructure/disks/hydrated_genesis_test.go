@@ -1,11 +1,13 @@
 package disks
 
 import (
+	"bytes"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/deepvalue-network/software/blockchain/domain/genesis"
+	"github.com/deepvalue-network/software/libs/hash"
 	"github.com/deepvalue-network/software/libs/hydro"
 )
 
@@ -22,5 +24,37 @@ func TestHydrate_genesis_Success(t *testing.T) {
 	gen := genesis.CreateGenesisForTests()
 
 	// execute:
-	hydro.VerifyAdapterUsingJSForTests(internalHydroAdapter, gen, t)
+	hydro.VerifyAdapterUsingJSForTests(internalDisk.hydroAdapter, gen, t)
+}
+
+func TestDehydrate_genesis_withTamperedHash_returnsError(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+
+	// encode a genesis:
+	gen := genesis.CreateGenesisForTests()
+	data, err := internalDisk.hydroAdapter.Encode(gen)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// replace its hash:
+	other, err := hash.NewAdapter().Hash([]byte("another genesis"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	tampered := bytes.Replace(data, []byte(gen.Hash().String()), []byte(other.String()), 1)
+	_, err = internalDisk.hydroAdapter.Decode(tampered, new(HydratedGenesis))
+	if err == nil {
+		t.Errorf("the error was expected to be valid since the stored hash does not match the computed one, nil returned")
+		return
+	}
 }
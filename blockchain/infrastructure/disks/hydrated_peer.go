@@ -48,7 +48,8 @@ func peerOnDehydrateEventFn(ins interface{}, fieldName string, structName string
 	if fieldName == "LastUpdatedOn" {
 		if str, ok := ins.(string); ok {
 			if str == "" {
-				return nil, nil
+				// a typed nil, since an untyped one keeps the string:
+				return (*time.Time)(nil), nil
 			}
 
 			lastUpdatedOn, err := time.Parse(timeLayout, str)
@@ -22,5 +22,5 @@ func TestHydrate_peer_Success(t *testing.T) {
 	peer := peers.CreatePeerForTests()
 
 	// execute:
-	hydro.VerifyAdapterUsingJSForTests(internalHydroAdapter, peer, t)
+	hydro.VerifyAdapterUsingJSForTests(internalDisk.hydroAdapter, peer, t)
 }
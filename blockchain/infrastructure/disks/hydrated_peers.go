@@ -53,7 +53,8 @@ func peersOnDehydrateEventFn(ins interface{}, fieldName string, structName strin
 	if fieldName == "LastSyncTime" {
 		if str, ok := ins.(string); ok {
 			if str == "" {
-				return nil, nil
+				// a typed nil, since an untyped one keeps the string:
+				return (*time.Time)(nil), nil
 			}
 
 			lastSyncTime, err := time.Parse(timeLayout, str)
@@ -22,5 +22,5 @@ func TestHydrate_peers_Success(t *testing.T) {
 	peers := peers.CreatePeersForTests()
 
 	// execute:
-	hydro.VerifyAdapterUsingJSForTests(internalHydroAdapter, peers, t)
+	hydro.VerifyAdapterUsingJSForTests(internalDisk.hydroAdapter, peers, t)
 }
@@ -8,17 +8,6 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-func createGenesisForBridge() *HydratedGenesis {
-	out := HydratedGenesis{
-		MiningValue:                    uint8(0x0),
-		BlockBaseDifficulty:            uint(2),
-		BlockIncreasePerHashDifficulty: float64(0.43),
-		LinkDifficulty:                 uint(11),
-	}
-
-	return &out
-}
-
 func createPeerForBridge() *HydratedPeer {
 	now := time.Now().UTC()
 	out := HydratedPeer{
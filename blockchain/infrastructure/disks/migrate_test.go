@@ -0,0 +1,241 @@
+package disks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	files_disks "github.com/deepvalue-network/software/libs/files/infrastructure/disks"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+func TestMigrate_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+
+	block := blocks.CreateBlockForTests()
+	err := internalDisk.serviceBlock.Insert(block)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// remove the version, like the files written before the versions were introduced:
+	head := block.Tree().Head().String()
+	path := filepath.Join(basePath, blocksDirName, head[0:2], head[2:4], head)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	old := strings.Replace(string(data), `"hydro_version":1,`, "", 1)
+	err = ioutil.WriteFile(path, []byte(old), 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the old file can still be read:
+	_, err = internalDisk.repositoryBlock.Retrieve(block.Tree().Head())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	amount, err := Migrate(basePath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("the amount of migrated files was expected to be %d, %d returned", 1, amount)
+		return
+	}
+
+	// the files are now at the latest version:
+	amount, err = Migrate(basePath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 0 {
+		t.Errorf("the amount of migrated files was expected to be %d, %d returned", 0, amount)
+		return
+	}
+}
+
+func TestMigrate_keyValue_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	InitWithBackend(basePath, 0777, time.Duration(time.Second), hydro.JSON, BackendKeyValue)
+
+	block := blocks.CreateBlockForTests()
+	err := internalDisk.serviceBlock.Insert(block)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// remove the version, like the values written before the versions were introduced:
+	key := strings.Join([]string{blocksDirName, block.Tree().Head().String()}, "/")
+	data, err := internalDisk.store.Retrieve(key)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	old := strings.Replace(string(data), `"hydro_version":1,`, "", 1)
+	err = internalDisk.store.Batch().Put(key, []byte(old)).Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	amount, err := Migrate(basePath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("the amount of migrated values was expected to be %d, %d returned", 1, amount)
+		return
+	}
+
+	// the migrated value can be read:
+	InitWithBackend(basePath, 0777, time.Duration(time.Second), hydro.JSON, BackendKeyValue)
+	defer internalDisk.store.Close()
+
+	_, err = internalDisk.repositoryBlock.Retrieve(block.Tree().Head())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	amount, err = Migrate(basePath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 0 {
+		t.Errorf("the amount of migrated values was expected to be %d, %d returned", 0, amount)
+		return
+	}
+}
+
+func TestMigrate_nestedPointers_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+
+	chain := chains.CreateChainForTests()
+	err := internalDisk.serviceBlockMined.Insert(chain.Root())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if chain.HasHead() {
+		err := internalDisk.serviceLinkMined.Insert(chain.Head())
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	// write the chain with untagged genesis and peers, like the files written before their versions were tagged:
+	encoded, err := internalDisk.hydroAdapter.Encode(chain)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	payload := map[string]interface{}{}
+	err = json.Unmarshal(encoded, &payload)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	untagForTests(payload["genesis"])
+	untagForTests(payload["peers"])
+	old, err := json.Marshal(payload)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	chainsPath := filepath.Join(basePath, chainsDirName)
+	err = files_disks.NewService(internalDisk.hydroAdapter, chainsPath, 0777).Insert(chain.ID().String(), old)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	amount, err := Migrate(basePath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("the amount of migrated files was expected to be %d, %d returned", 1, amount)
+		return
+	}
+
+	// the nested pointers are tagged again:
+	retData, err := files_disks.NewRepository(internalDisk.hydroAdapter, chainsPath, nil).Retrieve(chain.ID().String())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if strings.Count(string(retData.([]byte)), hydro.VersionKey) != strings.Count(string(encoded), hydro.VersionKey) {
+		t.Errorf("the nested pointers were expected to be tagged with their version: %s", retData)
+		return
+	}
+
+	_, err = internalDisk.repositoryChain.Retrieve(chain.ID())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+}
+
+// untagForTests removes the versions of the nested hydrated objects
+func untagForTests(value interface{}) {
+	switch casted := value.(type) {
+	case map[string]interface{}:
+		delete(casted, hydro.VersionKey)
+		for _, oneValue := range casted {
+			untagForTests(oneValue)
+		}
+	case []interface{}:
+		for _, oneValue := range casted {
+			untagForTests(oneValue)
+		}
+	}
+}
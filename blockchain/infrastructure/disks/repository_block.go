@@ -28,6 +28,11 @@ func (app *repositoryBlock) List() ([]hash.Hash, error) {
 	return app.fileRepository.List()
 }
 
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *repositoryBlock) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.fileRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves a block by hash
 func (app *repositoryBlock) Retrieve(blockHash hash.Hash) (blocks.Block, error) {
 	dehydrated, err := app.fileRepository.Retrieve(blockHash.String())
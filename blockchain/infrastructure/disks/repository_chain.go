@@ -28,6 +28,11 @@ func (app *repositoryChain) List() ([]*uuid.UUID, error) {
 	return app.fileRepository.ListIDs()
 }
 
+// ListPage lists a page of chain ids after the cursor, and returns the cursor of the next page
+func (app *repositoryChain) ListPage(cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	return app.fileRepository.ListIDsPage(cursor, amount)
+}
+
 // Retrieve retrieves the chain by id
 func (app *repositoryChain) Retrieve(chainID *uuid.UUID) (chains.Chain, error) {
 	dehydrated, err := app.fileRepository.Retrieve(chainID.String())
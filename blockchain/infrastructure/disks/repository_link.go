@@ -37,6 +37,11 @@ func (app *repositoryLink) List() ([]hash.Hash, error) {
 	return app.fileRepository.List()
 }
 
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *repositoryLink) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.fileRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves the link by hash
 func (app *repositoryLink) Retrieve(linkHash hash.Hash) (links.Link, error) {
 	dehydrated, err := app.fileRepository.Retrieve(linkHash.String())
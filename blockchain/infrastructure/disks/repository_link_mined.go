@@ -55,6 +55,11 @@ func (app *repositoryLinkMined) List() ([]hash.Hash, error) {
 	return app.fileRepository.List()
 }
 
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *repositoryLinkMined) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.fileRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves a mined link by hash
 func (app *repositoryLinkMined) Retrieve(linkHash hash.Hash) (link_mined.Link, error) {
 	dehydrated, err := app.fileRepository.Retrieve(linkHash.String())
@@ -34,6 +34,11 @@ func (app *repositoryBlockMined) List() ([]hash.Hash, error) {
 	return app.fileRepository.List()
 }
 
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *repositoryBlockMined) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return app.fileRepository.ListPage(cursor, amount)
+}
+
 // Retrieve retrieves a mined block by hash
 func (app *repositoryBlockMined) Retrieve(minedBlockHash hash.Hash) (blocks_mined.Block, error) {
 	dehydrated, err := app.fileRepository.Retrieve(minedBlockHash.String())
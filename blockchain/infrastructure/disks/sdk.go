@@ -1,20 +1,26 @@
 package disks
 
+// Only the genesis bridge is generated, here and in the servers package.  The other entities store the instances they
+// reference by hash and resolve them through the repositories when dehydrated, which the generator does not support.
+//go:generate go run github.com/deepvalue-network/software/libs/hydro/cmd/hydrogen -dir ../../domain/genesis -interface Genesis
+
 import (
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
 	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
 	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
-	"github.com/deepvalue-network/software/blockchain/domain/genesis"
 	"github.com/deepvalue-network/software/blockchain/domain/links"
 	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
 	"github.com/deepvalue-network/software/libs/events"
 	"github.com/deepvalue-network/software/libs/files/domain/files"
 	files_disks "github.com/deepvalue-network/software/libs/files/infrastructure/disks"
+	"github.com/deepvalue-network/software/libs/files/infrastructure/kvstores"
 	"github.com/deepvalue-network/software/libs/hash"
 	"github.com/deepvalue-network/software/libs/hydro"
 )
@@ -54,123 +60,209 @@ const (
 	EventChainDelete
 )
 
+const (
+	// BackendFiles represents the backend that writes one file per entity
+	BackendFiles Backend = iota + 1
+
+	// BackendKeyValue represents the backend that writes every entity in an embedded key-value store
+	BackendKeyValue
+)
+
+// Backend represents a storage backend
+type Backend uint8
+
+// Disk represents the repositories and services of a data directory
+type Disk interface {
+	DeadLetters() []events.DeadLetter
+	RepositoryApplication() repositories.Application
+	ServiceApplication(remoteBuilder repositories.RemoteBuilder, publisher services.Publisher, bootstrapPeers []peers.Peer, orphanDepth uint) services.Application
+	Close() error
+}
+
+const storeFileName = "store.kv"
+
 const timeLayout = "2006-01-02T15:04:05.000Z"
 
-// event manager:
-var internalEventManager events.Manager
+const blocksDirName = "blocks"
+
+const minedBlocksDirName = "blocks_mined"
+
+const linksDirName = "links"
+
+const minedLinksDirName = "links_mined"
+
+const chainsDirName = "chains"
 
-// peer sync interval:
-var internalPeerSyncInterval time.Duration
+const minedBlockPointersDirName = "blocks_mined_pointers"
 
-// adapters:
-var internalHydroAdapter hydro.Adapter
+const blockLinkPointersDirName = "links_blocks_pointers"
 
-// repositories:
-var internalRepositoryBlock blocks.Repository
-var internalRepositoryBlockMined block_mined.Repository
-var internalRepositoryLink links.Repository
-var internalRepositoryLinkMined link_mined.Repository
-var internalRepositoryChain chains.Repository
+const minedLinkLinkPointersDirName = "links_minedlinks_pointers"
 
-// services:
-var internalServiceBlock blocks.Service
-var internalServiceBlockMined block_mined.Service
-var internalServiceLink links.Service
-var internalServiceLinkMined link_mined.Service
+const linkMinedLinkPointersDirName = "links_mined_links_pointers"
 
-// Init initializes the package
+const headMinedLinkPointerDirName = "links_mined_head_pointer"
+
+const headFileName = "head.hash"
+
+// disk initialized by Init:
+var internalDisk *disk
+
+// Init initializes the package, with files encoded in JSON
 func Init(
 	basePath string,
 	fileMode os.FileMode,
 	peerSyncInterval time.Duration,
 ) {
+	InitWithFormat(basePath, fileMode, peerSyncInterval, hydro.JSON)
+}
 
-	// init events:
-	eventManager, err := initEventManager()
+// InitWithFormat initializes the package, with files encoded in the given format.  The files are read in any format
+func InitWithFormat(
+	basePath string,
+	fileMode os.FileMode,
+	peerSyncInterval time.Duration,
+	format hydro.Format,
+) {
+	InitWithBackend(basePath, fileMode, peerSyncInterval, format, BackendFiles)
+}
+
+// InitWithBackend initializes the package, with entities encoded in the given format and stored in the given backend.
+// The disk initialized before is closed
+func InitWithBackend(
+	basePath string,
+	fileMode os.FileMode,
+	peerSyncInterval time.Duration,
+	format hydro.Format,
+	backend Backend,
+) {
+	if internalDisk != nil {
+		internalDisk.Close()
+		internalDisk = nil
+	}
+
+	disk, err := createDisk(basePath, fileMode, peerSyncInterval, format, backend)
 	if err != nil {
 		panic(err)
 	}
 
-	// assign event manager:
-	internalEventManager = eventManager
-
-	// interval assign
-	internalPeerSyncInterval = peerSyncInterval
-
-	// create the block repository:
-	blockPtr := new(EntityHydratedBlock)
-	blockBasePath := filepath.Join(basePath, "blocks")
-	repositoryFileBlock := files_disks.NewRepository(internalHydroAdapter, blockBasePath, blockPtr)
-	repositoryBlock := NewRepositoryBlock(repositoryFileBlock)
-
-	// create the mined block repository:
-	minedBlockPtr := new(EntityHydratedBlockMined)
-	minedBlockBasePath := filepath.Join(basePath, "blocks_mined")
-	pointerMinedBlockBasePath := filepath.Join(basePath, "blocks_mined_pointers")
-	repositoryFileMinedBlock := files_disks.NewRepository(internalHydroAdapter, minedBlockBasePath, minedBlockPtr)
-	repositoryPointerFileMinedBlock := files_disks.NewRepository(internalHydroAdapter, pointerMinedBlockBasePath, nil)
-	repositoryBlockMined := NewRepositoryBlockMined(repositoryFileMinedBlock, repositoryPointerFileMinedBlock)
-
-	// create the link repository:
-	linkPtr := new(EntityHydratedLink)
-	linkBasePath := filepath.Join(basePath, "links")
-	blockPointerLinkBasePath := filepath.Join(basePath, "links_blocks_pointers")
-	minedLinkPointerLinkBasePath := filepath.Join(basePath, "links_minedlinks_pointers")
-	repositoryFileLink := files_disks.NewRepository(internalHydroAdapter, linkBasePath, linkPtr)
-	repositoryBlockPointerFileLink := files_disks.NewRepository(internalHydroAdapter, blockPointerLinkBasePath, nil)
-	repositoryMinedLinkPointerFileLink := files_disks.NewRepository(internalHydroAdapter, minedLinkPointerLinkBasePath, nil)
-	linkRepository := NewRepositoryLink(repositoryFileLink, repositoryBlockPointerFileLink, repositoryMinedLinkPointerFileLink)
-
-	// create the link mined repository:
-	minedLinkPtr := new(EntityHydratedLinkMined)
-	minedLinkBasePath := filepath.Join(basePath, "links_mined")
-	linkPointerMinedLinkBasePath := filepath.Join(basePath, "links_mined_links_pointers")
-	headPointerMinedLinkBasePath := filepath.Join(basePath, "links_mined_head_pointer")
-	headFileName := "head.hash"
-	repositoryFileLinkMined := files_disks.NewRepository(internalHydroAdapter, minedLinkBasePath, minedLinkPtr)
-	linkPointerFileRepository := files_disks.NewRepository(internalHydroAdapter, linkPointerMinedLinkBasePath, nil)
-	headPointerFileRepository := files_disks.NewRepository(internalHydroAdapter, headPointerMinedLinkBasePath, nil)
-	minedLinkRepository := NewRepositoryLinkMined(repositoryFileLinkMined, linkPointerFileRepository, headPointerFileRepository, headFileName)
-
-	// create the chain repository:
-	chainLinkPtr := new(EntityHydratedChain)
-	chainBasePath := filepath.Join(basePath, "chains")
-	repositoryFileChain := files_disks.NewRepository(internalHydroAdapter, chainBasePath, chainLinkPtr)
-	chainRepository := NewRepositoryChain(repositoryFileChain)
-
-	// repository assign:
-	internalRepositoryBlock = repositoryBlock
-	internalRepositoryBlockMined = repositoryBlockMined
-	internalRepositoryLink = linkRepository
-	internalRepositoryLinkMined = minedLinkRepository
-	internalRepositoryChain = chainRepository
-
-	// create the block service:
-	blockFileService := files_disks.NewService(internalHydroAdapter, blockBasePath, fileMode)
-	blockService := NewServiceBlock(internalEventManager, blockFileService)
-
-	// create the mined block service:
-	minedBlockFileService := files_disks.NewService(internalHydroAdapter, minedBlockBasePath, fileMode)
-	minedBlockPointerFileService := files_disks.NewService(internalHydroAdapter, pointerMinedBlockBasePath, fileMode)
-	minedBlockService := NewServiceBlockMined(internalEventManager, repositoryBlockMined, blockService, minedBlockFileService, minedBlockPointerFileService)
-
-	// create the link service:
-	linkFileService := files_disks.NewService(internalHydroAdapter, linkBasePath, fileMode)
-	linkBlockPointerFileService := files_disks.NewService(internalHydroAdapter, blockPointerLinkBasePath, fileMode)
-	linkMinedLinkPointerFileService := files_disks.NewService(internalHydroAdapter, minedLinkPointerLinkBasePath, fileMode)
-	linkService := NewServiceLink(internalEventManager, linkRepository, blockService, linkFileService, linkBlockPointerFileService, linkMinedLinkPointerFileService)
-
-	// create the mined link service:
-	minedLinkFileService := files_disks.NewService(internalHydroAdapter, minedLinkBasePath, fileMode)
-	minedLinkLinkPointerFileService := files_disks.NewService(internalHydroAdapter, linkPointerMinedLinkBasePath, fileMode)
-	headPointerFileService := files_disks.NewService(internalHydroAdapter, headPointerMinedLinkBasePath, fileMode)
-	minedLinkService := NewServiceLinkMined(internalEventManager, minedLinkRepository, linkService, minedLinkFileService, minedLinkLinkPointerFileService, headPointerFileService, headFileName)
-
-	// service assign:
-	internalServiceBlock = blockService
-	internalServiceBlockMined = minedBlockService
-	internalServiceLink = linkService
-	internalServiceLinkMined = minedLinkService
+	internalDisk = disk
+}
+
+// DeadLetters returns the latest failed deliveries of the events of the initialized package
+func DeadLetters() []events.DeadLetter {
+	return internalDisk.DeadLetters()
+}
+
+// NewRepositoryApplication creates a new repository application on the repositories of the initialized package
+func NewRepositoryApplication() repositories.Application {
+	return internalDisk.RepositoryApplication()
+}
+
+// NewServiceApplication creates a new service application on the repositories and services of the initialized
+// package.  The chains are synced with their own peers and the bootstrap peers, using the remote builder, and the
+// mined links orphaned by a fork are kept for the orphan depth.  The received mined blocks and mined links are announced
+// to the same peers, using the publisher
+func NewServiceApplication(
+	remoteBuilder repositories.RemoteBuilder,
+	publisher services.Publisher,
+	bootstrapPeers []peers.Peer,
+	orphanDepth uint,
+) services.Application {
+	return internalDisk.ServiceApplication(remoteBuilder, publisher, bootstrapPeers, orphanDepth)
+}
+
+// NewDisk creates a new disk on a data directory, with entities encoded in the given format and stored in the given
+// backend.  Unlike Init, every disk has its own repositories, services and events, so that several nodes can run in
+// the same process
+func NewDisk(
+	basePath string,
+	fileMode os.FileMode,
+	peerSyncInterval time.Duration,
+	format hydro.Format,
+	backend Backend,
+) (Disk, error) {
+	return createDisk(basePath, fileMode, peerSyncInterval, format, backend)
+}
+
+// Migrate upgrades every entity of a data directory to the latest version of its hydrated pointer, and returns the
+// amount of moved and rewritten files or values.  The files of the files backend are moved to their shard first, and
+// the values of the key-value backend are upgraded in its store, which is closed if the package uses it
+func Migrate(basePath string) (uint, error) {
+	entityPointers := []struct {
+		dirName string
+		ptr     interface{}
+	}{
+		{dirName: blocksDirName, ptr: new(EntityHydratedBlock)},
+		{dirName: minedBlocksDirName, ptr: new(EntityHydratedBlockMined)},
+		{dirName: linksDirName, ptr: new(EntityHydratedLink)},
+		{dirName: minedLinksDirName, ptr: new(EntityHydratedLinkMined)},
+		{dirName: chainsDirName, ptr: new(EntityHydratedChain)},
+	}
+
+	// the payloads are upgraded without being dehydrated, so the adapter needs no repository:
+	hydroAdapter, err := createHydroAdapter(new(disk))
+	if err != nil {
+		return 0, err
+	}
+
+	migrations := []files.Migration{}
+	storePath := filepath.Join(basePath, storeFileName)
+	if info, err := os.Stat(storePath); err == nil {
+		if internalDisk != nil {
+			internalDisk.Close()
+		}
+
+		store, err := kvstores.NewStore(storePath, info.Mode().Perm())
+		if err != nil {
+			return 0, err
+		}
+
+		defer store.Close()
+		for _, oneEntity := range entityPointers {
+			migrations = append(migrations, kvstores.NewMigration(hydroAdapter, store, oneEntity.dirName, oneEntity.ptr))
+		}
+	} else {
+		for _, oneDirName := range []string{
+			blocksDirName,
+			minedBlocksDirName,
+			minedBlockPointersDirName,
+			linksDirName,
+			blockLinkPointersDirName,
+			minedLinkLinkPointersDirName,
+			minedLinksDirName,
+			linkMinedLinkPointersDirName,
+			chainsDirName,
+		} {
+			migrations = append(migrations, files_disks.NewShardMigration(filepath.Join(basePath, oneDirName)))
+		}
+
+		for _, oneEntity := range entityPointers {
+			migrations = append(migrations, files_disks.NewMigration(hydroAdapter, filepath.Join(basePath, oneEntity.dirName), oneEntity.ptr))
+		}
+	}
+
+	amount := uint(0)
+	for _, oneMigration := range migrations {
+		migrated, err := oneMigration.Execute()
+		amount += migrated
+		if err != nil {
+			return amount, err
+		}
+	}
+
+	return amount, nil
+}
+
+// NewServiceChain creates a new disk chain service instance
+func NewServiceChain(
+	eventManager events.Manager,
+	validator chains.Validator,
+	minedBlockService block_mined.Service,
+	minedLinkService link_mined.Service,
+	fileService files.Service,
+) chains.Service {
+	return createServiceChain(eventManager, validator, minedBlockService, minedLinkService, fileService)
 }
 
 // NewRepositoryChain creates a new chain repository
@@ -184,13 +276,14 @@ func NewRepositoryChain(
 func NewServiceLinkMined(
 	eventManager events.Manager,
 	minedLinkRepository link_mined.Repository,
+	linkRepository links.Repository,
 	linkService links.Service,
 	fileService files.Service,
 	linkPointerFileService files.Service,
 	headPointerFileService files.Service,
 	headFileName string,
 ) link_mined.Service {
-	return createServiceLinkMined(eventManager, minedLinkRepository, linkService, fileService, linkPointerFileService, headPointerFileService, headFileName)
+	return createServiceLinkMined(eventManager, minedLinkRepository, linkRepository, linkService, fileService, linkPointerFileService, headPointerFileService, headFileName)
 }
 
 // NewRepositoryLinkMined represents a new disk link mined repository instance
@@ -208,6 +301,7 @@ func NewRepositoryLinkMined(
 func NewServiceLink(
 	eventManager events.Manager,
 	linkRepository links.Repository,
+	blockRepository blocks.Repository,
 	blockService blocks.Service,
 	fileService files.Service,
 	blockPointerFileService files.Service,
@@ -216,6 +310,7 @@ func NewServiceLink(
 	return createServiceLink(
 		eventManager,
 		linkRepository,
+		blockRepository,
 		blockService,
 		fileService,
 		blockPointerFileService,
@@ -261,134 +356,10 @@ func NewRepositoryBlockMined(
 func NewServiceBlockMined(
 	eventManager events.Manager,
 	minedBlockRepository block_mined.Repository,
+	blockRepository blocks.Repository,
 	blockService blocks.Service,
 	fileService files.Service,
 	pointerFileService files.Service,
 ) block_mined.Service {
-	return createServiceBlockMined(eventManager, minedBlockRepository, blockService, fileService, pointerFileService)
-}
-
-func init() {
-	// create bridges:
-	blockBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*blocks.Block)(nil)).
-		WithDehydratedConstructor(newBlock).
-		WithDehydratedPointer(blocks.NewPointer()).
-		WithHydratedPointer(new(EntityHydratedBlock)).
-		OnHydrate(blockOnHydrateEventFn).
-		OnDehydrate(blockOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	blockMinedBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*block_mined.Block)(nil)).
-		WithDehydratedConstructor(newBlockMined).
-		WithDehydratedPointer(block_mined.NewPointer()).
-		WithHydratedPointer(new(EntityHydratedBlockMined)).
-		OnHydrate(blockMinedOnHydrateEventFn).
-		OnDehydrate(blockMinedOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	linkBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*links.Link)(nil)).
-		WithDehydratedConstructor(newLink).
-		WithDehydratedPointer(links.NewPointer()).
-		WithHydratedPointer(new(EntityHydratedLink)).
-		OnHydrate(linkOnHydrateEventFn).
-		OnDehydrate(linkOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	minedLinkBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*link_mined.Link)(nil)).
-		WithDehydratedConstructor(newLinkMined).
-		WithDehydratedPointer(link_mined.NewPointer()).
-		WithHydratedPointer(new(EntityHydratedLinkMined)).
-		OnHydrate(linkMinedOnHydrateEventFn).
-		OnDehydrate(linkMinedOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	peerBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*peers.Peer)(nil)).
-		WithDehydratedConstructor(newPeer).
-		WithDehydratedPointer(peers.NewPeerPointer()).
-		WithHydratedPointer(createPeerForBridge()).
-		OnHydrate(peerOnHydrateEventFn).
-		OnDehydrate(peerOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	peersBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*peers.Peers)(nil)).
-		WithDehydratedConstructor(newPeers).
-		WithDehydratedPointer(peers.NewPointer()).
-		WithHydratedPointer(createPeersForBridge()).
-		OnHydrate(peersOnHydrateEventFn).
-		OnDehydrate(peersOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	genesisBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*genesis.Genesis)(nil)).
-		WithDehydratedConstructor(newGenesis).
-		WithDehydratedPointer(genesis.NewPointer()).
-		WithHydratedPointer(createGenesisForBridge()).
-		OnHydrate(genesisOnHydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	chainBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*chains.Chain)(nil)).
-		WithDehydratedConstructor(newChain).
-		WithDehydratedPointer(chains.NewPointer()).
-		WithHydratedPointer(new(EntityHydratedChain)).
-		OnHydrate(chainOnHydrateEventFn).
-		OnDehydrate(chainOnDehydrateEventFn).
-		Now()
-
-	if err != nil {
-		panic(err)
-	}
-
-	// build the manager:
-	manager := hydro.NewManagerFactory().Create()
-
-	// register the bridges:
-	manager.Register(blockBridge)
-	manager.Register(blockMinedBridge)
-	manager.Register(linkBridge)
-	manager.Register(minedLinkBridge)
-	manager.Register(peerBridge)
-	manager.Register(peersBridge)
-	manager.Register(genesisBridge)
-	manager.Register(chainBridge)
-
-	// create the adapter:
-	internalHydroAdapter, err = hydro.NewAdapterBuilder().Create().WithManager(manager).Now()
-	if err != nil {
-		panic(err)
-	}
+	return createServiceBlockMined(eventManager, minedBlockRepository, blockRepository, blockService, fileService, pointerFileService)
 }
@@ -0,0 +1,473 @@
+package disks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/libs/hash"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestServiceApplication_syncWithHeavierRemoteFork_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+
+	remote := &remoteForTests{
+		chains:     remoteChainsForTests{},
+		minedLinks: remoteMinedLinksForTests{},
+	}
+
+	bootstrapPeers := []peers.Peer{peers.CreatePeerForTests()}
+	app := NewServiceApplication(remote, nil, bootstrapPeers, 0)
+
+	// create the chain:
+	id := uuid.NewV4()
+	local, err := app.Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, createHashesForTests(t, "root"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// mine a local link on the root, then update the chain:
+	orphan := createMinedLinkForTests(t, local.Root().Hash(), createHashesForTests(t, "local"))
+	err = internalDisk.serviceLinkMined.Insert(orphan)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	updated, err := chains.NewBuilder(time.Second).Create().WithOriginal(local).WithHead(orphan).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = internalDisk.serviceChain.Update(local, updated)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the remote peer mined two links on the root:
+	first := createMinedLinkForTests(t, local.Root().Hash(), createHashesForTests(t, "first", "second"))
+	second := createMinedLinkForTests(t, first.Hash(), createHashesForTests(t, "third"))
+	remoteChain, err := chains.NewBuilder(time.Second).Create().WithID(&id).WithGenesis(local.Genesis()).WithRoot(local.Root()).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for _, oneMinedLink := range []link_mined.Link{first, second} {
+		remoteChain, err = chains.NewBuilder(time.Second).Create().WithOriginal(remoteChain).WithHead(oneMinedLink).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		remote.minedLinks[oneMinedLink.Hash().String()] = oneMinedLink
+	}
+
+	remote.chains[id.String()] = remoteChain
+
+	// sync:
+	err = app.Chain().SyncOnce()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retChain, err := internalDisk.repositoryChain.Retrieve(&id)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retChain.HasHead() || !retChain.Head().Hash().Compare(second.Hash()) {
+		t.Errorf("the head of the chain was expected to be the head of the remote chain")
+		return
+	}
+
+	if retChain.Height() != 2 || retChain.TotalHashes() != 4 {
+		t.Errorf("the chain was expected to have an height of 2 and 4 total hashes, height: %d, total hashes: %d", retChain.Height(), retChain.TotalHashes())
+		return
+	}
+
+	head, err := internalDisk.repositoryLinkMined.Head()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !head.Hash().Compare(second.Hash()) {
+		t.Errorf("the head mined link was expected to be the head of the remote chain")
+		return
+	}
+
+	// the orphan depth is zero, so the orphaned mined link is deleted:
+	_, err = internalDisk.repositoryLinkMined.Retrieve(orphan.Hash())
+	if err == nil {
+		t.Errorf("the orphaned mined link was expected to be deleted")
+		return
+	}
+}
+
+func TestServiceApplication_syncWithHeavierRemoteFork_sharingBlock_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+
+	remote := &remoteForTests{
+		chains:     remoteChainsForTests{},
+		minedLinks: remoteMinedLinksForTests{},
+	}
+
+	bootstrapPeers := []peers.Peer{peers.CreatePeerForTests()}
+	app := NewServiceApplication(remote, nil, bootstrapPeers, 0)
+
+	// create the chain:
+	id := uuid.NewV4()
+	local, err := app.Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, createHashesForTests(t, "root"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// mine a local link of the shared block on the root, then update the chain:
+	orphan := createMinedLinkForTests(t, local.Root().Hash(), createHashesForTests(t, "shared"))
+	err = internalDisk.serviceLinkMined.Insert(orphan)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	updated, err := chains.NewBuilder(time.Second).Create().WithOriginal(local).WithHead(orphan).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = internalDisk.serviceChain.Update(local, updated)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the remote peer mined a link on the root, then a link of the shared block:
+	first := createMinedLinkForTests(t, local.Root().Hash(), createHashesForTests(t, "first"))
+	second := createMinedLinkForTests(t, first.Hash(), createHashesForTests(t, "shared"))
+	remoteChain, err := chains.NewBuilder(time.Second).Create().WithID(&id).WithGenesis(local.Genesis()).WithRoot(local.Root()).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for _, oneMinedLink := range []link_mined.Link{first, second} {
+		remoteChain, err = chains.NewBuilder(time.Second).Create().WithOriginal(remoteChain).WithHead(oneMinedLink).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		remote.minedLinks[oneMinedLink.Hash().String()] = oneMinedLink
+	}
+
+	remote.chains[id.String()] = remoteChain
+
+	// sync:
+	err = app.Chain().SyncOnce()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retChain, err := internalDisk.repositoryChain.Retrieve(&id)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retChain.HasHead() || !retChain.Head().Hash().Compare(second.Hash()) {
+		t.Errorf("the head of the chain was expected to be the head of the remote chain")
+		return
+	}
+
+	// the orphan depth is zero, so the orphaned mined link and its link are deleted:
+	_, err = internalDisk.repositoryLinkMined.Retrieve(orphan.Hash())
+	if err == nil {
+		t.Errorf("the orphaned mined link was expected to be deleted")
+		return
+	}
+
+	_, err = internalDisk.repositoryLink.Retrieve(orphan.Link().Hash())
+	if err == nil {
+		t.Errorf("the link of the orphaned mined link was expected to be deleted")
+		return
+	}
+
+	// the shared block, and the mined links of the chain, are kept:
+	sharedBlockHash := second.Link().NextBlock().Tree().Head()
+	_, err = internalDisk.repositoryBlock.Retrieve(sharedBlockHash)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retLink, err := internalDisk.repositoryLink.RetrieveByBlockHash(sharedBlockHash)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retLink.Hash().Compare(second.Link().Hash()) {
+		t.Errorf("the shared block was expected to point to the link of the head of the chain")
+		return
+	}
+
+	for _, oneMinedLink := range []link_mined.Link{first, second} {
+		retMinedLink, err := internalDisk.repositoryLinkMined.RetrieveByLinkHash(oneMinedLink.Link().Hash())
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !retMinedLink.Hash().Compare(oneMinedLink.Hash()) {
+			t.Errorf("the mined link (hash: %s) of the chain was expected to be kept", oneMinedLink.Hash().String())
+			return
+		}
+	}
+}
+
+func TestServiceApplication_extend_validatesBeforeSaving_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	Init(basePath, 0777, time.Duration(time.Second))
+	app := NewServiceApplication(nil, nil, nil, 0)
+
+	// create the chain:
+	id := uuid.NewV4()
+	local, err := app.Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, createHashesForTests(t, "root"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// a mined link created before the root is rejected, and nothing is saved:
+	minedLink := createMinedLinkForTests(t, local.Root().Hash(), createHashesForTests(t, "first"))
+	invalid, err := link_mined.NewBuilder().Create().
+		WithLink(minedLink.Link()).
+		WithResults(minedLink.Results()).
+		CreatedOn(local.Root().CreatedOn().Add(time.Hour * -1)).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = app.Chain().Extend(invalid)
+	if err == nil || !services.IsRejected(err) {
+		t.Errorf("the mined link was expected to be rejected")
+		return
+	}
+
+	_, err = internalDisk.repositoryLinkMined.Retrieve(invalid.Hash())
+	if err == nil {
+		t.Errorf("the rejected mined link was expected not to be saved")
+		return
+	}
+
+	_, err = internalDisk.repositoryLink.Retrieve(invalid.Link().Hash())
+	if err == nil {
+		t.Errorf("the link of the rejected mined link was expected not to be saved")
+		return
+	}
+
+	_, err = internalDisk.repositoryBlock.Retrieve(invalid.Link().NextBlock().Tree().Head())
+	if err == nil {
+		t.Errorf("the block of the rejected mined link was expected not to be saved")
+		return
+	}
+
+	// a mined link whose previous mined link is unknown is rejected:
+	unknown := createMinedLinkForTests(t, createHashesForTests(t, "unknown")[0], createHashesForTests(t, "second"))
+	_, err = app.Chain().Extend(unknown)
+	if err == nil || !services.IsRejected(err) {
+		t.Errorf("the mined link was expected to be rejected")
+		return
+	}
+
+	// the valid mined link extends the chain:
+	isNew, err := app.Chain().Extend(minedLink)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !isNew {
+		t.Errorf("the mined link was expected to be new")
+		return
+	}
+
+	retChain, err := internalDisk.repositoryChain.Retrieve(&id)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retChain.HasHead() || !retChain.Head().Hash().Compare(minedLink.Hash()) {
+		t.Errorf("the head of the chain was expected to be the mined link")
+		return
+	}
+}
+
+func createHashesForTests(t *testing.T, values ...string) []hash.Hash {
+	out := []hash.Hash{}
+	for _, oneValue := range values {
+		hsh, err := hash.NewAdapter().Hash([]byte(oneValue))
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return nil
+		}
+
+		out = append(out, *hsh)
+	}
+
+	return out
+}
+
+func createMinedLinkForTests(t *testing.T, prevMinedLink hash.Hash, hashes []hash.Hash) link_mined.Link {
+	block, err := blocks.NewBuilder().Create().WithHashes(hashes).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	link, err := links.NewBuilder().Create().WithPreviousMinedLink(prevMinedLink).WithNextBlock(block).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	results, _, err := services.NewMiner().Mine(context.Background(), 2, 1, link.Hash())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	minedLink, err := link_mined.NewBuilder().Create().WithLink(link).WithResults(results).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return minedLink
+}
+
+// remoteForTests represents an in-memory remote peer, built by the remote builder it implements
+type remoteForTests struct {
+	chains     remoteChainsForTests
+	minedLinks remoteMinedLinksForTests
+}
+
+func (app *remoteForTests) Create() repositories.RemoteBuilder {
+	return app
+}
+
+func (app *remoteForTests) WithPeer(peer peers.Peer) repositories.RemoteBuilder {
+	return app
+}
+
+func (app *remoteForTests) Now() (repositories.Application, error) {
+	return repositories.NewApplication(
+		repositories.NewBlock(nil),
+		repositories.NewMinedBlock(nil),
+		repositories.NewLink(nil),
+		repositories.NewMinedLink(app.minedLinks),
+		repositories.NewChain(app.chains),
+	), nil
+}
+
+type remoteChainsForTests map[string]chains.Chain
+
+func (obj remoteChainsForTests) List() ([]*uuid.UUID, error) {
+	out := []*uuid.UUID{}
+	for _, oneChain := range obj {
+		out = append(out, oneChain.ID())
+	}
+
+	return out, nil
+}
+
+func (obj remoteChainsForTests) ListPage(cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	out, err := obj.List()
+	return out, "", err
+}
+
+func (obj remoteChainsForTests) Retrieve(id *uuid.UUID) (chains.Chain, error) {
+	if ins, ok := obj[id.String()]; ok {
+		return ins, nil
+	}
+
+	return nil, errors.New("the chain does not exist")
+}
+
+type remoteMinedLinksForTests map[string]link_mined.Link
+
+func (obj remoteMinedLinksForTests) Head() (link_mined.Link, error) {
+	return nil, errors.New("the remote peer has no head")
+}
+
+func (obj remoteMinedLinksForTests) List() ([]hash.Hash, error) {
+	out := []hash.Hash{}
+	for _, oneMinedLink := range obj {
+		out = append(out, oneMinedLink.Hash())
+	}
+
+	return out, nil
+}
+
+func (obj remoteMinedLinksForTests) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	out, err := obj.List()
+	return out, "", err
+}
+
+func (obj remoteMinedLinksForTests) Retrieve(minedLinkHash hash.Hash) (link_mined.Link, error) {
+	if ins, ok := obj[minedLinkHash.String()]; ok {
+		return ins, nil
+	}
+
+	return nil, errors.New("the mined link does not exist")
+}
+
+func (obj remoteMinedLinksForTests) RetrieveByLinkHash(linkHash hash.Hash) (link_mined.Link, error) {
+	for _, oneMinedLink := range obj {
+		if oneMinedLink.Link().Hash().Compare(linkHash) {
+			return oneMinedLink, nil
+		}
+	}
+
+	return nil, errors.New("the mined link does not exist")
+}
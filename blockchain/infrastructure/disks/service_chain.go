@@ -4,10 +4,12 @@ import (
 	mined_blocks "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
 	mined_links "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/libs/events"
 	"github.com/deepvalue-network/software/libs/files/domain/files"
 )
 
 type serviceChain struct {
+	eventManager events.Manager
 	validator    chains.Validator
 	blockService mined_blocks.Service
 	linkService  mined_links.Service
@@ -15,12 +17,14 @@ type serviceChain struct {
 }
 
 func createServiceChain(
+	eventManager events.Manager,
 	validator chains.Validator,
 	blockService mined_blocks.Service,
 	linkService mined_links.Service,
 	fileService files.Service,
 ) chains.Service {
 	out := serviceChain{
+		eventManager: eventManager,
 		validator:    validator,
 		blockService: blockService,
 		linkService:  linkService,
@@ -32,15 +36,31 @@ func createServiceChain(
 
 // Insert inserts a chain
 func (app *serviceChain) Insert(chain chains.Chain) error {
-	return nil
+	err := app.validator.Execute(chain)
+	if err != nil {
+		return err
+	}
+
+	return app.eventManager.Trigger(EventChainInsert, chain, func() error {
+		return app.fileService.Insert(chain.ID().String(), chain)
+	})
 }
 
 // Update updates a chain
 func (app *serviceChain) Update(original chains.Chain, updated chains.Chain) error {
-	return nil
+	err := app.validator.Execute(updated)
+	if err != nil {
+		return err
+	}
+
+	return app.eventManager.Trigger(EventChainUpdate, updated, func() error {
+		return app.fileService.Update(original.ID().String(), updated)
+	})
 }
 
 // Delete deletes a chain
 func (app *serviceChain) Delete(chain chains.Chain) error {
-	return nil
+	return app.eventManager.Trigger(EventChainDelete, chain, func() error {
+		return app.fileService.Delete(chain.ID().String())
+	})
 }
@@ -11,6 +11,7 @@ import (
 type serviceLink struct {
 	eventManager                events.Manager
 	linkRepository              links.Repository
+	blockRepository             blocks.Repository
 	blockService                blocks.Service
 	fileService                 files.Service
 	blockPointerFileService     files.Service
@@ -20,6 +21,7 @@ type serviceLink struct {
 func createServiceLink(
 	eventManager events.Manager,
 	linkRepository links.Repository,
+	blockRepository blocks.Repository,
 	blockService blocks.Service,
 	fileService files.Service,
 	blockPointerFileService files.Service,
@@ -28,6 +30,7 @@ func createServiceLink(
 	out := serviceLink{
 		eventManager:                eventManager,
 		linkRepository:              linkRepository,
+		blockRepository:             blockRepository,
 		blockService:                blockService,
 		fileService:                 fileService,
 		blockPointerFileService:     blockPointerFileService,
@@ -37,13 +40,18 @@ func createServiceLink(
 	return &out
 }
 
-// Insert inserts a link
+// Insert inserts a link; its next block is only saved if not already stored, and its pointers are replaced since
+// the links of concurrent forks share their blocks and previous mined links
 func (app *serviceLink) Insert(link links.Link) error {
 	return app.eventManager.Trigger(EventLinkInsert, link, func() error {
 		block := link.NextBlock()
-		err := app.blockService.Insert(block)
+		blockHash := block.Tree().Head()
+		_, err := app.blockRepository.Retrieve(blockHash)
 		if err != nil {
-			return err
+			err := app.blockService.Insert(block)
+			if err != nil {
+				return err
+			}
 		}
 
 		// save the link:
@@ -54,12 +62,15 @@ func (app *serviceLink) Insert(link links.Link) error {
 		}
 
 		// save the pointers:
-		err = app.blockPointerFileService.Insert(block.Tree().Head().String(), linkHashStr)
+		_, err = app.linkRepository.RetrieveByBlockHash(blockHash)
+		err = savePointer(app.blockPointerFileService, err == nil, blockHash.String(), linkHashStr)
 		if err != nil {
 			return err
 		}
 
-		err = app.minedLinkPointerFileService.Insert(link.PrevMinedLink().String(), linkHashStr)
+		prevMinedLinkHash := link.PrevMinedLink()
+		_, err = app.linkRepository.RetrieveByMinedLinkHash(prevMinedLinkHash)
+		err = savePointer(app.minedLinkPointerFileService, err == nil, prevMinedLinkHash.String(), linkHashStr)
 		if err != nil {
 			return err
 		}
@@ -68,10 +79,30 @@ func (app *serviceLink) Insert(link links.Link) error {
 	})
 }
 
-// Delete deletes a link
+// Delete deletes a link, then the pointers that still point to it; the pointers replaced by the other links of its
+// block or previous mined link are kept
 func (app *serviceLink) Delete(link links.Link) error {
 	return app.eventManager.Trigger(EventLinkDelete, link, func() error {
-		return app.fileService.Delete(link.Hash().String())
+		linkHash := link.Hash()
+		blockHash := link.NextBlock().Tree().Head()
+		pointed, err := app.linkRepository.RetrieveByBlockHash(blockHash)
+		if err == nil && pointed.Hash().Compare(linkHash) {
+			err := app.blockPointerFileService.Delete(blockHash.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		prevMinedLinkHash := link.PrevMinedLink()
+		pointed, err = app.linkRepository.RetrieveByMinedLinkHash(prevMinedLinkHash)
+		if err == nil && pointed.Hash().Compare(linkHash) {
+			err := app.minedLinkPointerFileService.Delete(prevMinedLinkHash.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		return app.fileService.Delete(linkHash.String())
 	})
 }
 
@@ -10,6 +10,7 @@ import (
 type serviceLinkMined struct {
 	eventManager           events.Manager
 	minedLinkRepository    link_mined.Repository
+	linkRepository         links.Repository
 	linkService            links.Service
 	fileService            files.Service
 	linkPointerFileService files.Service
@@ -20,6 +21,7 @@ type serviceLinkMined struct {
 func createServiceLinkMined(
 	eventManager events.Manager,
 	minedLinkRepository link_mined.Repository,
+	linkRepository links.Repository,
 	linkService links.Service,
 	fileService files.Service,
 	linkPointerFileService files.Service,
@@ -29,6 +31,7 @@ func createServiceLinkMined(
 	out := serviceLinkMined{
 		eventManager:           eventManager,
 		minedLinkRepository:    minedLinkRepository,
+		linkRepository:         linkRepository,
 		linkService:            linkService,
 		fileService:            fileService,
 		linkPointerFileService: linkPointerFileService,
@@ -39,13 +42,17 @@ func createServiceLinkMined(
 	return &out
 }
 
-// Insert inserts a mined link
+// Insert inserts a mined link, then points the head to it; its link is only saved if not already stored, since a link
+// can be mined more than once
 func (app *serviceLinkMined) Insert(minedLink link_mined.Link) error {
 	return app.eventManager.Trigger(EventLinkMinedInsert, minedLink, func() error {
 		link := minedLink.Link()
-		err := app.linkService.Insert(link)
+		_, err := app.linkRepository.Retrieve(link.Hash())
 		if err != nil {
-			return err
+			err := app.linkService.Insert(link)
+			if err != nil {
+				return err
+			}
 		}
 
 		minedLinkHashStr := minedLink.Hash().String()
@@ -55,24 +62,45 @@ func (app *serviceLinkMined) Insert(minedLink link_mined.Link) error {
 		}
 
 		// save the pointers:
-		err = app.linkPointerFileService.Insert(link.Hash().String(), minedLinkHashStr)
-		if err != nil {
-			return err
-		}
-
-		err = app.headPointerFileService.Insert(app.headFileName, minedLinkHashStr)
+		_, err = app.minedLinkRepository.RetrieveByLinkHash(link.Hash())
+		err = savePointer(app.linkPointerFileService, err == nil, link.Hash().String(), minedLinkHashStr)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		return app.UpdateHead(minedLink)
 	})
 }
 
-// Delete deletes a mined link
+// UpdateHead points the head to the given mined link
+func (app *serviceLinkMined) UpdateHead(minedLink link_mined.Link) error {
+	_, err := app.minedLinkRepository.Head()
+	return savePointer(app.headPointerFileService, err == nil, app.headFileName, minedLink.Hash().String())
+}
+
+// Delete deletes a mined link, then the pointer of its link and the head if they still point to it; the pointer
+// replaced by another mined link of the same link is kept
 func (app *serviceLinkMined) Delete(minedLink link_mined.Link) error {
 	return app.eventManager.Trigger(EventLinkMinedDelete, minedLink, func() error {
-		return app.fileService.Delete(minedLink.Hash().String())
+		minedLinkHash := minedLink.Hash()
+		linkHash := minedLink.Link().Hash()
+		pointed, err := app.minedLinkRepository.RetrieveByLinkHash(linkHash)
+		if err == nil && pointed.Hash().Compare(minedLinkHash) {
+			err := app.linkPointerFileService.Delete(linkHash.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		head, err := app.minedLinkRepository.Head()
+		if err == nil && head.Hash().Compare(minedLinkHash) {
+			err := app.headPointerFileService.Delete(app.headFileName)
+			if err != nil {
+				return err
+			}
+		}
+
+		return app.fileService.Delete(minedLinkHash.String())
 	})
 }
 
@@ -10,6 +10,7 @@ import (
 type serviceBlockMined struct {
 	eventManager         events.Manager
 	minedBlockRepository mined_blocks.Repository
+	blockRepository      blocks.Repository
 	blockService         blocks.Service
 	fileService          files.Service
 	pointerFileService   files.Service
@@ -18,6 +19,7 @@ type serviceBlockMined struct {
 func createServiceBlockMined(
 	eventManager events.Manager,
 	minedBlockRepository mined_blocks.Repository,
+	blockRepository blocks.Repository,
 	blockService blocks.Service,
 	fileService files.Service,
 	pointerFileService files.Service,
@@ -25,6 +27,7 @@ func createServiceBlockMined(
 	out := serviceBlockMined{
 		eventManager:         eventManager,
 		minedBlockRepository: minedBlockRepository,
+		blockRepository:      blockRepository,
 		blockService:         blockService,
 		fileService:          fileService,
 		pointerFileService:   pointerFileService,
@@ -33,12 +36,16 @@ func createServiceBlockMined(
 	return &out
 }
 
-// Insert inserts a block
+// Insert inserts a block; its underlying block is only saved if not already stored
 func (app *serviceBlockMined) Insert(minedBlock mined_blocks.Block) error {
 	return app.eventManager.Trigger(EventBlockMinedInsert, minedBlock, func() error {
-		err := app.blockService.Insert(minedBlock.Block())
+		block := minedBlock.Block()
+		_, err := app.blockRepository.Retrieve(block.Tree().Head())
 		if err != nil {
-			return err
+			err := app.blockService.Insert(block)
+			if err != nil {
+				return err
+			}
 		}
 
 		// save the mined block:
@@ -58,10 +65,15 @@ func (app *serviceBlockMined) Insert(minedBlock mined_blocks.Block) error {
 	})
 }
 
-// Delete deletes a block
+// Delete deletes a block, and the pointer of its underlying block
 func (app *serviceBlockMined) Delete(minedBlock mined_blocks.Block) error {
 	return app.eventManager.Trigger(EventBlockMinedDelete, minedBlock, func() error {
-		return app.fileService.Delete(minedBlock.Hash().String())
+		err := app.fileService.Delete(minedBlock.Hash().String())
+		if err != nil {
+			return err
+		}
+
+		return app.pointerFileService.Delete(minedBlock.Block().Tree().Head().String())
 	})
 }
 
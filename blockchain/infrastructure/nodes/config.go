@@ -0,0 +1,117 @@
+package nodes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// jsonConfig represents the content of a config file
+type jsonConfig struct {
+	DataDir      string   `json:"data_dir"`
+	Port         uint     `json:"port"`
+	Peers        []string `json:"peers"`
+	SyncInterval string   `json:"sync_interval"`
+	Mining       bool     `json:"mining"`
+	OrphanDepth  *uint    `json:"orphan_depth"`
+}
+
+type config struct {
+	dataDir      string
+	port         uint
+	peers        []string
+	syncInterval time.Duration
+	isMining     bool
+	orphanDepth  uint
+}
+
+func createConfigFromFile(path string) (Config, error) {
+	js, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return createConfig(js)
+}
+
+func createConfig(js []byte) (Config, error) {
+	ins := new(jsonConfig)
+	err := json.Unmarshal(js, ins)
+	if err != nil {
+		return nil, err
+	}
+
+	out := config{
+		dataDir:      DefaultDataDir,
+		port:         DefaultPort,
+		peers:        []string{},
+		syncInterval: DefaultSyncInterval,
+		isMining:     ins.Mining,
+		orphanDepth:  DefaultOrphanDepth,
+	}
+
+	if ins.DataDir != "" {
+		out.dataDir = ins.DataDir
+	}
+
+	if ins.Port != 0 {
+		out.port = ins.Port
+	}
+
+	if ins.Peers != nil {
+		out.peers = ins.Peers
+	}
+
+	if ins.OrphanDepth != nil {
+		out.orphanDepth = *ins.OrphanDepth
+	}
+
+	if ins.SyncInterval != "" {
+		syncInterval, err := time.ParseDuration(ins.SyncInterval)
+		if err != nil {
+			str := fmt.Sprintf("the sync interval (%s) is invalid: %s", ins.SyncInterval, err.Error())
+			return nil, errors.New(str)
+		}
+
+		if syncInterval <= 0 {
+			str := fmt.Sprintf("the sync interval (%s) was expected to be greater than zero", ins.SyncInterval)
+			return nil, errors.New(str)
+		}
+
+		out.syncInterval = syncInterval
+	}
+
+	return &out, nil
+}
+
+// DataDir returns the data directory
+func (obj *config) DataDir() string {
+	return obj.dataDir
+}
+
+// Port returns the port of the REST server
+func (obj *config) Port() uint {
+	return obj.port
+}
+
+// Peers returns the servers of the bootstrap peers
+func (obj *config) Peers() []string {
+	return obj.peers
+}
+
+// SyncInterval returns the interval between the sync rounds
+func (obj *config) SyncInterval() time.Duration {
+	return obj.syncInterval
+}
+
+// IsMining returns true if the node mines its chains, false otherwise
+func (obj *config) IsMining() bool {
+	return obj.isMining
+}
+
+// OrphanDepth returns the amount of links a chain grows past a fork point before its orphaned mined links are deleted
+func (obj *config) OrphanDepth() uint {
+	return obj.orphanDepth
+}
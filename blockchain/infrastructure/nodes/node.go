@@ -0,0 +1,184 @@
+package nodes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/disks"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+type node struct {
+	config        Config
+	remoteBuilder repositories.RemoteBuilder
+	repository    repositories.Application
+	application   services.Application
+	server        servers.Server
+	logger        observability.Logger
+	stop          chan struct{}
+	cancel        context.CancelFunc
+	wg            *sync.WaitGroup
+}
+
+func createNode(config Config, remoteBuilder repositories.RemoteBuilder, publisher services.Publisher) (Node, error) {
+	bootstrapPeers := []peers.Peer{}
+	peerBuilder := peers.NewPeerBuilder()
+	for _, oneServer := range config.Peers() {
+		peer, err := peerBuilder.Create().WithServer(oneServer).Now()
+		if err != nil {
+			return nil, err
+		}
+
+		bootstrapPeers = append(bootstrapPeers, peer)
+	}
+
+	syncInterval := config.SyncInterval()
+	disk, err := disks.NewDisk(config.DataDir(), fileMode, syncInterval, hydro.JSON, disks.BackendFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := disk.RepositoryApplication()
+	application := disk.ServiceApplication(remoteBuilder, publisher, bootstrapPeers, config.OrphanDepth())
+
+	server := servers.NewServer(repository, application.Gossip(), mux.NewRouter(), shutdownWaitPeriod, config.Port())
+	out := node{
+		config:        config,
+		remoteBuilder: remoteBuilder,
+		repository:    repository,
+		application:   application,
+		server:        server,
+		logger:        observability.DefaultLogger().With("component", "node"),
+		stop:          nil,
+		cancel:        nil,
+		wg:            new(sync.WaitGroup),
+	}
+
+	return &out, nil
+}
+
+// Repository returns the repository application
+func (app *node) Repository() repositories.Application {
+	return app.repository
+}
+
+// Application returns the service application
+func (app *node) Application() services.Application {
+	return app.application
+}
+
+// Start starts the REST server, then the sync and mining loop
+func (app *node) Start() error {
+	if app.stop != nil {
+		return errors.New("the node is already started")
+	}
+
+	err := app.server.Listen()
+	if err != nil {
+		return err
+	}
+
+	if app.remoteBuilder == nil {
+		app.logger.Info("no remote builder was given, the chains will not be synced")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	app.stop = make(chan struct{})
+	app.cancel = cancel
+	app.wg.Add(1)
+	go app.loop(ctx, app.stop)
+
+	app.logger.Info("the node is started", "port", app.config.Port(), "data_dir", app.config.DataDir(), "mining", app.config.IsMining())
+	return nil
+}
+
+// Stop stops the sync and mining loop, cancelling the current mining, then shuts down the REST server
+func (app *node) Stop() error {
+	if app.stop == nil {
+		return errors.New("the node is not started")
+	}
+
+	close(app.stop)
+	app.cancel()
+	app.wg.Wait()
+	app.stop = nil
+	app.cancel = nil
+
+	err := app.server.Shutdown()
+	if err != nil {
+		return err
+	}
+
+	app.logger.Info("the node is stopped")
+	return nil
+}
+
+func (app *node) loop(ctx context.Context, stop chan struct{}) {
+	defer app.wg.Done()
+
+	ticker := time.NewTicker(app.config.SyncInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			app.round(ctx)
+		}
+	}
+}
+
+// round syncs the chains with their peers, then mines them if mining is enabled
+func (app *node) round(ctx context.Context) {
+	if app.remoteBuilder != nil {
+		// the errors are logged by the chain application:
+		app.application.Chain().SyncOnce()
+	}
+
+	if !app.config.IsMining() {
+		return
+	}
+
+	chainIDs, err := app.repository.Chain().List()
+	if err != nil {
+		app.logger.Error("the chains could not be listed", "error", err)
+		return
+	}
+
+	for _, oneChainID := range chainIDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := app.application.Chain().Update(ctx, oneChainID)
+		if err != nil {
+			app.logger.Error("the chain could not be mined", "chain", oneChainID.String(), "error", err)
+			continue
+		}
+
+		app.announce(oneChainID)
+	}
+}
+
+// announce pushes the head of the chain to the peers; a head that was already announced is skipped by the gossip
+func (app *node) announce(chainID *uuid.UUID) {
+	chain, err := app.repository.Chain().Retrieve(chainID)
+	if err != nil {
+		app.logger.Error("the mined chain could not be retrieved", "chain", chainID.String(), "error", err)
+		return
+	}
+
+	if chain.HasHead() {
+		app.application.Gossip().AnnounceMinedLink(chain.Head())
+	}
+}
@@ -0,0 +1,114 @@
+package nodes
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestNode_createChain_thenStartAndStop_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	js := []byte(fmt.Sprintf(`{"data_dir": "%s", "port": 38471, "sync_interval": "50ms", "mining": false}`, basePath))
+	config, err := NewConfig(js)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	node, err := NewNode(config, nil, nil)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	initial, err := hash.NewAdapter().Hash([]byte("first transaction"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	id := uuid.NewV4()
+	created, err := node.Application().Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, []hash.Hash{*initial})
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if created.ID().String() != id.String() {
+		t.Errorf("the chain id was expected to be %s, %s returned", id.String(), created.ID().String())
+		return
+	}
+
+	ids, err := node.Repository().Chain().List()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(ids) != 1 {
+		t.Errorf("%d chain was expected, %d returned", 1, len(ids))
+		return
+	}
+
+	retrieved, err := node.Repository().Chain().Retrieve(&id)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retrieved.Root().Hash().Compare(created.Root().Hash()) {
+		t.Errorf("the retrieved chain was expected to have the same root as the created chain")
+		return
+	}
+
+	err = node.Start()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = node.Start()
+	if err == nil {
+		t.Errorf("the error was expected to be valid when starting an already started node, nil returned")
+		return
+	}
+
+	err = node.Stop()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+}
+
+func TestConfig_withDefaults_Success(t *testing.T) {
+	config, err := NewConfig([]byte(`{}`))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if config.DataDir() != DefaultDataDir || config.Port() != DefaultPort || config.SyncInterval() != DefaultSyncInterval || config.OrphanDepth() != DefaultOrphanDepth {
+		t.Errorf("the config was expected to contain the default values")
+		return
+	}
+
+	if config.IsMining() || len(config.Peers()) != 0 {
+		t.Errorf("the config was expected to have mining disabled and no peers")
+		return
+	}
+}
+
+func TestConfig_withInvalidSyncInterval_returnsError(t *testing.T) {
+	_, err := NewConfig([]byte(`{"sync_interval": "soon"}`))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -0,0 +1,61 @@
+package nodes
+
+import (
+	"os"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
+)
+
+// DefaultDataDir represents the default data directory
+const DefaultDataDir = "./data"
+
+// DefaultPort represents the default port of the REST server
+const DefaultPort = 8080
+
+// DefaultSyncInterval represents the default interval between the sync rounds
+const DefaultSyncInterval = time.Minute
+
+// DefaultOrphanDepth represents the default amount of links a chain grows past a fork point before its orphaned mined
+// links are deleted
+const DefaultOrphanDepth = 16
+
+const fileMode = os.FileMode(0700)
+
+// the wait period of the REST server, before closing the current requests on shutdown:
+const shutdownWaitPeriod = time.Second * 5
+
+// NewConfig parses a JSON config; the missing values are replaced by their defaults
+func NewConfig(js []byte) (Config, error) {
+	return createConfig(js)
+}
+
+// NewConfigFromFile reads a JSON config file
+func NewConfigFromFile(path string) (Config, error) {
+	return createConfigFromFile(path)
+}
+
+// NewNode creates a new node instance, on its own disk, so that several nodes can run in the same process.  Without a remote builder, the chains are not synced with their peers, and without a
+// publisher, the mined blocks and mined links are not pushed to them
+func NewNode(config Config, remoteBuilder repositories.RemoteBuilder, publisher services.Publisher) (Node, error) {
+	return createNode(config, remoteBuilder, publisher)
+}
+
+// Config represents a node configuration
+type Config interface {
+	DataDir() string
+	Port() uint
+	Peers() []string
+	SyncInterval() time.Duration
+	IsMining() bool
+	OrphanDepth() uint
+}
+
+// Node represents a node, serving its chains on the REST server while syncing and mining them in the background
+type Node interface {
+	Repository() repositories.Application
+	Application() services.Application
+	Start() error
+	Stop() error
+}
@@ -0,0 +1,59 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type block struct {
+	requester *requester
+}
+
+func createBlock(
+	requester *requester,
+) repositories.Block {
+	out := block{
+		requester: requester,
+	}
+
+	return &out
+}
+
+// List lists the block hashes
+func (app *block) List() ([]hash.Hash, error) {
+	data, contentType, err := app.requester.get("/blocks")
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeHashes(data, contentType)
+}
+
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *block) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	data, contentType, next, err := app.requester.getPage("/blocks", cursor, amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hashes, err := servers.DecodeHashes(data, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return hashes, next, nil
+}
+
+// Retrieve retrieves a block by hash
+func (app *block) Retrieve(hash hash.Hash) (blocks.Block, error) {
+	data, _, err := app.requester.get(fmt.Sprintf("/blocks/%s", hash.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeBlock(data)
+}
@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	uuid "github.com/satori/go.uuid"
+)
+
+type chain struct {
+	requester *requester
+	minedLink repositories.MinedLink
+}
+
+func createChain(
+	requester *requester,
+	minedLink repositories.MinedLink,
+) repositories.Chain {
+	out := chain{
+		requester: requester,
+		minedLink: minedLink,
+	}
+
+	return &out
+}
+
+// List lists the chain ids
+func (app *chain) List() ([]*uuid.UUID, error) {
+	data, contentType, err := app.requester.get("/chains")
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeIDs(data, contentType)
+}
+
+// ListPage lists a page of chain ids after the cursor, and returns the cursor of the next page
+func (app *chain) ListPage(cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	data, contentType, next, err := app.requester.getPage("/chains", cursor, amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ids, err := servers.DecodeIDs(data, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return ids, next, nil
+}
+
+// Retrieve retrieves a chain by id; its mined links are retrieved from the same peer
+func (app *chain) Retrieve(id *uuid.UUID) (chains.Chain, error) {
+	data, _, err := app.requester.get(fmt.Sprintf("/chains/%s", id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeChain(data, app.minedLink)
+}
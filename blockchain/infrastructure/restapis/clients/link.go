@@ -0,0 +1,59 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type link struct {
+	requester *requester
+}
+
+func createLink(
+	requester *requester,
+) repositories.Link {
+	out := link{
+		requester: requester,
+	}
+
+	return &out
+}
+
+// List lists the link hashes
+func (app *link) List() ([]hash.Hash, error) {
+	data, contentType, err := app.requester.get("/links")
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeHashes(data, contentType)
+}
+
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *link) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	data, contentType, next, err := app.requester.getPage("/links", cursor, amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hashes, err := servers.DecodeHashes(data, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return hashes, next, nil
+}
+
+// Retrieve retrieves a link by hash
+func (app *link) Retrieve(hash hash.Hash) (links.Link, error) {
+	data, _, err := app.requester.get(fmt.Sprintf("/links/%s", hash.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeLink(data)
+}
@@ -0,0 +1,59 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	mined_block "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type minedBlock struct {
+	requester *requester
+}
+
+func createMinedBlock(
+	requester *requester,
+) repositories.MinedBlock {
+	out := minedBlock{
+		requester: requester,
+	}
+
+	return &out
+}
+
+// List lists the mined block hashes
+func (app *minedBlock) List() ([]hash.Hash, error) {
+	data, contentType, err := app.requester.get("/mblocks")
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeHashes(data, contentType)
+}
+
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *minedBlock) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	data, contentType, next, err := app.requester.getPage("/mblocks", cursor, amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hashes, err := servers.DecodeHashes(data, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return hashes, next, nil
+}
+
+// Retrieve retrieves a mined block by hash
+func (app *minedBlock) Retrieve(hash hash.Hash) (mined_block.Block, error) {
+	data, _, err := app.requester.get(fmt.Sprintf("/mblocks/%s", hash.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeMinedBlock(data)
+}
@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	mined_link "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type minedLink struct {
+	requester *requester
+}
+
+func createMinedLink(
+	requester *requester,
+) repositories.MinedLink {
+	out := minedLink{
+		requester: requester,
+	}
+
+	return &out
+}
+
+// List lists the mined link hashes
+func (app *minedLink) List() ([]hash.Hash, error) {
+	data, contentType, err := app.requester.get("/mlinks")
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeHashes(data, contentType)
+}
+
+// ListPage lists a page of hashes after the cursor, and returns the cursor of the next page
+func (app *minedLink) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	data, contentType, next, err := app.requester.getPage("/mlinks", cursor, amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hashes, err := servers.DecodeHashes(data, contentType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return hashes, next, nil
+}
+
+// Head retrieves the head mined link
+func (app *minedLink) Head() (mined_link.Link, error) {
+	data, _, err := app.requester.get("/mlinks/head")
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeMinedLink(data)
+}
+
+// Retrieve retrieves a mined link by hash
+func (app *minedLink) Retrieve(hash hash.Hash) (mined_link.Link, error) {
+	data, _, err := app.requester.get(fmt.Sprintf("/mlinks/%s", hash.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return servers.DecodeMinedLink(data)
+}
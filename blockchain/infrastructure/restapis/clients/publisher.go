@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/services"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+type publisher struct {
+	client          *http.Client
+	logger          observability.Logger
+	scheme          string
+	retries         uint
+	retryWaitPeriod time.Duration
+}
+
+func createPublisher(
+	client *http.Client,
+	logger observability.Logger,
+	scheme string,
+	retries uint,
+	retryWaitPeriod time.Duration,
+) services.Publisher {
+	out := publisher{
+		client:          client,
+		logger:          logger,
+		scheme:          scheme,
+		retries:         retries,
+		retryWaitPeriod: retryWaitPeriod,
+	}
+
+	return &out
+}
+
+// MinedBlock pushes a mined block to the peer
+func (app *publisher) MinedBlock(peer peers.Peer, minedBlock block_mined.Block) error {
+	data, err := servers.EncodeMinedBlock(minedBlock)
+	if err != nil {
+		return err
+	}
+
+	return app.post(peer, "/mblocks", data)
+}
+
+// MinedLink pushes a mined link to the peer
+func (app *publisher) MinedLink(peer peers.Peer, minedLink link_mined.Link) error {
+	data, err := servers.EncodeMinedLink(minedLink)
+	if err != nil {
+		return err
+	}
+
+	return app.post(peer, "/mlinks", data)
+}
+
+func (app *publisher) post(peer peers.Peer, path string, data []byte) error {
+	requester, err := createRequesterByPeer(app.client, app.logger, app.scheme, app.retries, app.retryWaitPeriod, peer)
+	if err != nil {
+		return err
+	}
+
+	return requester.post(path, data)
+}
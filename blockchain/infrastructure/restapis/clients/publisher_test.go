@@ -0,0 +1,420 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/services"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/disks"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestPublisher_withGossipServer_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	disks.Init(basePath, 0777, time.Duration(time.Second))
+
+	// the other nodes record the pushed instances:
+	first := createRecordingNodeForTests()
+	defer first.server.Close()
+
+	second := createRecordingNodeForTests()
+	defer second.server.Close()
+
+	bootstrapPeers := []peers.Peer{
+		createPeerForTests(t, first.server.Listener.Addr()),
+		createPeerForTests(t, second.server.Listener.Addr()),
+	}
+
+	// create a chain on the gossiping node:
+	publisher := NewPublisher()
+	app := disks.NewServiceApplication(nil, publisher, bootstrapPeers, 0)
+	initial, err := hash.NewAdapter().Hash([]byte("first transaction"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	id := uuid.NewV4()
+	local, err := app.Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, []hash.Hash{*initial})
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// serve the gossiping node:
+	port, err := freePort()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	server := servers.NewServer(disks.NewRepositoryApplication(), app.Gossip(), mux.NewRouter(), time.Second, port)
+	err = server.Listen()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer server.Shutdown()
+
+	peer, err := peers.NewPeerBuilder().Create().WithServer(fmt.Sprintf("https://127.0.0.1:%d", port)).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// push a mined link that extends the chain:
+	minedLink := createMinedLinkForTests(t, local.Root().Hash())
+	err = publisher.MinedLink(peer, minedLink)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retChain, err := disks.NewRepositoryApplication().Chain().Retrieve(&id)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retChain.HasHead() || !retChain.Head().Hash().Compare(minedLink.Hash()) || retChain.Height() != 1 {
+		t.Errorf("the chain was expected to be extended by the pushed mined link")
+		return
+	}
+
+	// the mined link is re-announced to the other nodes:
+	path := fmt.Sprintf("/mlinks#%s", minedLink.Hash().String())
+	for _, oneNode := range []*recordingNodeForTests{first, second} {
+		if !oneNode.waitFor(path, 1, time.Second*5) {
+			t.Errorf("the mined link was expected to be announced once to every node, %d announcements received", oneNode.amount(path))
+			return
+		}
+	}
+
+	// pushing the same mined link again, or the root mined block, is accepted but not re-announced:
+	err = publisher.MinedLink(peer, minedLink)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = publisher.MinedBlock(peer, local.Root())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	time.Sleep(time.Millisecond * 200)
+	for _, oneNode := range []*recordingNodeForTests{first, second} {
+		if oneNode.amount(path) != 1 || oneNode.amount("/mblocks") != 0 {
+			t.Errorf("the known instances were not expected to be announced again")
+			return
+		}
+	}
+
+	// a mined link that does not extend a chain is rejected:
+	unknown, err := hash.NewAdapter().Hash([]byte("unknown mined link"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = publisher.MinedLink(peer, createMinedLinkForTests(t, *unknown))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestPublisher_withNodes_propagates_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// the nodes gossip in a cycle, A to B, B to C and C back to A, and C also announces to a recording node:
+	recorder := createRecordingNodeForTests()
+	defer recorder.server.Close()
+
+	names := []string{"a", "b", "c"}
+	ports := []uint{}
+	nodePeers := []peers.Peer{}
+	for range names {
+		port, err := freePort()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		peer, err := peers.NewPeerBuilder().Create().WithServer(fmt.Sprintf("https://127.0.0.1:%d", port)).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		ports = append(ports, port)
+		nodePeers = append(nodePeers, peer)
+	}
+
+	bootstrapPeers := [][]peers.Peer{
+		{nodePeers[1]},
+		{nodePeers[2]},
+		{nodePeers[0], createPeerForTests(t, recorder.server.Listener.Addr())},
+	}
+
+	// create the chain on A, then copy its data directory to B and C:
+	firstDisk, err := disks.NewDisk(filepath.Join(basePath, names[0]), 0777, time.Second, hydro.JSON, disks.BackendFiles)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	initial, err := hash.NewAdapter().Hash([]byte("first transaction"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	id := uuid.NewV4()
+	local, err := firstDisk.ServiceApplication(nil, nil, nil, 0).Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, []hash.Hash{*initial})
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for _, oneName := range names[1:] {
+		err := copyDirForTests(filepath.Join(basePath, names[0]), filepath.Join(basePath, oneName))
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	// serve every node on its own disk:
+	publisher := NewPublisher()
+	nodeDisks := []disks.Disk{firstDisk}
+	for index, oneName := range names {
+		if index > 0 {
+			disk, err := disks.NewDisk(filepath.Join(basePath, oneName), 0777, time.Second, hydro.JSON, disks.BackendFiles)
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			nodeDisks = append(nodeDisks, disk)
+		}
+
+		defer nodeDisks[index].Close()
+
+		app := nodeDisks[index].ServiceApplication(nil, publisher, bootstrapPeers[index], 0)
+		server := servers.NewServer(nodeDisks[index].RepositoryApplication(), app.Gossip(), mux.NewRouter(), time.Second, ports[index])
+		err := server.Listen()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		defer server.Shutdown()
+	}
+
+	// push a mined link to A only, it reaches C through B:
+	minedLink := createMinedLinkForTests(t, local.Root().Hash())
+	err = publisher.MinedLink(nodePeers[0], minedLink)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for index, oneDisk := range nodeDisks {
+		isExtended := false
+		deadline := time.Now().Add(time.Second * 5)
+		for !isExtended && time.Now().Before(deadline) {
+			retChain, err := oneDisk.RepositoryApplication().Chain().Retrieve(&id)
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			isExtended = retChain.HasHead() && retChain.Head().Hash().Compare(minedLink.Hash()) && retChain.Height() == 1
+			if !isExtended {
+				time.Sleep(time.Millisecond * 10)
+			}
+		}
+
+		if !isExtended {
+			t.Errorf("the chain of the node %s was expected to be extended by the pushed mined link", names[index])
+			return
+		}
+	}
+
+	// the cycle ends on the nodes that already know the mined link, so it leaves the cycle once:
+	path := fmt.Sprintf("/mlinks#%s", minedLink.Hash().String())
+	if !recorder.waitFor(path, 1, time.Second*5) {
+		t.Errorf("the mined link was expected to be announced once, %d announcements received", recorder.amount(path))
+		return
+	}
+
+	// pushing the same mined link again to every node is accepted but not re-announced:
+	for _, onePeer := range nodePeers {
+		err = publisher.MinedLink(onePeer, minedLink)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	time.Sleep(time.Millisecond * 200)
+	if recorder.amount(path) != 1 {
+		t.Errorf("the mined link was expected to be announced once, %d announcements received", recorder.amount(path))
+		return
+	}
+}
+
+func createPeerForTests(t *testing.T, addr net.Addr) peers.Peer {
+	peer, err := peers.NewPeerBuilder().Create().WithServer(fmt.Sprintf("https://127.0.0.1:%d", addr.(*net.TCPAddr).Port)).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return peer
+}
+
+func copyDirForTests(from string, to string) error {
+	return filepath.Walk(from, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(to, relPath)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+func createMinedLinkForTests(t *testing.T, prevMinedLink hash.Hash) link_mined.Link {
+	hsh, err := hash.NewAdapter().Hash([]byte("second transaction"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	block, err := blocks.NewBuilder().Create().WithHashes([]hash.Hash{*hsh}).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	link, err := links.NewBuilder().Create().WithPreviousMinedLink(prevMinedLink).WithNextBlock(block).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	results, _, err := services.NewMiner().Mine(context.Background(), 2, 1, link.Hash())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	minedLink, err := link_mined.NewBuilder().Create().WithLink(link).WithResults(results).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return minedLink
+}
+
+// recordingNodeForTests represents an in-process node that records the instances pushed to it, by path and hash
+type recordingNodeForTests struct {
+	server   *httptest.Server
+	received map[string]int
+	mutex    sync.Mutex
+}
+
+func createRecordingNodeForTests() *recordingNodeForTests {
+	out := recordingNodeForTests{
+		received: map[string]int{},
+	}
+
+	out.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		keyname := r.URL.Path
+		if minedLink, err := servers.DecodeMinedLink(data); err == nil {
+			keyname = fmt.Sprintf("%s#%s", r.URL.Path, minedLink.Hash().String())
+		}
+
+		out.mutex.Lock()
+		out.received[keyname]++
+		out.received[r.URL.Path]++
+		out.mutex.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	return &out
+}
+
+func (obj *recordingNodeForTests) amount(keyname string) int {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	return obj.received[keyname]
+}
+
+func (obj *recordingNodeForTests) waitFor(keyname string, amount int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if obj.amount(keyname) >= amount {
+			return obj.amount(keyname) == amount
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+type remoteBuilder struct {
+	client          *http.Client
+	logger          observability.Logger
+	scheme          string
+	retries         uint
+	retryWaitPeriod time.Duration
+	peer            peers.Peer
+}
+
+func createRemoteBuilder(
+	client *http.Client,
+	logger observability.Logger,
+	scheme string,
+	retries uint,
+	retryWaitPeriod time.Duration,
+) repositories.RemoteBuilder {
+	out := remoteBuilder{
+		client:          client,
+		logger:          logger,
+		scheme:          scheme,
+		retries:         retries,
+		retryWaitPeriod: retryWaitPeriod,
+		peer:            nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *remoteBuilder) Create() repositories.RemoteBuilder {
+	return createRemoteBuilder(app.client, app.logger, app.scheme, app.retries, app.retryWaitPeriod)
+}
+
+// WithPeer adds a peer to the builder
+func (app *remoteBuilder) WithPeer(peer peers.Peer) repositories.RemoteBuilder {
+	app.peer = peer
+	return app
+}
+
+// Now builds a new remote Application instance
+func (app *remoteBuilder) Now() (repositories.Application, error) {
+	if app.peer == nil {
+		return nil, errors.New("the peer is mandatory in order to build a remote Application instance")
+	}
+
+	requester, err := createRequesterByPeer(app.client, app.logger, app.scheme, app.retries, app.retryWaitPeriod, app.peer)
+	if err != nil {
+		return nil, err
+	}
+
+	minedLink := createMinedLink(requester)
+	return repositories.NewApplication(
+		createBlock(requester),
+		createMinedBlock(requester),
+		createLink(requester),
+		minedLink,
+		createChain(requester, minedLink),
+	), nil
+}
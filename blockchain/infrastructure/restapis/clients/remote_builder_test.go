@@ -0,0 +1,318 @@
+package clients
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/disks"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestRemoteBuilder_withServer_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	disks.Init(basePath, 0777, time.Duration(time.Second))
+
+	// create a chain:
+	initial, err := hash.NewAdapter().Hash([]byte("first transaction"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	id := uuid.NewV4()
+	local, err := disks.NewServiceApplication(nil, nil, nil, 0).Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, []hash.Hash{*initial})
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// serve the repositories:
+	port, err := freePort()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	server := servers.NewServer(disks.NewRepositoryApplication(), nil, mux.NewRouter(), time.Second, port)
+	err = server.Listen()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer server.Shutdown()
+
+	// build the remote application:
+	peer, err := peers.NewPeerBuilder().Create().WithServer(fmt.Sprintf("https://127.0.0.1:%d", port)).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	remote, err := NewRemoteBuilder().Create().WithPeer(peer).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	ids, err := remote.Chain().List()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(ids) != 1 || ids[0].String() != id.String() {
+		t.Errorf("the chain list was expected to only contain the chain (id: %s)", id.String())
+		return
+	}
+
+	retrieved, err := remote.Chain().Retrieve(&id)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retrieved.Root().Hash().Compare(local.Root().Hash()) {
+		t.Errorf("the remote chain was expected to have the same root as the local chain")
+		return
+	}
+
+	if !retrieved.Genesis().Hash().Compare(local.Genesis().Hash()) {
+		t.Errorf("the remote chain was expected to have the same genesis as the local chain")
+		return
+	}
+
+	if retrieved.Height() != local.Height() || retrieved.TotalHashes() != local.TotalHashes() {
+		t.Errorf("the remote chain was expected to have the same height and amount of hashes as the local chain")
+		return
+	}
+
+	minedBlockHashes, err := remote.MinedBlock().List()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(minedBlockHashes) != 1 {
+		t.Errorf("%d mined block was expected, %d returned", 1, len(minedBlockHashes))
+		return
+	}
+
+	minedBlock, err := remote.MinedBlock().Retrieve(minedBlockHashes[0])
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	block, err := remote.Block().Retrieve(minedBlock.Block().Tree().Head())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(block.Hashes()) != 1 || !block.Hashes()[0].Compare(*initial) {
+		t.Errorf("the remote block was expected to contain the initial hash")
+		return
+	}
+}
+
+func TestRemoteBuilder_listPage_withServer_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// init:
+	disks.Init(basePath, 0777, time.Duration(time.Second))
+
+	// create the chains, on different root blocks:
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		initial, err := hash.NewAdapter().Hash([]byte(fmt.Sprintf("transaction %d", i)))
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		id := uuid.NewV4()
+		_, err = disks.NewServiceApplication(nil, nil, nil, 0).Chain().Create(&id, 2, 1, 0.0001, 1, 0, 0, []hash.Hash{*initial})
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		ids = append(ids, id.String())
+	}
+
+	sort.Strings(ids)
+
+	// serve the repositories:
+	port, err := freePort()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	server := servers.NewServer(disks.NewRepositoryApplication(), nil, mux.NewRouter(), time.Second, port)
+	err = server.Listen()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer server.Shutdown()
+
+	peer, err := peers.NewPeerBuilder().Create().WithServer(fmt.Sprintf("https://127.0.0.1:%d", port)).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	remote, err := NewRemoteBuilder().Create().WithPeer(peer).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// list the chains, two at a time:
+	listed := []string{}
+	pages := 0
+	cursor := ""
+	for {
+		page, next, err := remote.Chain().ListPage(cursor, 2)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		pages++
+		for _, oneID := range page {
+			listed = append(listed, oneID.String())
+		}
+
+		if next == "" {
+			break
+		}
+
+		cursor = next
+	}
+
+	if pages != 2 || !reflect.DeepEqual(listed, ids) {
+		t.Errorf("the chains were expected to be listed in order, in %d pages: %v, %d pages", 2, listed, pages)
+		return
+	}
+
+	// an invalid amount is a bad request:
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/chains?%s=invalid", port, servers.AmountKeyname))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("the status %d was expected, %d returned", http.StatusBadRequest, resp.StatusCode)
+		return
+	}
+}
+
+func TestRemoteBuilder_retriesServerErrors_Success(t *testing.T) {
+	amount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		amount++
+		if amount < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set(contentTypeHeaderKeyname, servers.ContentTypeJSON)
+		w.Write([]byte("[]"))
+	}))
+
+	defer server.Close()
+
+	requester := createRequester(server.Client(), observability.NewLogger(ioutil.Discard, observability.LevelError), server.URL, 2, time.Millisecond)
+	_, _, err := requester.get("/chains")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 3 {
+		t.Errorf("%d requests were expected, %d executed", 3, amount)
+		return
+	}
+}
+
+func TestRemoteBuilder_doesNotRetryClientErrors_returnsError(t *testing.T) {
+	amount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		amount++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+
+	defer server.Close()
+
+	requester := createRequester(server.Client(), observability.NewLogger(ioutil.Discard, observability.LevelError), server.URL, 2, time.Millisecond)
+	_, _, err := requester.get("/chains/invalid")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("%d request was expected, %d executed", 1, amount)
+		return
+	}
+}
+
+func TestRemoteBuilder_bodyTooLarge_returnsError(t *testing.T) {
+	amount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		amount++
+		w.Header().Set(contentTypeHeaderKeyname, servers.ContentTypeJSON)
+		w.Write(make([]byte, servers.MaxBodySize+1))
+	}))
+
+	defer server.Close()
+
+	requester := createRequester(server.Client(), observability.NewLogger(ioutil.Discard, observability.LevelError), server.URL, 2, time.Millisecond)
+	_, _, err := requester.get("/chains")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("%d request was expected, %d executed", 1, amount)
+		return
+	}
+}
+
+func freePort() (uint, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+
+	defer listener.Close()
+	return uint(listener.Addr().(*net.TCPAddr).Port), nil
+}
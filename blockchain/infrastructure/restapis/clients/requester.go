@@ -0,0 +1,168 @@
+package clients
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/servers"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+type requester struct {
+	client          *http.Client
+	logger          observability.Logger
+	baseURL         string
+	retries         uint
+	retryWaitPeriod time.Duration
+}
+
+func createRequester(
+	client *http.Client,
+	logger observability.Logger,
+	baseURL string,
+	retries uint,
+	retryWaitPeriod time.Duration,
+) *requester {
+	out := requester{
+		client:          client,
+		logger:          logger,
+		baseURL:         baseURL,
+		retries:         retries,
+		retryWaitPeriod: retryWaitPeriod,
+	}
+
+	return &out
+}
+
+// createRequesterByPeer creates a requester on the server of the peer; only the normal peers can be reached
+func createRequesterByPeer(
+	client *http.Client,
+	logger observability.Logger,
+	scheme string,
+	retries uint,
+	retryWaitPeriod time.Duration,
+	peer peers.Peer,
+) (*requester, error) {
+	content := peer.Content()
+	if !content.IsNormal() {
+		str := fmt.Sprintf("the peer (%s) cannot be reached, only the normal peers are supported", content.String())
+		return nil, errors.New(str)
+	}
+
+	server := content.Normal()
+	baseURL := fmt.Sprintf(urlPattern, scheme, server.Host(), server.Port())
+	return createRequester(client, logger.With("peer", content.String()), baseURL, retries, retryWaitPeriod), nil
+}
+
+// get executes a GET request on the path, retrying it on network and server errors, and returns the body and its
+// content type
+func (app *requester) get(path string) ([]byte, string, error) {
+	data, header, err := app.do(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, contentType(header), nil
+}
+
+// getPage executes a GET request of a list page on the path, and returns the body, its content type and the cursor of
+// the next page
+func (app *requester) getPage(path string, cursor string, amount uint) ([]byte, string, string, error) {
+	values := url.Values{}
+	values.Set(servers.CursorKeyname, cursor)
+	values.Set(servers.AmountKeyname, strconv.FormatUint(uint64(amount), 10))
+	data, header, err := app.do(http.MethodGet, fmt.Sprintf("%s?%s", path, values.Encode()), nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return data, contentType(header), header.Get(servers.NextCursorHeaderKeyname), nil
+}
+
+// post executes a POST request of the binary body on the path, retrying it on network and server errors
+func (app *requester) post(path string, body []byte) error {
+	_, _, err := app.do(http.MethodPost, path, body)
+	return err
+}
+
+// do executes a request on the path, retrying it on network and server errors, and returns the body and its headers
+func (app *requester) do(method string, path string, body []byte) ([]byte, http.Header, error) {
+	url := fmt.Sprintf("%s%s", app.baseURL, path)
+	waitPeriod := app.retryWaitPeriod
+
+	var lastErr error
+	for attempt := uint(0); attempt <= app.retries; attempt++ {
+		if attempt > 0 {
+			app.logger.Debug("retrying the request", "method", method, "url", url, "attempt", attempt, "error", lastErr)
+			time.Sleep(waitPeriod)
+			waitPeriod *= 2
+		}
+
+		data, header, isRetryable, err := app.execute(method, url, body)
+		if err == nil {
+			return data, header, nil
+		}
+
+		lastErr = err
+		if !isRetryable {
+			break
+		}
+	}
+
+	return nil, nil, lastErr
+}
+
+func (app *requester) execute(method string, url string, body []byte) ([]byte, http.Header, bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	req.Header.Set(acceptHeaderKeyname, acceptHeaderValue)
+	if body != nil {
+		req.Header.Set(contentTypeHeaderKeyname, servers.ContentTypeBinary)
+	}
+
+	resp, err := app.client.Do(req)
+	if err != nil {
+		return nil, nil, true, err
+	}
+
+	// one byte past the max size is read, to tell a body of the max size from a longer one:
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, servers.MaxBodySize+1))
+	if err != nil {
+		return nil, nil, true, err
+	}
+
+	if len(data) > servers.MaxBodySize {
+		str := fmt.Sprintf("the response (url: %s) was expected to contain at most %d bytes", url, servers.MaxBodySize)
+		return nil, nil, false, errors.New(str)
+	}
+
+	// a pushed instance is created, or was already known:
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		str := fmt.Sprintf("the request (url: %s) failed with the status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(data)))
+		return nil, nil, resp.StatusCode >= http.StatusInternalServerError, errors.New(str)
+	}
+
+	return data, resp.Header, false, nil
+}
+
+func contentType(header http.Header) string {
+	return strings.TrimSpace(strings.Split(header.Get(contentTypeHeaderKeyname), ";")[0])
+}
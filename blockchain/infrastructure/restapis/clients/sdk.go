@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+// DefaultScheme represents the default scheme used to reach the REST servers, which serve plain HTTP
+const DefaultScheme = "http"
+
+// DefaultTimeout represents the default timeout of a request
+const DefaultTimeout = time.Second * 10
+
+// DefaultRetries represents the default amount of retries of a failed request
+const DefaultRetries = 3
+
+// DefaultRetryWaitPeriod represents the default wait period before the first retry; it doubles on every retry
+const DefaultRetryWaitPeriod = time.Millisecond * 250
+
+const acceptHeaderKeyname = "Accept"
+
+const contentTypeHeaderKeyname = "Content-Type"
+
+// the binary format is preferred, since it is the canonical one:
+const acceptHeaderValue = "application/octet-stream, application/json;q=0.9"
+
+const urlPattern = "%s://%s:%d"
+
+// NewRemoteBuilder creates a new remote builder instance, using the default options
+func NewRemoteBuilder() repositories.RemoteBuilder {
+	return NewRemoteBuilderWithOptions(DefaultScheme, DefaultTimeout, DefaultRetries, DefaultRetryWaitPeriod)
+}
+
+// NewRemoteBuilderWithOptions creates a new remote builder instance.  A request that fails on the network, or with a
+// server error, is retried the given amount of times
+func NewRemoteBuilderWithOptions(
+	scheme string,
+	timeout time.Duration,
+	retries uint,
+	retryWaitPeriod time.Duration,
+) repositories.RemoteBuilder {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	logger := observability.DefaultLogger().With("component", "rest_client")
+	return createRemoteBuilder(client, logger, scheme, retries, retryWaitPeriod)
+}
+
+// NewPublisher creates a new publisher instance, using the default options
+func NewPublisher() services.Publisher {
+	return NewPublisherWithOptions(DefaultScheme, DefaultTimeout, DefaultRetries, DefaultRetryWaitPeriod)
+}
+
+// NewPublisherWithOptions creates a new publisher instance.  A push that fails on the network, or with a server error,
+// is retried the given amount of times
+func NewPublisherWithOptions(
+	scheme string,
+	timeout time.Duration,
+	retries uint,
+	retryWaitPeriod time.Duration,
+) services.Publisher {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	logger := observability.DefaultLogger().With("component", "rest_publisher")
+	return createPublisher(client, logger, scheme, retries, retryWaitPeriod)
+}
@@ -0,0 +1,194 @@
+package servers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
+	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	"github.com/deepvalue-network/software/blockchain/domain/links"
+	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+	uuid "github.com/satori/go.uuid"
+)
+
+func decodeHashes(data []byte, contentType string) ([]hash.Hash, error) {
+	list, err := decodeList(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []hash.Hash{}
+	hashAdapter := hash.NewAdapter()
+	for _, oneHashStr := range list {
+		hsh, err := hashAdapter.FromString(oneHashStr)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, *hsh)
+	}
+
+	return out, nil
+}
+
+func decodeIDs(data []byte, contentType string) ([]*uuid.UUID, error) {
+	list, err := decodeList(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []*uuid.UUID{}
+	for _, oneIDStr := range list {
+		id, err := uuid.FromString(oneIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, &id)
+	}
+
+	return out, nil
+}
+
+func decodeList(data []byte, contentType string) ([]string, error) {
+	list := []string{}
+	if contentType == ContentTypeBinary {
+		err := hydro.UnmarshalBinary(data, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		return list, nil
+	}
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func decodeBlock(data []byte) (blocks.Block, error) {
+	ins, err := internalHydroAdapter.Decode(data, new(entityHydratedBlock))
+	if err != nil {
+		return nil, err
+	}
+
+	if block, ok := ins.(blocks.Block); ok {
+		return block, nil
+	}
+
+	return nil, errors.New("the decoded instance was expected to be a Block")
+}
+
+func decodeMinedBlock(data []byte) (block_mined.Block, error) {
+	ins, err := internalHydroAdapter.Decode(data, new(entityHydratedBlockMined))
+	if err != nil {
+		return nil, err
+	}
+
+	if block, ok := ins.(block_mined.Block); ok {
+		return block, nil
+	}
+
+	return nil, errors.New("the decoded instance was expected to be a mined Block")
+}
+
+func decodeLink(data []byte) (links.Link, error) {
+	ins, err := internalHydroAdapter.Decode(data, new(entityHydratedLink))
+	if err != nil {
+		return nil, err
+	}
+
+	if link, ok := ins.(links.Link); ok {
+		return link, nil
+	}
+
+	return nil, errors.New("the decoded instance was expected to be a Link")
+}
+
+func decodeMinedLink(data []byte) (link_mined.Link, error) {
+	ins, err := internalHydroAdapter.Decode(data, new(entityHydratedLinkMined))
+	if err != nil {
+		return nil, err
+	}
+
+	return toMinedLink(ins)
+}
+
+// decodeChain decodes a chain, then applies the mined links from its root to its head, retrieved from the repository
+func decodeChain(data []byte, minedLinkRepository repositories.MinedLink) (chains.Chain, error) {
+	ptr := new(entityHydratedChain)
+	err := internalHydroAdapter.Unmarshal(data, ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	// dehydrate the chain without its head:
+	hydratedHead := ptr.Head
+	ptr.Head = nil
+	ins, err := internalHydroAdapter.Dehydrate(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, ok := ins.(chains.Chain)
+	if !ok {
+		return nil, errors.New("the decoded instance was expected to be a Chain")
+	}
+
+	if hydratedHead == nil {
+		return chain, nil
+	}
+
+	headIns, err := internalHydroAdapter.Dehydrate(hydratedHead)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := toMinedLink(headIns)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve the mined links from the head back to the root:
+	rootHash := chain.Root().Hash()
+	list := []link_mined.Link{head}
+	for {
+		prevHash := list[0].Link().PrevMinedLink()
+		if prevHash.Compare(rootHash) {
+			break
+		}
+
+		prev, err := minedLinkRepository.Retrieve(prevHash)
+		if err != nil {
+			str := fmt.Sprintf("the previous mined link (hash: %s) of the chain (id: %s) could not be retrieved: %s", prevHash.String(), chain.ID().String(), err.Error())
+			return nil, errors.New(str)
+		}
+
+		list = append([]link_mined.Link{prev}, list...)
+	}
+
+	for _, oneMinedLink := range list {
+		chain, err = chains.NewBuilder(chain.Peers().SyncInterval()).Create().WithOriginal(chain).WithHead(oneMinedLink).CreatedOn(chain.CreatedOn()).Now()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return chain, nil
+}
+
+func toMinedLink(ins interface{}) (link_mined.Link, error) {
+	if link, ok := ins.(link_mined.Link); ok {
+		return link, nil
+	}
+
+	return nil, errors.New("the decoded instance was expected to be a mined Link")
+}
@@ -1,6 +1,7 @@
 package servers
 
 import (
+	"errors"
 	"time"
 
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
@@ -24,6 +25,16 @@ func newBlock(
 		hashes = append(hashes, oneLeaf.Head())
 	}
 
+	// remove the leaves that only pad the tree to a power of two, since the tree pads them back:
+	filler, err := hash.NewAdapter().Hash(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(hashes) > 1 && hashes[len(hashes)-1].Compare(*filler) {
+		hashes = hashes[:len(hashes)-1]
+	}
+
 	return blocks.NewBuilder().
 		Create().
 		WithHashes(hashes).
@@ -96,20 +107,6 @@ func newPeers(
 	return builder.Now()
 }
 
-func newGenesis(
-	miningValue uint8,
-	blockBaseDifficulty uint,
-	blockIncreasePerHashDifficulty float64,
-	linkDifficulty uint,
-) (genesis.Genesis, error) {
-	return genesis.NewBuilder().Create().
-		WithMiningValue(miningValue).
-		WithBlockBaseDifficulty(blockBaseDifficulty).
-		WithBlockIncreasePerHashDifficulty(blockIncreasePerHashDifficulty).
-		WithLinkDifficulty(linkDifficulty).
-		Now()
-}
-
 func newChain(
 	id *uuid.UUID,
 	peers peers.Peers,
@@ -118,7 +115,12 @@ func newChain(
 	createdOn time.Time,
 	head mined_link.Link,
 ) (chains.Chain, error) {
-	builder := chains.NewBuilder(internalPeerSyncInterval).
+	// the servers render the chains with their peers, so the chains are built with the sync interval of their peers:
+	if peers == nil {
+		return nil, errors.New("the peers are mandatory in order to decode a Chain instance")
+	}
+
+	builder := chains.NewBuilder(peers.SyncInterval()).
 		Create().
 		WithID(id).
 		WithPeers(peers).
@@ -126,19 +128,15 @@ func newChain(
 		WithGenesis(gen).
 		CreatedOn(createdOn)
 
-	if head != nil {
-		builder.WithHead(head)
+	chain, err := builder.Now()
+	if err != nil {
+		return nil, err
 	}
 
+	// the height of a decoded chain only counts its head; DecodeChain walks the previous mined links:
 	if head != nil {
-		// retrieve previous version of chain:
-		prevChain, err := internalChainRepository.Retrieve(id)
-		if err != nil {
-			return nil, err
-		}
-
-		builder.WithOriginal(prevChain)
+		return chains.NewBuilder(peers.SyncInterval()).Create().WithOriginal(chain).WithHead(head).CreatedOn(createdOn).Now()
 	}
 
-	return builder.Now()
+	return chain, nil
 }
@@ -5,12 +5,21 @@ import (
 )
 
 type entityHydratedBlock struct {
-	Tree hashtree.Compact `json:"tree" hydro:"0"`
+	Tree *hashtree.JSONCompact `json:"tree" hydro:"0"`
 }
 
 func blockOnHydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if tree, ok := ins.(hashtree.HashTree); ok {
-		return tree.Compact(), nil
+		compact := tree.Compact()
+		return hashtree.ToJSON(compact), nil
+	}
+
+	return nil, nil
+}
+
+func blockOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
+	if js, ok := ins.(*hashtree.JSONCompact); ok {
+		return hashtree.ToCompact(js)
 	}
 
 	return nil, nil
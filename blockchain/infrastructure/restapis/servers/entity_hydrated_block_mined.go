@@ -18,7 +18,7 @@ func blockMinedOnHydrateEventFn(ins interface{}, fieldName string, structName st
 	}
 
 	if createdOn, ok := ins.(time.Time); ok {
-		return createdOn.Format(internalTimeLayout), nil
+		return createdOn.Format(timeLayout), nil
 	}
 
 	return nil, nil
@@ -26,7 +26,7 @@ func blockMinedOnHydrateEventFn(ins interface{}, fieldName string, structName st
 
 func blockMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "CreatedOn" {
-		createdOn, err := time.Parse(internalTimeLayout, ins.(string))
+		createdOn, err := time.Parse(timeLayout, ins.(string))
 		if err != nil {
 			return nil, err
 		}
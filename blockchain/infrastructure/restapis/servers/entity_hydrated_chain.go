@@ -9,8 +9,8 @@ import (
 type entityHydratedChain struct {
 	ID        string                    `json:"id" hydro:"0"`
 	Peers     *hydratedPeers            `json:"peers" hydro:"1"`
-	Genesis   *hydratedGenesis          `json:"genesis" hydro:"2"`
-	Root      *entityHydratedBlockMined `json:"root_block_mined_hash" hydro:"3"`
+	Genesis   *hydratedGenesis          `json:"genesis" hydro:"3"`
+	Root      *entityHydratedBlockMined `json:"root_block_mined_hash" hydro:"2"`
 	CreatedOn string                    `json:"created_on" hydro:"4"`
 	Head      *entityHydratedLinkMined  `json:"head_mined_link_hash" hydro:"5"`
 }
@@ -24,7 +24,7 @@ func chainOnHydrateEventFn(ins interface{}, fieldName string, structName string)
 
 	if fieldName == "CreatedOn" {
 		if createdOn, ok := ins.(time.Time); ok {
-			return createdOn.Format(internalTimeLayout), nil
+			return createdOn.Format(timeLayout), nil
 		}
 	}
 
@@ -42,7 +42,7 @@ func chainOnDehydrateEventFn(ins interface{}, fieldName string, structName strin
 	}
 
 	if fieldName == "CreatedOn" {
-		createdOn, err := time.Parse(internalTimeLayout, ins.(string))
+		createdOn, err := time.Parse(timeLayout, ins.(string))
 		if err != nil {
 			return nil, err
 		}
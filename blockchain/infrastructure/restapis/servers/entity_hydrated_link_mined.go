@@ -12,7 +12,7 @@ type entityHydratedLinkMined struct {
 
 func linkMinedOnHydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if createdOn, ok := ins.(time.Time); ok {
-		return createdOn.Format(internalTimeLayout), nil
+		return createdOn.Format(timeLayout), nil
 	}
 
 	return nil, nil
@@ -20,7 +20,7 @@ func linkMinedOnHydrateEventFn(ins interface{}, fieldName string, structName str
 
 func linkMinedOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "CreatedOn" {
-		createdOn, err := time.Parse(internalTimeLayout, ins.(string))
+		createdOn, err := time.Parse(timeLayout, ins.(string))
 		if err != nil {
 			return nil, err
 		}
@@ -1,14 +1,20 @@
 package servers
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	uuid "github.com/satori/go.uuid"
+	"github.com/deepvalue-network/software/blockchain/application/services"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
 )
 
 func fetchHashFromParams(hashAdapter hash.Adapter, w http.ResponseWriter, r *http.Request, keyname string) *hash.Hash {
@@ -45,8 +51,167 @@ func fetchIDFromParams(w http.ResponseWriter, r *http.Request, keyname string) *
 	return nil
 }
 
-func renderInsToJSON(w http.ResponseWriter, ins interface{}, err error) {
-	panic(errors.New("TODO: finish the renderInsToJSON func in the restapi server helper"))
+// fetchAmountFromQuery returns the amount of elements of the requested list page, or zero if the whole list is
+// requested.  False is returned, once the bad request is rendered, if the amount is invalid
+func fetchAmountFromQuery(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	amountAsStr := r.URL.Query().Get(AmountKeyname)
+	if amountAsStr == "" {
+		return 0, true
+	}
+
+	amount, err := strconv.ParseUint(amountAsStr, 10, 32)
+	if err != nil || amount == 0 {
+		renderBadRequest(w, fmt.Errorf("the amount (%s) is invalid", amountAsStr), []byte(invalidAmountErrorOutput))
+		return 0, false
+	}
+
+	return uint(amount), true
+}
+
+// renderHashes renders the hashes of a list, or of the requested list page along with the cursor of the next page
+func renderHashes(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func() ([]hash.Hash, error),
+	listPage func(cursor string, amount uint) ([]hash.Hash, string, error),
+) {
+	amount, ok := fetchAmountFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	if amount == 0 {
+		hashes, err := list()
+		renderIns(w, r, hashes, err)
+		return
+	}
+
+	hashes, next, err := listPage(r.URL.Query().Get(CursorKeyname), amount)
+	w.Header().Set(NextCursorHeaderKeyname, next)
+	renderIns(w, r, hashes, err)
+}
+
+// renderIDs renders the ids of a list, or of the requested list page along with the cursor of the next page
+func renderIDs(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func() ([]*uuid.UUID, error),
+	listPage func(cursor string, amount uint) ([]*uuid.UUID, string, error),
+) {
+	amount, ok := fetchAmountFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	if amount == 0 {
+		ids, err := list()
+		renderIns(w, r, ids, err)
+		return
+	}
+
+	ids, next, err := listPage(r.URL.Query().Get(CursorKeyname), amount)
+	w.Header().Set(NextCursorHeaderKeyname, next)
+	renderIns(w, r, ids, err)
+}
+
+// fetchBody reads the body of the request, up to the max body size
+func fetchBody(w http.ResponseWriter, r *http.Request) []byte {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		renderBadRequest(w, err, []byte(invalidBodyErrorOutput))
+		return nil
+	}
+
+	return data
+}
+
+// renderReceived renders the outcome of a pushed instance: created if it is new, ok if it was already known.  A rejected
+// instance is a bad request, while the other errors are server errors, so that the pushing peer retries them
+func renderReceived(w http.ResponseWriter, isNew bool, err error) {
+	if err != nil {
+		if !services.IsRejected(err) {
+			renderError(w, err, []byte(internalErrorOutput))
+			return
+		}
+
+		output := fmt.Sprintf(rejectedErrorOutput, err.Error())
+		renderBadRequest(w, err, []byte(output))
+		return
+	}
+
+	if isNew {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func renderIns(w http.ResponseWriter, r *http.Request, ins interface{}, err error) {
+	if err != nil {
+		renderError(w, err, []byte(internalErrorOutput))
+		return
+	}
+
+	format := negotiateFormat(r)
+	data, err := encodeIns(ins, format)
+	if err != nil {
+		renderError(w, err, []byte(internalErrorOutput))
+		return
+	}
+
+	contentType := ContentTypeJSON
+	if format == hydro.Binary {
+		contentType = ContentTypeBinary
+	}
+
+	w.Header().Set(contentTypeHeaderKeyname, contentType)
+	renderSuccess(w, data)
+}
+
+// negotiateFormat returns the binary format when the request accepts it before JSON, and JSON otherwise
+func negotiateFormat(r *http.Request) hydro.Format {
+	for _, oneAccept := range strings.Split(r.Header.Get(acceptHeaderKeyname), ",") {
+		mediaType := strings.TrimSpace(strings.Split(oneAccept, ";")[0])
+		if mediaType == ContentTypeBinary {
+			return hydro.Binary
+		}
+
+		if mediaType == ContentTypeJSON {
+			return hydro.JSON
+		}
+	}
+
+	return hydro.JSON
+}
+
+func encodeIns(ins interface{}, format hydro.Format) ([]byte, error) {
+	switch list := ins.(type) {
+	case []hash.Hash:
+		out := []string{}
+		for _, oneHash := range list {
+			out = append(out, oneHash.String())
+		}
+
+		return encodeList(out, format)
+	case []*uuid.UUID:
+		out := []string{}
+		for _, oneID := range list {
+			out = append(out, oneID.String())
+		}
+
+		return encodeList(out, format)
+	}
+
+	return internalHydroAdapter.EncodeWithFormat(ins, format)
+}
+
+func encodeList(list []string, format hydro.Format) ([]byte, error) {
+	if format == hydro.Binary {
+		return hydro.MarshalBinary(list)
+	}
+
+	return json.Marshal(list)
 }
 
 func renderSuccess(w http.ResponseWriter, data []byte) {
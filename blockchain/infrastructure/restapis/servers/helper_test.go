@@ -0,0 +1,142 @@
+package servers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deepvalue-network/software/blockchain/domain/blocks"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+func TestNegotiateFormat_Success(t *testing.T) {
+	accepts := map[string]hydro.Format{
+		"":                                    hydro.JSON,
+		"*/*":                                 hydro.JSON,
+		"application/json":                    hydro.JSON,
+		"application/octet-stream":            hydro.Binary,
+		"text/html, application/octet-stream": hydro.Binary,
+		"application/json, application/octet-stream":       hydro.JSON,
+		"application/octet-stream;q=0.9, application/json": hydro.Binary,
+	}
+
+	for accept, expected := range accepts {
+		r := httptest.NewRequest(http.MethodGet, "/blocks", nil)
+		r.Header.Set(acceptHeaderKeyname, accept)
+		if format := negotiateFormat(r); format != expected {
+			t.Errorf("the format for the accept header (%s) was expected to be %d, %d returned", accept, expected, format)
+			return
+		}
+	}
+}
+
+func TestRenderIns_block_Success(t *testing.T) {
+	block := blocks.CreateBlockForTests()
+
+	// binary:
+	r := httptest.NewRequest(http.MethodGet, "/blocks", nil)
+	r.Header.Set(acceptHeaderKeyname, ContentTypeBinary)
+	w := httptest.NewRecorder()
+	renderIns(w, r, block, nil)
+
+	if w.Header().Get(contentTypeHeaderKeyname) != ContentTypeBinary {
+		t.Errorf("the content type was expected to be %s, %s returned", ContentTypeBinary, w.Header().Get(contentTypeHeaderKeyname))
+		return
+	}
+
+	ins, err := internalHydroAdapter.Decode(w.Body.Bytes(), new(entityHydratedBlock))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !ins.(blocks.Block).Tree().Head().Compare(block.Tree().Head()) {
+		t.Errorf("the decoded block is different")
+		return
+	}
+
+	// json:
+	r = httptest.NewRequest(http.MethodGet, "/blocks", nil)
+	w = httptest.NewRecorder()
+	renderIns(w, r, block, nil)
+
+	if w.Header().Get(contentTypeHeaderKeyname) != ContentTypeJSON {
+		t.Errorf("the content type was expected to be %s, %s returned", ContentTypeJSON, w.Header().Get(contentTypeHeaderKeyname))
+		return
+	}
+
+	ins, err = internalHydroAdapter.Decode(w.Body.Bytes(), new(entityHydratedBlock))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !ins.(blocks.Block).Tree().Head().Compare(block.Tree().Head()) {
+		t.Errorf("the decoded block is different")
+		return
+	}
+}
+
+func TestRenderIns_hashes_Success(t *testing.T) {
+	hashAdapter := hash.NewAdapter()
+	first, _ := hashAdapter.Hash([]byte("first"))
+	second, _ := hashAdapter.Hash([]byte("second"))
+	hashes := []hash.Hash{*first, *second}
+
+	r := httptest.NewRequest(http.MethodGet, "/blocks", nil)
+	r.Header.Set(acceptHeaderKeyname, ContentTypeBinary)
+	w := httptest.NewRecorder()
+	renderIns(w, r, hashes, nil)
+
+	binaryList := []string{}
+	err := hydro.UnmarshalBinary(w.Body.Bytes(), &binaryList)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/blocks", nil)
+	w = httptest.NewRecorder()
+	renderIns(w, r, hashes, nil)
+
+	jsonList := []string{}
+	err = json.Unmarshal(w.Body.Bytes(), &jsonList)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for index, oneHash := range hashes {
+		if binaryList[index] != oneHash.String() || jsonList[index] != oneHash.String() {
+			t.Errorf("the hash (index: %d) was expected to be %s", index, oneHash.String())
+			return
+		}
+	}
+}
+
+func TestRenderReceived_Success(t *testing.T) {
+	statuses := map[bool]int{
+		true:  http.StatusCreated,
+		false: http.StatusOK,
+	}
+
+	for isNew, expected := range statuses {
+		w := httptest.NewRecorder()
+		renderReceived(w, isNew, nil)
+		if w.Code != expected {
+			t.Errorf("the status was expected to be %d, %d returned", expected, w.Code)
+			return
+		}
+	}
+
+	// an error that is not a rejection is a server error, so that the pushing peer retries it:
+	w := httptest.NewRecorder()
+	renderReceived(w, false, errors.New("the store is unavailable"))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("the status was expected to be %d, %d returned", http.StatusInternalServerError, w.Code)
+		return
+	}
+}
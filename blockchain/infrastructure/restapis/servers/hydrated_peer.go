@@ -15,13 +15,13 @@ type hydratedPeer struct {
 func peerOnHydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "CreatedOn" {
 		if createdOn, ok := ins.(time.Time); ok {
-			return createdOn.Format(internalTimeLayout), nil
+			return createdOn.Format(timeLayout), nil
 		}
 	}
 
 	if fieldName == "LastUpdatedOn" {
 		if lastUpdatedOn, ok := ins.(time.Time); ok {
-			return lastUpdatedOn.Format(internalTimeLayout), nil
+			return lastUpdatedOn.Format(timeLayout), nil
 		}
 	}
 
@@ -36,7 +36,7 @@ func peerOnHydrateEventFn(ins interface{}, fieldName string, structName string)
 
 func peerOnDehydrateEventFn(ins interface{}, fieldName string, structName string) (interface{}, error) {
 	if fieldName == "CreatedOn" {
-		createdOn, err := time.Parse(internalTimeLayout, ins.(string))
+		createdOn, err := time.Parse(timeLayout, ins.(string))
 		if err != nil {
 			return nil, err
 		}
@@ -47,10 +47,11 @@ func peerOnDehydrateEventFn(ins interface{}, fieldName string, structName string
 	if fieldName == "LastUpdatedOn" {
 		if str, ok := ins.(string); ok {
 			if str == "" {
-				return nil, nil
+				// a typed nil, since an untyped one keeps the string:
+				return (*time.Time)(nil), nil
 			}
 
-			lastUpdatedOn, err := time.Parse(internalTimeLayout, str)
+			lastUpdatedOn, err := time.Parse(timeLayout, str)
 			if err != nil {
 				return nil, err
 			}
@@ -28,7 +28,7 @@ func peersOnHydrateEventFn(ins interface{}, fieldName string, structName string)
 
 	if fieldName == "LastSyncTime" {
 		if lastSyncTime, ok := ins.(*time.Time); ok {
-			return lastSyncTime.Format(internalTimeLayout), nil
+			return lastSyncTime.Format(timeLayout), nil
 		}
 	}
 
@@ -52,10 +52,11 @@ func peersOnDehydrateEventFn(ins interface{}, fieldName string, structName strin
 	if fieldName == "LastSyncTime" {
 		if str, ok := ins.(string); ok {
 			if str == "" {
-				return nil, nil
+				// a typed nil, since an untyped one keeps the string:
+				return (*time.Time)(nil), nil
 			}
 
-			lastSyncTime, err := time.Parse(internalTimeLayout, str)
+			lastSyncTime, err := time.Parse(timeLayout, str)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,45 @@
+package servers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+// statusRecorder records the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code, then writes it
+func (obj *statusRecorder) WriteHeader(status int) {
+	obj.status = status
+	obj.ResponseWriter.WriteHeader(status)
+}
+
+func (app *server) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		beginsOn := time.Now().UTC()
+		recorder := &statusRecorder{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+		}
+
+		next.ServeHTTP(recorder, r)
+		app.requests.Inc()
+		app.requestDuration.Observe(time.Now().UTC().Sub(beginsOn).Seconds())
+		if recorder.status >= http.StatusInternalServerError {
+			app.requestErrors.Inc()
+		}
+	})
+}
+
+func (app *server) metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(contentTypeHeaderKeyname, observability.ContentType)
+	err := app.registry.Export(w)
+	if err != nil {
+		renderError(w, err, []byte(internalErrorOutput))
+	}
+}
@@ -0,0 +1,38 @@
+package servers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
+	"github.com/gorilla/mux"
+)
+
+func TestServer_metrics_Success(t *testing.T) {
+	registry := observability.NewRegistry()
+	router := mux.NewRouter()
+	createServer(nil, nil, hash.NewAdapter(), registry, router, time.Second, 0)
+
+	// an invalid hash is a bad request, but is still counted:
+	r := httptest.NewRequest(http.MethodGet, "/blocks/ab", nil)
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	r = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Header().Get(contentTypeHeaderKeyname) != observability.ContentType {
+		t.Errorf("the content type was expected to be %s, %s returned", observability.ContentType, w.Header().Get(contentTypeHeaderKeyname))
+		return
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, "# TYPE rest_requests_total counter\nrest_requests_total 1\n") {
+		t.Errorf("the request was expected to be counted, exported:\n%s", body)
+		return
+	}
+}
@@ -1,19 +1,24 @@
 package servers
 
+// The generated bridges are the ones of the disks package, unexported.
+//go:generate go run github.com/deepvalue-network/software/libs/hydro/cmd/hydrogen -dir ../../../domain/genesis -interface Genesis -unexported
+
 import (
 	"time"
 
 	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
 	block_mined "github.com/deepvalue-network/software/blockchain/domain/blocks/mined"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
 	"github.com/deepvalue-network/software/blockchain/domain/chains/peers"
-	"github.com/deepvalue-network/software/blockchain/domain/genesis"
 	"github.com/deepvalue-network/software/blockchain/domain/links"
 	link_mined "github.com/deepvalue-network/software/blockchain/domain/links/mined"
 	"github.com/deepvalue-network/software/libs/hash"
 	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
 	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
 )
 
 const internalErrorOutput = "server error"
@@ -24,47 +29,119 @@ const invalidIDErrorOutput = "the given id, in the URL, is invalid"
 
 const missingParamErrorOutput = "the '%s' parameter was expected, none given"
 
+const invalidBodyErrorOutput = "the given body is invalid"
+
+const invalidAmountErrorOutput = "the given amount, in the URL, is invalid"
+
+const rejectedErrorOutput = "the given instance was rejected: %s"
+
+// MaxBodySize represents the max size of a pushed body, and of a read response, in bytes
+const MaxBodySize = 1024 * 1024 * 4
+
 const hashKeyname = "hash"
 
+const acceptHeaderKeyname = "Accept"
+
+const contentTypeHeaderKeyname = "Content-Type"
+
+// ContentTypeJSON represents the content type of the JSON responses
+const ContentTypeJSON = "application/json"
+
+// ContentTypeBinary represents the content type of the canonical binary responses
+const ContentTypeBinary = "application/octet-stream"
+
 const idKeyname = "id"
 
+// CursorKeyname represents the query parameter of the cursor after which a list page starts
+const CursorKeyname = "cursor"
+
+// AmountKeyname represents the query parameter of the amount of elements of a list page; without it, the whole list is
+// returned
+const AmountKeyname = "amount"
+
+// NextCursorHeaderKeyname represents the header of the cursor of the next list page; it is empty on the last page
+const NextCursorHeaderKeyname = "X-Next-Cursor"
+
 const retrievePattern = "%s/%s"
 
-// internal elements provided by outside:
-var internalPeerSyncInterval time.Duration
-var internalChainRepository chains.Repository
-var internalTimeLayout string
+// timeLayout represents the layout of the times rendered and received by every server
+const timeLayout = "2006-01-02T15:04:05.000Z"
 
-// internal elements provided created by init:
-var internalHydroAdapter hydro.Adapter
+const requestsMetric = "rest_requests_total"
 
-// Init initializes the package
-func Init(
-	peerSyncInterval time.Duration,
-	chainRepository chains.Repository,
-	timeLayout string,
-) {
+const requestErrorsMetric = "rest_request_errors_total"
 
-	internalPeerSyncInterval = peerSyncInterval
-	internalChainRepository = chainRepository
-	internalTimeLayout = timeLayout
-}
+const requestSecondsMetric = "rest_request_seconds"
 
-// NewServer creates a new server instance
+// internal elements provided created by init:
+var internalHydroAdapter hydro.Adapter
+
+// NewServer creates a new server instance; the metrics of the process wide registry are exported on /metrics.  The
+// mined blocks and mined links pushed by the peers are given to the gossip; without gossip, the server is read-only
 func NewServer(
 	rep repositories.Application,
+	gossip services.Gossip,
 	router *mux.Router,
 	waitPeriod time.Duration,
 	port uint,
 ) Server {
 	hashAdapter := hash.NewAdapter()
-	return createServer(rep, hashAdapter, router, waitPeriod, port)
+	registry := observability.DefaultRegistry()
+	return createServer(rep, gossip, hashAdapter, registry, router, waitPeriod, port)
+}
+
+// EncodeMinedBlock encodes a mined block in the canonical binary format, the way the server receives it
+func EncodeMinedBlock(minedBlock block_mined.Block) ([]byte, error) {
+	return encodeIns(minedBlock, hydro.Binary)
+}
+
+// EncodeMinedLink encodes a mined link in the canonical binary format, the way the server receives it
+func EncodeMinedLink(minedLink link_mined.Link) ([]byte, error) {
+	return encodeIns(minedLink, hydro.Binary)
+}
+
+// DecodeHashes decodes a list of hashes rendered by the server, in the given content type
+func DecodeHashes(data []byte, contentType string) ([]hash.Hash, error) {
+	return decodeHashes(data, contentType)
+}
+
+// DecodeIDs decodes a list of ids rendered by the server, in the given content type
+func DecodeIDs(data []byte, contentType string) ([]*uuid.UUID, error) {
+	return decodeIDs(data, contentType)
+}
+
+// DecodeBlock decodes a block rendered by the server
+func DecodeBlock(data []byte) (blocks.Block, error) {
+	return decodeBlock(data)
+}
+
+// DecodeMinedBlock decodes a mined block rendered by the server
+func DecodeMinedBlock(data []byte) (block_mined.Block, error) {
+	return decodeMinedBlock(data)
+}
+
+// DecodeLink decodes a link rendered by the server
+func DecodeLink(data []byte) (links.Link, error) {
+	return decodeLink(data)
+}
+
+// DecodeMinedLink decodes a mined link rendered by the server
+func DecodeMinedLink(data []byte) (link_mined.Link, error) {
+	return decodeMinedLink(data)
+}
+
+// DecodeChain decodes a chain rendered by the server; its height is computed by retrieving its mined links from the
+// given repository, usually the one of the same server
+func DecodeChain(data []byte, minedLinkRepository repositories.MinedLink) (chains.Chain, error) {
+	return decodeChain(data, minedLinkRepository)
 }
 
 // Server represents a rest api server
 type Server interface {
 	Start()
 	Stop()
+	Listen() error
+	Shutdown() error
 }
 
 func init() {
@@ -74,6 +151,7 @@ func init() {
 		WithDehydratedPointer(blocks.NewPointer()).
 		WithHydratedPointer(new(entityHydratedBlock)).
 		OnHydrate(blockOnHydrateEventFn).
+		OnDehydrate(blockOnDehydrateEventFn).
 		Now()
 
 	if err != nil {
@@ -145,13 +223,7 @@ func init() {
 		panic(err)
 	}
 
-	genesisBridge, err := hydro.NewBridgeBuilder().Create().
-		WithDehydratedInterface((*genesis.Genesis)(nil)).
-		WithDehydratedConstructor(newGenesis).
-		WithDehydratedPointer(genesis.NewPointer()).
-		WithHydratedPointer(createGenesisForBridge()).
-		Now()
-
+	genesisBridge, err := newGenesisBridge()
 	if err != nil {
 		panic(err)
 	}
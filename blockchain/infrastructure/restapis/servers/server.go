@@ -4,39 +4,54 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"time"
 
-	"github.com/gorilla/mux"
 	"github.com/deepvalue-network/software/blockchain/application/repositories"
+	"github.com/deepvalue-network/software/blockchain/application/services"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/observability"
+	"github.com/gorilla/mux"
 )
 
 type server struct {
-	server      *http.Server
-	rep         repositories.Application
-	hashAdapter hash.Adapter
-	router      *mux.Router
-	waitPeriod  time.Duration
-	port        uint
+	server          *http.Server
+	rep             repositories.Application
+	gossip          services.Gossip
+	hashAdapter     hash.Adapter
+	registry        observability.Registry
+	requests        observability.Counter
+	requestErrors   observability.Counter
+	requestDuration observability.Histogram
+	router          *mux.Router
+	waitPeriod      time.Duration
+	port            uint
 }
 
 func createServer(
 	rep repositories.Application,
+	gossip services.Gossip,
 	hashAdapter hash.Adapter,
+	registry observability.Registry,
 	router *mux.Router,
 	waitPeriod time.Duration,
 	port uint,
 ) Server {
 	out := server{
-		server:      nil,
-		rep:         rep,
-		hashAdapter: hashAdapter,
-		router:      router,
-		waitPeriod:  waitPeriod,
-		port:        port,
+		server:          nil,
+		rep:             rep,
+		gossip:          gossip,
+		hashAdapter:     hashAdapter,
+		registry:        registry,
+		requests:        registry.Counter(requestsMetric, "The amount of REST requests"),
+		requestErrors:   registry.Counter(requestErrorsMetric, "The amount of REST requests that failed on the server"),
+		requestDuration: registry.Histogram(requestSecondsMetric, "The duration of the REST requests", observability.DefaultBuckets),
+		router:          router,
+		waitPeriod:      waitPeriod,
+		port:            port,
 	}
 
 	hashPattern := fmt.Sprintf("{%s:[0-9a-f]+}", hashKeyname)
@@ -58,6 +73,8 @@ func createServer(
 	chainURI := fmt.Sprintf("/chains")
 	chainRetrieveURI := fmt.Sprintf(retrievePattern, chainURI, idPattern)
 
+	out.router.Use(out.instrument)
+	out.router.HandleFunc("/metrics", out.metrics).Methods(http.MethodGet, http.MethodOptions)
 	out.router.HandleFunc(blockURI, out.blockList).Methods(http.MethodGet, http.MethodOptions)
 	out.router.HandleFunc(blockRetrieveURI, out.blockRetrieve).Methods(http.MethodGet, http.MethodOptions)
 	out.router.HandleFunc(minedBlockURI, out.minedBlockList).Methods(http.MethodGet, http.MethodOptions)
@@ -70,17 +87,45 @@ func createServer(
 	out.router.HandleFunc(chainURI, out.chainList).Methods(http.MethodGet, http.MethodOptions)
 	out.router.HandleFunc(chainRetrieveURI, out.chainRetrieve).Methods(http.MethodGet, http.MethodOptions)
 
+	// the peers push their mined blocks and mined links, if the server gossips:
+	if out.gossip != nil {
+		out.router.HandleFunc(minedBlockURI, out.minedBlockReceive).Methods(http.MethodPost)
+		out.router.HandleFunc(minedLinkURI, out.minedLinkReceive).Methods(http.MethodPost)
+	}
+
 	return &out
 }
 
-// Start starts the server
+// Start starts the server, and blocks until an interrupt signal stops it
 func (app *server) Start() {
-	if app.server != nil {
+	err := app.Listen()
+	if err != nil {
+		log.Println(err)
 		return
 	}
 
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+
+	//block until we receive signal
+	<-c
+
+	// stops:
+	app.Stop()
+}
+
+// Listen starts serving in the background, once the port is bound
+func (app *server) Listen() error {
+	if app.server != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", app.port))
+	if err != nil {
+		return err
+	}
+
 	app.server = &http.Server{
-		Addr: fmt.Sprintf("0.0.0.0:%d", app.port),
 		// Good practice to set timeouts to avoid Slowloris attacks.
 		WriteTimeout: time.Second * 15,
 		ReadTimeout:  time.Second * 15,
@@ -89,26 +134,19 @@ func (app *server) Start() {
 	}
 
 	// Run our server in a goroutine so that it doesn't block.
-	go func() {
-		if err := app.server.ListenAndServe(); err != nil {
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			log.Println(err)
 		}
-	}()
+	}(app.server)
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-
-	//block until we receive signal
-	<-c
-
-	// stops:
-	app.Stop()
+	return nil
 }
 
-// Stop stops the server
-func (app *server) Stop() {
+// Shutdown stops serving, waiting for the current requests during the wait period
+func (app *server) Shutdown() error {
 	if app.server == nil {
-		return
+		return nil
 	}
 
 	// Create a deadline to wait for.
@@ -117,10 +155,20 @@ func (app *server) Stop() {
 
 	// Doesn't block if no connections, but will otherwise wait
 	// until the timeout deadline.
-	app.server.Shutdown(ctx)
+	err := app.server.Shutdown(ctx)
 
 	// set the server as nil:
 	app.server = nil
+	return err
+}
+
+// Stop stops the server, then exits the process
+func (app *server) Stop() {
+	if app.server == nil {
+		return
+	}
+
+	app.Shutdown()
 
 	// shut down:
 	log.Println("shutting down")
@@ -128,8 +176,7 @@ func (app *server) Stop() {
 }
 
 func (app *server) blockList(w http.ResponseWriter, r *http.Request) {
-	hashes, err := app.rep.Block().List()
-	renderInsToJSON(w, hashes, err)
+	renderHashes(w, r, app.rep.Block().List, app.rep.Block().ListPage)
 }
 
 func (app *server) blockRetrieve(w http.ResponseWriter, r *http.Request) {
@@ -139,12 +186,11 @@ func (app *server) blockRetrieve(w http.ResponseWriter, r *http.Request) {
 	}
 
 	block, err := app.rep.Block().Retrieve(*hash)
-	renderInsToJSON(w, block, err)
+	renderIns(w, r, block, err)
 }
 
 func (app *server) minedBlockList(w http.ResponseWriter, r *http.Request) {
-	hashes, err := app.rep.MinedBlock().List()
-	renderInsToJSON(w, hashes, err)
+	renderHashes(w, r, app.rep.MinedBlock().List, app.rep.MinedBlock().ListPage)
 }
 
 func (app *server) minedBlockRetrieve(w http.ResponseWriter, r *http.Request) {
@@ -154,12 +200,27 @@ func (app *server) minedBlockRetrieve(w http.ResponseWriter, r *http.Request) {
 	}
 
 	block, err := app.rep.MinedBlock().Retrieve(*hash)
-	renderInsToJSON(w, block, err)
+	renderIns(w, r, block, err)
+}
+
+func (app *server) minedBlockReceive(w http.ResponseWriter, r *http.Request) {
+	data := fetchBody(w, r)
+	if data == nil {
+		return
+	}
+
+	minedBlock, err := decodeMinedBlock(data)
+	if err != nil {
+		renderBadRequest(w, err, []byte(invalidBodyErrorOutput))
+		return
+	}
+
+	isNew, err := app.gossip.ReceiveMinedBlock(minedBlock)
+	renderReceived(w, isNew, err)
 }
 
 func (app *server) linkList(w http.ResponseWriter, r *http.Request) {
-	hashes, err := app.rep.Link().List()
-	renderInsToJSON(w, hashes, err)
+	renderHashes(w, r, app.rep.Link().List, app.rep.Link().ListPage)
 }
 
 func (app *server) linkRetrieve(w http.ResponseWriter, r *http.Request) {
@@ -169,17 +230,16 @@ func (app *server) linkRetrieve(w http.ResponseWriter, r *http.Request) {
 	}
 
 	block, err := app.rep.Link().Retrieve(*hash)
-	renderInsToJSON(w, block, err)
+	renderIns(w, r, block, err)
 }
 
 func (app *server) minedLinkList(w http.ResponseWriter, r *http.Request) {
-	hashes, err := app.rep.MinedLink().List()
-	renderInsToJSON(w, hashes, err)
+	renderHashes(w, r, app.rep.MinedLink().List, app.rep.MinedLink().ListPage)
 }
 
 func (app *server) minedLinkHead(w http.ResponseWriter, r *http.Request) {
 	head, err := app.rep.MinedLink().Head()
-	renderInsToJSON(w, head, err)
+	renderIns(w, r, head, err)
 }
 
 func (app *server) minedLinkRetrieve(w http.ResponseWriter, r *http.Request) {
@@ -189,12 +249,27 @@ func (app *server) minedLinkRetrieve(w http.ResponseWriter, r *http.Request) {
 	}
 
 	block, err := app.rep.MinedLink().Retrieve(*hash)
-	renderInsToJSON(w, block, err)
+	renderIns(w, r, block, err)
+}
+
+func (app *server) minedLinkReceive(w http.ResponseWriter, r *http.Request) {
+	data := fetchBody(w, r)
+	if data == nil {
+		return
+	}
+
+	minedLink, err := decodeMinedLink(data)
+	if err != nil {
+		renderBadRequest(w, err, []byte(invalidBodyErrorOutput))
+		return
+	}
+
+	isNew, err := app.gossip.ReceiveMinedLink(minedLink)
+	renderReceived(w, isNew, err)
 }
 
 func (app *server) chainList(w http.ResponseWriter, r *http.Request) {
-	hashes, err := app.rep.Chain().List()
-	renderInsToJSON(w, hashes, err)
+	renderIDs(w, r, app.rep.Chain().List, app.rep.Chain().ListPage)
 }
 
 func (app *server) chainRetrieve(w http.ResponseWriter, r *http.Request) {
@@ -204,5 +279,5 @@ func (app *server) chainRetrieve(w http.ResponseWriter, r *http.Request) {
 	}
 
 	chain, err := app.rep.Chain().Retrieve(id)
-	renderInsToJSON(w, chain, err)
+	renderIns(w, r, chain, err)
 }
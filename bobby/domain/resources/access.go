@@ -1,21 +1,25 @@
 package resources
 
 import (
+	"errors"
+
 	uuid "github.com/satori/go.uuid"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
 	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/public"
 )
 
 type access struct {
-	resource  Mutable
-	owners    []*uuid.UUID
-	encrypted public.Key
+	resource   Mutable
+	owners     []*uuid.UUID
+	encrypted  public.Key
+	recipients []encryption.Recipient
 }
 
 func createAccess(
 	resource Mutable,
 	owners []*uuid.UUID,
 ) Access {
-	return createAccessInternally(resource, owners, nil)
+	return createAccessInternally(resource, owners, nil, nil)
 }
 
 func createAccessWithEncryptedPubkey(
@@ -23,18 +27,37 @@ func createAccessWithEncryptedPubkey(
 	owners []*uuid.UUID,
 	encrypted public.Key,
 ) Access {
-	return createAccessInternally(resource, owners, encrypted)
+	return createAccessInternally(resource, owners, encrypted, nil)
+}
+
+func createAccessWithRecipients(
+	resource Mutable,
+	owners []*uuid.UUID,
+	recipients []encryption.Recipient,
+) Access {
+	return createAccessInternally(resource, owners, nil, recipients)
+}
+
+func createAccessWithEncryptedPubkeyAndRecipients(
+	resource Mutable,
+	owners []*uuid.UUID,
+	encrypted public.Key,
+	recipients []encryption.Recipient,
+) Access {
+	return createAccessInternally(resource, owners, encrypted, recipients)
 }
 
 func createAccessInternally(
 	resource Mutable,
 	owners []*uuid.UUID,
 	encrypted public.Key,
+	recipients []encryption.Recipient,
 ) Access {
 	out := access{
-		resource:  resource,
-		owners:    owners,
-		encrypted: encrypted,
+		resource:   resource,
+		owners:     owners,
+		encrypted:  encrypted,
+		recipients: recipients,
 	}
 
 	return &out
@@ -59,3 +82,22 @@ func (obj *access) IsEncrypted() bool {
 func (obj *access) Encrypted() public.Key {
 	return obj.encrypted
 }
+
+// HasRecipients returns true if there is recipients, false otherwise
+func (obj *access) HasRecipients() bool {
+	return obj.recipients != nil
+}
+
+// Recipients returns the encryption pubKey of each owner, if any
+func (obj *access) Recipients() []encryption.Recipient {
+	return obj.recipients
+}
+
+// Seal seals the payload in an envelope that every owner can open
+func (obj *access) Seal(payload []byte) (encryption.Envelope, error) {
+	if !obj.HasRecipients() {
+		return nil, errors.New("the access cannot seal a payload because it does not contain recipients")
+	}
+
+	return encryption.NewEnvelopeBuilder().Create().WithRecipients(obj.recipients).WithPayload(payload).Now()
+}
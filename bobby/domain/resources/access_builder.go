@@ -2,22 +2,26 @@ package resources
 
 import (
 	"errors"
+	"fmt"
 
 	uuid "github.com/satori/go.uuid"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
 	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/public"
 )
 
 type accessBuilder struct {
-	resource  Mutable
-	owners    []*uuid.UUID
-	encrypted public.Key
+	resource   Mutable
+	owners     []*uuid.UUID
+	encrypted  public.Key
+	recipients []encryption.Recipient
 }
 
 func createAccessBuilder() AccessBuilder {
 	out := accessBuilder{
-		resource:  nil,
-		owners:    nil,
-		encrypted: nil,
+		resource:   nil,
+		owners:     nil,
+		encrypted:  nil,
+		recipients: nil,
 	}
 
 	return &out
@@ -46,6 +50,12 @@ func (app *accessBuilder) WithEncryptionPubKey(pubKey public.Key) AccessBuilder
 	return app
 }
 
+// WithRecipients adds the encryption pubKey of each owner to the builder
+func (app *accessBuilder) WithRecipients(recipients []encryption.Recipient) AccessBuilder {
+	app.recipients = recipients
+	return app
+}
+
 // Now builds a new Access instance
 func (app *accessBuilder) Now() (Access, error) {
 	if app.owners != nil && len(app.owners) <= 0 {
@@ -60,6 +70,23 @@ func (app *accessBuilder) Now() (Access, error) {
 		return nil, errors.New("the owners are mandatory in order to build an Access instance")
 	}
 
+	if app.recipients != nil && len(app.recipients) <= 0 {
+		app.recipients = nil
+	}
+
+	if app.recipients != nil {
+		if len(app.recipients) != len(app.owners) {
+			str := fmt.Sprintf("the amount of recipients (%d) was expected to match the amount of owners (%d)", len(app.recipients), len(app.owners))
+			return nil, errors.New(str)
+		}
+
+		if app.encrypted != nil {
+			return createAccessWithEncryptedPubkeyAndRecipients(app.resource, app.owners, app.encrypted, app.recipients), nil
+		}
+
+		return createAccessWithRecipients(app.resource, app.owners, app.recipients), nil
+	}
+
 	if app.encrypted != nil {
 		return createAccessWithEncryptedPubkey(app.resource, app.owners, app.encrypted), nil
 	}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	uuid "github.com/satori/go.uuid"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
 	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/public"
 	"github.com/deepvalue-network/software/libs/hash"
 )
@@ -151,6 +152,7 @@ type AccessBuilder interface {
 	WithResource(res Mutable) AccessBuilder
 	WithOwners(owners []*uuid.UUID) AccessBuilder
 	WithEncryptionPubKey(pubKey public.Key) AccessBuilder
+	WithRecipients(recipients []encryption.Recipient) AccessBuilder
 	Now() (Access, error)
 }
 
@@ -160,4 +162,7 @@ type Access interface {
 	Owners() []*uuid.UUID
 	IsEncrypted() bool
 	Encrypted() public.Key
+	HasRecipients() bool
+	Recipients() []encryption.Recipient
+	Seal(payload []byte) (encryption.Envelope, error)
 }
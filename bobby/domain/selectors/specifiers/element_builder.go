@@ -45,16 +45,11 @@ func (app *elementBuilder) WithID(id *uuid.UUID) ElementBuilder {
 // Now builds a new Element instance
 func (app *elementBuilder) Now() (Element, error) {
 	if app.hash != nil {
-		hsh, err := app.hashAdapter.FromBytes(app.hash.Bytes())
-		if err != nil {
-			return nil, err
-		}
-
-		return createElementWithHash(*hsh, app.hash), nil
+		return createElementWithHash(*app.hash, app.hash), nil
 	}
 
 	if app.id != nil {
-		hsh, err := app.hashAdapter.FromBytes(app.id.Bytes())
+		hsh, err := app.hashAdapter.Hash(app.id.Bytes())
 		if err != nil {
 			return nil, err
 		}
@@ -3,12 +3,56 @@ package states
 import (
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	derrors "github.com/deepvalue-network/software/bobby/domain/errors"
 	"github.com/deepvalue-network/software/bobby/domain/resources"
 	"github.com/deepvalue-network/software/bobby/domain/states/overviews"
+	"github.com/deepvalue-network/software/bobby/domain/structures"
 	"github.com/deepvalue-network/software/bobby/domain/transactions"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
 	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
+const validTransactionsMetric = "bobby_valid_transactions_total"
+
+const invalidTransactionsMetric = "bobby_invalid_transactions_total"
+
+const saveSecondsMetric = "bobby_state_save_seconds"
+
+// NewServiceBuilder creates a new service builder instance, that saves the states and the hash of the last state in
+// the file service
+func NewServiceBuilder(
+	structureService structures.Service,
+	trxProc transactions.TransactionProcessor,
+	hydroAdapter hydro.Adapter,
+	fileService files.Service,
+	lastStateFileName string,
+) ServiceBuilder {
+	errorBuilder := derrors.NewBuilder()
+	validTrxBuilder := overviews.NewValidTransactionBuilder()
+	invalidTrxBuilder := overviews.NewInvalidTransactionBuilder()
+	overviewBuilder := overviews.NewBuilder()
+	registry := observability.DefaultRegistry()
+	validTrxs := registry.Counter(validTransactionsMetric, "The amount of transactions processed successfully")
+	invalidTrxs := registry.Counter(invalidTransactionsMetric, "The amount of transactions that failed to be processed")
+	saveDuration := registry.Histogram(saveSecondsMetric, "The duration of the state saves", observability.DefaultBuckets)
+	return createServiceBuilder(
+		errorBuilder,
+		validTrxBuilder,
+		invalidTrxBuilder,
+		overviewBuilder,
+		structureService,
+		trxProc,
+		hydroAdapter,
+		fileService,
+		lastStateFileName,
+		validTrxs,
+		invalidTrxs,
+		saveDuration,
+	)
+}
+
 // Builder represents a state builder
 type Builder interface {
 	Create() Builder
@@ -1,12 +1,9 @@
 package states
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/deepvalue-network/software/blockchain/domain/blocks"
 	"github.com/deepvalue-network/software/blockchain/domain/chains"
@@ -14,8 +11,10 @@ import (
 	"github.com/deepvalue-network/software/bobby/domain/states/overviews"
 	"github.com/deepvalue-network/software/bobby/domain/structures"
 	"github.com/deepvalue-network/software/bobby/domain/transactions"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
 	"github.com/deepvalue-network/software/libs/hash"
 	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
 type service struct {
@@ -26,10 +25,12 @@ type service struct {
 	structureService  structures.Service
 	trxProc           transactions.TransactionProcessor
 	hydroAdapter      hydro.Adapter
+	fileService       files.Service
 	onChain           chains.Chain
-	dirPath           string
 	lastStateFileName string
-	filePermission    os.FileMode
+	validTrxs         observability.Counter
+	invalidTrxs       observability.Counter
+	saveDuration      observability.Histogram
 	mp                map[string]*stateOverviews
 }
 
@@ -41,10 +42,12 @@ func createService(
 	structureService structures.Service,
 	trxProc transactions.TransactionProcessor,
 	hydroAdapter hydro.Adapter,
+	fileService files.Service,
 	onChain chains.Chain,
-	dirPath string,
 	lastStateFileName string,
-	filePermission os.FileMode,
+	validTrxs observability.Counter,
+	invalidTrxs observability.Counter,
+	saveDuration observability.Histogram,
 ) Service {
 	out := service{
 		errorBuilder:      errorBuilder,
@@ -54,10 +57,12 @@ func createService(
 		structureService:  structureService,
 		trxProc:           trxProc,
 		hydroAdapter:      hydroAdapter,
+		fileService:       fileService,
 		onChain:           onChain,
-		dirPath:           dirPath,
 		lastStateFileName: lastStateFileName,
-		filePermission:    filePermission,
+		validTrxs:         validTrxs,
+		invalidTrxs:       invalidTrxs,
+		saveDuration:      saveDuration,
 		mp:                map[string]*stateOverviews{},
 	}
 	return &out
@@ -80,8 +85,10 @@ func (app *service) Prepare(state State) ([]overviews.Overview, error) {
 // Save saves the state instance
 func (app *service) Save(hash hash.Hash) error {
 	keyname := hash.String()
+	beginsOn := time.Now().UTC()
 	defer func() {
 		delete(app.mp, keyname)
+		app.saveDuration.Observe(time.Now().UTC().Sub(beginsOn).Seconds())
 	}()
 
 	if stateOverviews, ok := app.mp[keyname]; ok {
@@ -106,28 +113,28 @@ func (app *service) Save(hash hash.Hash) error {
 			return err
 		}
 
-		dehydrated, err := app.hydroAdapter.Dehydrate(stateOverviews.state)
+		js, err := app.hydroAdapter.Encode(stateOverviews.state)
 		if err != nil {
 			return err
 		}
 
-		js, err := json.Marshal(dehydrated)
+		// save the state and the current state hash together, replacing them if the state was already saved:
+		stateHashStr := stateOverviews.state.Resource().Hash().String()
+		stateFilename := fmt.Sprintf("%s.json", stateHashStr)
+		trx := app.fileService.Begin()
+		err = trx.Upsert(stateFilename, js)
 		if err != nil {
+			trx.Rollback()
 			return err
 		}
 
-		// save the data using the hash:
-		stateHashStr := stateOverviews.state.Resource().Hash().String()
-		stateFilename := fmt.Sprintf("%s.json", stateHashStr)
-		path := filepath.Join(app.dirPath, stateFilename)
-		err = ioutil.WriteFile(path, js, app.filePermission)
+		err = trx.Upsert(app.lastStateFileName, stateHashStr)
 		if err != nil {
+			trx.Rollback()
 			return err
 		}
 
-		// save the current state hash:
-		lastStatePath := filepath.Join(app.dirPath, app.lastStateFileName)
-		return ioutil.WriteFile(lastStatePath, []byte(stateHashStr), app.filePermission)
+		return trx.Commit()
 	}
 
 	str := fmt.Sprintf("the State (ID: %s) has never been prepared and therefore cannot be saved", keyname)
@@ -165,10 +172,12 @@ func (app *service) processTrans(
 		}
 
 		if validTrx != nil {
+			app.validTrxs.Inc()
 			validTrans = append(validTrans, validTrx)
 		}
 
 		if invalidTrx != nil {
+			app.invalidTrxs.Inc()
 			invalidTrans = append(invalidTrans, invalidTrx)
 		}
 	}
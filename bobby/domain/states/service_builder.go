@@ -0,0 +1,110 @@
+package states
+
+import (
+	"errors"
+
+	"github.com/deepvalue-network/software/blockchain/domain/chains"
+	derrors "github.com/deepvalue-network/software/bobby/domain/errors"
+	"github.com/deepvalue-network/software/bobby/domain/states/overviews"
+	"github.com/deepvalue-network/software/bobby/domain/structures"
+	"github.com/deepvalue-network/software/bobby/domain/transactions"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+type serviceBuilder struct {
+	errorBuilder      derrors.Builder
+	validTrxBuilder   overviews.ValidTransactionBuilder
+	invalidTrxBuilder overviews.InvalidTransactionBuilder
+	overviewBuilder   overviews.Builder
+	structureService  structures.Service
+	trxProc           transactions.TransactionProcessor
+	hydroAdapter      hydro.Adapter
+	fileService       files.Service
+	lastStateFileName string
+	validTrxs         observability.Counter
+	invalidTrxs       observability.Counter
+	saveDuration      observability.Histogram
+	chain             chains.Chain
+}
+
+func createServiceBuilder(
+	errorBuilder derrors.Builder,
+	validTrxBuilder overviews.ValidTransactionBuilder,
+	invalidTrxBuilder overviews.InvalidTransactionBuilder,
+	overviewBuilder overviews.Builder,
+	structureService structures.Service,
+	trxProc transactions.TransactionProcessor,
+	hydroAdapter hydro.Adapter,
+	fileService files.Service,
+	lastStateFileName string,
+	validTrxs observability.Counter,
+	invalidTrxs observability.Counter,
+	saveDuration observability.Histogram,
+) ServiceBuilder {
+	out := serviceBuilder{
+		errorBuilder:      errorBuilder,
+		validTrxBuilder:   validTrxBuilder,
+		invalidTrxBuilder: invalidTrxBuilder,
+		overviewBuilder:   overviewBuilder,
+		structureService:  structureService,
+		trxProc:           trxProc,
+		hydroAdapter:      hydroAdapter,
+		fileService:       fileService,
+		lastStateFileName: lastStateFileName,
+		validTrxs:         validTrxs,
+		invalidTrxs:       invalidTrxs,
+		saveDuration:      saveDuration,
+		chain:             nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *serviceBuilder) Create() ServiceBuilder {
+	return createServiceBuilder(
+		app.errorBuilder,
+		app.validTrxBuilder,
+		app.invalidTrxBuilder,
+		app.overviewBuilder,
+		app.structureService,
+		app.trxProc,
+		app.hydroAdapter,
+		app.fileService,
+		app.lastStateFileName,
+		app.validTrxs,
+		app.invalidTrxs,
+		app.saveDuration,
+	)
+}
+
+// WithChain adds a chain to the builder
+func (app *serviceBuilder) WithChain(chain chains.Chain) ServiceBuilder {
+	app.chain = chain
+	return app
+}
+
+// Now builds a new Service instance
+func (app *serviceBuilder) Now() (Service, error) {
+	if app.chain == nil {
+		return nil, errors.New("the chain is mandatory in order to build a state Service instance")
+	}
+
+	return createService(
+		app.errorBuilder,
+		app.validTrxBuilder,
+		app.invalidTrxBuilder,
+		app.overviewBuilder,
+		app.structureService,
+		app.trxProc,
+		app.hydroAdapter,
+		app.fileService,
+		app.chain,
+		app.lastStateFileName,
+		app.validTrxs,
+		app.invalidTrxs,
+		app.saveDuration,
+	), nil
+}
@@ -10,4 +10,5 @@ require (
 	github.com/satori/go.uuid v1.2.0
 	github.com/steve-care-software/products v0.0.0-20210205004815-c0e7c3bb8d9d // indirect
 	go.dedis.ch/kyber/v3 v3.0.13
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
@@ -168,7 +168,7 @@ func (app *transaction) View(govID *uuid.UUID, amount uint, seed string) (views.
 
 	owners := []hash.Hash{}
 	for _, onePubKey := range ring {
-		hsh, err := app.hashAdapter.FromBytes([]byte(onePubKey.String()))
+		hsh, err := app.hashAdapter.Hash([]byte(onePubKey.String()))
 		if err != nil {
 			return nil, err
 		}
@@ -226,7 +226,7 @@ func (app *transaction) Receive(section views.Section, pk signature.PrivateKey,
 
 	to := []hash.Hash{}
 	for _, onePubKey := range ring {
-		hsh, err := app.hashAdapter.FromBytes([]byte(onePubKey.String()))
+		hsh, err := app.hashAdapter.Hash([]byte(onePubKey.String()))
 		if err != nil {
 			return err
 		}
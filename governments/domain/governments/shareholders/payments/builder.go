@@ -55,7 +55,7 @@ func (app *builder) Now() (Payment, error) {
 
 	msg := app.content.Hash().String()
 	pubKey := app.sig.PublicKey(msg)
-	hashedPubKey, err := app.hashAdapter.FromBytes([]byte(pubKey.String()))
+	hashedPubKey, err := app.hashAdapter.Hash([]byte(pubKey.String()))
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (app *builder) Now() (Transfer, error) {
 	pubKeys := app.sig.Ring()
 	pubKeyHashes := []hash.Hash{}
 	for _, oneKey := range pubKeys {
-		hsh, err := app.hashAdapter.FromBytes([]byte(oneKey.String()))
+		hsh, err := app.hashAdapter.Hash([]byte(oneKey.String()))
 		if err != nil {
 			return nil, err
 		}
@@ -75,7 +75,7 @@ func (app *builder) Now() (Resolution, error) {
 		sigKeys := sig.Ring()
 		pubKeyHashes := []hash.Hash{}
 		for _, oneSigKey := range sigKeys {
-			hsh, err := app.hashAdapter.FromBytes([]byte(oneSigKey.String()))
+			hsh, err := app.hashAdapter.Hash([]byte(oneSigKey.String()))
 			if err != nil {
 				return nil, err
 			}
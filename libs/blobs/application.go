@@ -0,0 +1,382 @@
+package blobs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/deepvalue-network/software/libs/files/infrastructure/kvstores"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hashtree"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+type application struct {
+	hashAdapter     hash.Adapter
+	hashTreeBuilder hashtree.Builder
+	store           kvstores.Store
+	chunkSize       uint
+	mutex           sync.Mutex
+}
+
+func createApplication(
+	hashAdapter hash.Adapter,
+	hashTreeBuilder hashtree.Builder,
+	store kvstores.Store,
+	chunkSize uint,
+) (Application, error) {
+	if chunkSize == 0 {
+		return nil, errors.New(chunkSizeIsZeroErrorOutput)
+	}
+
+	out := application{
+		hashAdapter:     hashAdapter,
+		hashTreeBuilder: hashTreeBuilder,
+		store:           store,
+		chunkSize:       chunkSize,
+	}
+
+	return &out, nil
+}
+
+// Put stores a blob if it does not exists yet, adds a reference to it and returns its hash
+func (app *application) Put(data []byte) (*hash.Hash, error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	batch := app.store.Batch()
+	references := map[string]uint{}
+	if uint(len(data)) <= app.chunkSize {
+		hsh, err := app.putChunk(batch, references, data)
+		if err != nil {
+			return nil, err
+		}
+
+		return hsh, batch.Commit()
+	}
+
+	chunkHashes := []hash.Hash{}
+	chunks := []string{}
+	for offset := 0; offset < len(data); offset += int(app.chunkSize) {
+		end := offset + int(app.chunkSize)
+		if end > len(data) {
+			end = len(data)
+		}
+
+		chunkHash, err := app.hashAdapter.Hash(data[offset:end])
+		if err != nil {
+			return nil, err
+		}
+
+		chunkHashes = append(chunkHashes, *chunkHash)
+		chunks = append(chunks, chunkHash.String())
+	}
+
+	tree, err := app.hashTreeBuilder.Create().WithHashes(chunkHashes).Now()
+	if err != nil {
+		return nil, err
+	}
+
+	hsh := tree.Head()
+	if app.store.Has(toKey(manifestsPrefix, hsh)) {
+		err := app.addReference(batch, references, hsh, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hsh, batch.Commit()
+	}
+
+	for offset := 0; offset < len(data); offset += int(app.chunkSize) {
+		end := offset + int(app.chunkSize)
+		if end > len(data) {
+			end = len(data)
+		}
+
+		_, err := app.putChunk(batch, references, data[offset:end])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	js, err := hydro.MarshalBinary(manifest{
+		Length: uint64(len(data)),
+		Chunks: chunks,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Put(toKey(manifestsPrefix, hsh), js)
+	err = app.addReference(batch, references, hsh, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hsh, batch.Commit()
+}
+
+// Retrieve retrieves a blob by hash, and verifies its content
+func (app *application) Retrieve(hsh hash.Hash) ([]byte, error) {
+	if app.store.Has(toKey(blobsPrefix, hsh)) {
+		return app.retrieveChunk(hsh)
+	}
+
+	manifest, err := app.retrieveManifest(hsh)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkHashes := []hash.Hash{}
+	for _, oneChunk := range manifest.Chunks {
+		chunkHash, err := app.hashAdapter.FromString(oneChunk)
+		if err != nil {
+			return nil, err
+		}
+
+		chunkHashes = append(chunkHashes, *chunkHash)
+	}
+
+	tree, err := app.hashTreeBuilder.Create().WithHashes(chunkHashes).Now()
+	if err != nil {
+		return nil, err
+	}
+
+	if head := tree.Head(); !head.Compare(hsh) {
+		str := fmt.Sprintf("the chunks of the blob (hash: %s) build another hash (%s)", hsh.String(), head.String())
+		return nil, errors.New(str)
+	}
+
+	out := new(bytes.Buffer)
+	for _, oneChunkHash := range chunkHashes {
+		data, err := app.retrieveChunk(oneChunkHash)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Write(data)
+	}
+
+	if uint64(out.Len()) != manifest.Length {
+		str := fmt.Sprintf("the blob (hash: %s) was expected to contain %d bytes, %d assembled", hsh.String(), manifest.Length, out.Len())
+		return nil, errors.New(str)
+	}
+
+	return out.Bytes(), nil
+}
+
+// Exists returns true if the blob exists, false otherwise
+func (app *application) Exists(hsh hash.Hash) bool {
+	return app.store.Has(toKey(blobsPrefix, hsh)) || app.store.Has(toKey(manifestsPrefix, hsh))
+}
+
+// References returns the amount of references of a blob
+func (app *application) References(hsh hash.Hash) (uint, error) {
+	return app.references(map[string]uint{}, hsh)
+}
+
+// Reference adds a reference to an existing blob
+func (app *application) Reference(hsh hash.Hash) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if !app.Exists(hsh) {
+		str := fmt.Sprintf(blobDoesNotExistsPattern, hsh.String())
+		return errors.New(str)
+	}
+
+	batch := app.store.Batch()
+	err := app.addReference(batch, map[string]uint{}, hsh, 1)
+	if err != nil {
+		return err
+	}
+
+	return batch.Commit()
+}
+
+// Release removes a reference from a blob; the blob is removed by the next garbage collection once unreferenced
+func (app *application) Release(hsh hash.Hash) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	batch := app.store.Batch()
+	err := app.addReference(batch, map[string]uint{}, hsh, -1)
+	if err != nil {
+		return err
+	}
+
+	return batch.Commit()
+}
+
+// Collect removes the unreferenced blobs, and the chunks they were the last to reference, and returns the amount of
+// removed blobs and chunks
+func (app *application) Collect() (uint, error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	amount := uint(0)
+	for {
+		batch := app.store.Batch()
+		references := map[string]uint{}
+		removed := uint(0)
+		for _, oneKey := range app.store.Keys(fmt.Sprintf("%s/", referencesPrefix)) {
+			hsh, err := app.hashAdapter.FromString(strings.TrimPrefix(oneKey, fmt.Sprintf("%s/", referencesPrefix)))
+			if err != nil {
+				return amount, err
+			}
+
+			current, err := app.references(references, *hsh)
+			if err != nil {
+				return amount, err
+			}
+
+			if current > 0 {
+				continue
+			}
+
+			if app.store.Has(toKey(manifestsPrefix, *hsh)) {
+				manifest, err := app.retrieveManifest(*hsh)
+				if err != nil {
+					return amount, err
+				}
+
+				for _, oneChunk := range manifest.Chunks {
+					chunkHash, err := app.hashAdapter.FromString(oneChunk)
+					if err != nil {
+						return amount, err
+					}
+
+					err = app.addReference(batch, references, *chunkHash, -1)
+					if err != nil {
+						return amount, err
+					}
+				}
+
+				batch.Delete(toKey(manifestsPrefix, *hsh))
+			}
+
+			if app.store.Has(toKey(blobsPrefix, *hsh)) {
+				batch.Delete(toKey(blobsPrefix, *hsh))
+			}
+
+			batch.Delete(oneKey)
+			removed++
+		}
+
+		if removed <= 0 {
+			return amount, nil
+		}
+
+		err := batch.Commit()
+		if err != nil {
+			return amount, err
+		}
+
+		amount += removed
+	}
+}
+
+func (app *application) putChunk(batch kvstores.Batch, references map[string]uint, data []byte) (*hash.Hash, error) {
+	hsh, err := app.hashAdapter.Hash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	_, isPending := references[toKey(referencesPrefix, *hsh)]
+	if !isPending && !app.store.Has(toKey(blobsPrefix, *hsh)) {
+		batch.Put(toKey(blobsPrefix, *hsh), data)
+	}
+
+	err = app.addReference(batch, references, *hsh, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return hsh, nil
+}
+
+func (app *application) retrieveChunk(hsh hash.Hash) ([]byte, error) {
+	data, err := app.store.Retrieve(toKey(blobsPrefix, hsh))
+	if err != nil {
+		str := fmt.Sprintf(blobDoesNotExistsPattern, hsh.String())
+		return nil, errors.New(str)
+	}
+
+	verified, err := app.hashAdapter.Hash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verified.Compare(hsh) {
+		str := fmt.Sprintf("the blob (hash: %s) is corrupted, its content hashes to %s", hsh.String(), verified.String())
+		return nil, errors.New(str)
+	}
+
+	return data, nil
+}
+
+func (app *application) retrieveManifest(hsh hash.Hash) (*manifest, error) {
+	data, err := app.store.Retrieve(toKey(manifestsPrefix, hsh))
+	if err != nil {
+		str := fmt.Sprintf(blobDoesNotExistsPattern, hsh.String())
+		return nil, errors.New(str)
+	}
+
+	out := new(manifest)
+	err = hydro.UnmarshalBinary(data, out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// references returns the amount of references of a blob, including the ones pending in the current batch
+func (app *application) references(pending map[string]uint, hsh hash.Hash) (uint, error) {
+	key := toKey(referencesPrefix, hsh)
+	if amount, ok := pending[key]; ok {
+		return amount, nil
+	}
+
+	if !app.store.Has(key) {
+		return 0, nil
+	}
+
+	data, err := app.store.Retrieve(key)
+	if err != nil {
+		return 0, err
+	}
+
+	amount, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(amount), nil
+}
+
+func (app *application) addReference(batch kvstores.Batch, pending map[string]uint, hsh hash.Hash, delta int) error {
+	current, err := app.references(pending, hsh)
+	if err != nil {
+		return err
+	}
+
+	if delta < 0 && current < uint(-delta) {
+		str := fmt.Sprintf("the blob (hash: %s) has no reference to release", hsh.String())
+		return errors.New(str)
+	}
+
+	key := toKey(referencesPrefix, hsh)
+	amount := uint(int(current) + delta)
+	pending[key] = amount
+	batch.Put(key, []byte(strconv.FormatUint(uint64(amount), 10)))
+	return nil
+}
+
+func toKey(prefix string, hsh hash.Hash) string {
+	return fmt.Sprintf("%s/%s", prefix, hsh.String())
+}
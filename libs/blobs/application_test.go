@@ -0,0 +1,181 @@
+package blobs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/files/infrastructure/kvstores"
+)
+
+func TestApplication_Success(t *testing.T) {
+	basePath := "./test_files"
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, err := kvstores.NewStore(filepath.Join(basePath, "blobs.kv"), 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer store.Close()
+	application, err := NewApplication(store, 4)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// small blob, put twice:
+	small := []byte("abc")
+	smallHash, err := application.Put(small)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	application.Put(small)
+	if references, _ := application.References(*smallHash); references != 2 {
+		t.Errorf("the small blob was expected to have %d references, %d returned", 2, references)
+		return
+	}
+
+	// chunked blobs, sharing their first chunks:
+	first := []byte("aaaabbbbcc")
+	second := []byte("aaaabbbbdd")
+	firstHash, err := application.Put(first)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondHash, err := application.Put(second)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the small blob and 4 chunks, the manifests are stored apart:
+	if amount := len(store.Keys(blobsPrefix)); amount != 5 {
+		t.Errorf("%d blobs were expected, %d stored", 5, amount)
+		return
+	}
+
+	retFirst, err := application.Retrieve(*firstHash)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Equal(retFirst, first) {
+		t.Errorf("the retrieved blob is invalid")
+		return
+	}
+
+	// nothing is collected while referenced:
+	amount, err := application.Collect()
+	if err != nil || amount != 0 {
+		t.Errorf("no blob was expected to be collected")
+		return
+	}
+
+	// release the first chunked blob:
+	err = application.Release(*firstHash)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	amount, err = application.Collect()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the manifest and its own last chunk:
+	if amount != 2 {
+		t.Errorf("%d blobs were expected to be collected, %d collected", 2, amount)
+		return
+	}
+
+	if application.Exists(*firstHash) {
+		t.Errorf("the released blob was not expected to exist")
+		return
+	}
+
+	retSecond, err := application.Retrieve(*secondHash)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Equal(retSecond, second) {
+		t.Errorf("the shared chunks were not expected to be collected")
+		return
+	}
+
+	// a blob without references cannot be released:
+	err = application.Release(*firstHash)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestApplication_withZeroChunkSize_returnsError(t *testing.T) {
+	basePath := "./test_files"
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, err := kvstores.NewStore(filepath.Join(basePath, "blobs.kv"), 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer store.Close()
+	_, err = NewApplication(store, 0)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestApplication_retrieve_withTamperedManifest_returnsError(t *testing.T) {
+	basePath := "./test_files"
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, err := kvstores.NewStore(filepath.Join(basePath, "blobs.kv"), 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer store.Close()
+	application, err := NewApplication(store, 4)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	firstHash, _ := application.Put([]byte("aaaabbbbcc"))
+	secondHash, _ := application.Put([]byte("aaaabbbbdd"))
+
+	// the manifest of the first blob lists the chunks of the second one:
+	secondManifest, _ := store.Retrieve(toKey(manifestsPrefix, *secondHash))
+	store.Batch().Put(toKey(manifestsPrefix, *firstHash), secondManifest).Commit()
+
+	_, err = application.Retrieve(*firstHash)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -0,0 +1,7 @@
+package blobs
+
+// manifest represents the chunks of a blob, in order
+type manifest struct {
+	Length uint64   `json:"length"`
+	Chunks []string `json:"chunks"`
+}
@@ -0,0 +1,45 @@
+package blobs
+
+import (
+	"github.com/deepvalue-network/software/libs/files/infrastructure/kvstores"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hashtree"
+)
+
+// DefaultChunkSize represents the default maximum size of a chunk, in bytes
+const DefaultChunkSize = 256 * 1024
+
+const blobsPrefix = "blobs"
+
+const manifestsPrefix = "manifests"
+
+const referencesPrefix = "references"
+
+const blobDoesNotExistsPattern = "the blob (hash: %s) does not exists"
+
+const chunkSizeIsZeroErrorOutput = "the chunk size must be greater than zero"
+
+// NewApplication creates a new blob application on top of a key-value store.  The values bigger than the chunk
+// size are split in chunks, and their hash is the head of the hashtree of their chunk hashes.  The chunk size must be
+// greater than zero
+func NewApplication(
+	store kvstores.Store,
+	chunkSize uint,
+) (Application, error) {
+	hashAdapter := hash.NewAdapter()
+	hashTreeBuilder := hashtree.NewBuilder()
+	return createApplication(hashAdapter, hashTreeBuilder, store, chunkSize)
+}
+
+// Application represents a content-addressed blob store.  A blob is stored once no matter how many times it is put:
+// every put, or reference, adds a reference to it, and every release removes one.  The blobs without references are
+// removed by the garbage collection
+type Application interface {
+	Put(data []byte) (*hash.Hash, error)
+	Retrieve(hsh hash.Hash) ([]byte, error)
+	Exists(hsh hash.Hash) bool
+	References(hsh hash.Hash) (uint, error)
+	Reference(hsh hash.Hash) error
+	Release(hsh hash.Hash) error
+	Collect() (uint, error)
+}
@@ -4,10 +4,19 @@ import "errors"
 
 type builder struct {
 	password []byte
+	cipher   Cipher
+	kdf      KeyDerivation
+	isLegacy bool
 }
 
 func createBuilder() Builder {
-	out := builder{}
+	out := builder{
+		password: nil,
+		cipher:   DefaultCipher,
+		kdf:      DefaultKeyDerivation,
+		isLegacy: false,
+	}
+
 	return &out
 }
 
@@ -22,14 +31,40 @@ func (app *builder) WithPassword(password []byte) Builder {
 	return app
 }
 
+// WithCipher adds a cipher to the builder
+func (app *builder) WithCipher(cipher Cipher) Builder {
+	app.cipher = cipher
+	return app
+}
+
+// WithKeyDerivation adds a key derivation function to the builder
+func (app *builder) WithKeyDerivation(kdf KeyDerivation) Builder {
+	app.kdf = kdf
+	return app
+}
+
+// WithLegacyDecryption allows the decryption of the legacy, unauthenticated, ciphertexts.  Their decryption never fails,
+// so a wrong password, or a text that is not a ciphertext, decrypts to garbage
+func (app *builder) WithLegacyDecryption() Builder {
+	app.isLegacy = true
+	return app
+}
+
 // Now builds a new Encryption instance
 func (app *builder) Now() (Encryption, error) {
 	if app.password == nil {
 		return nil, errors.New("the password is mandatory in order to build an Encryption instance")
 	}
 
-	hasher := curve.Hash()
-	hasher.Write([]byte(app.password))
-	hashedPass := hasher.Sum(nil)
-	return createEncryption(hashedPass), nil
+	_, err := app.cipher.aead(make([]byte, keySize))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = app.kdf.defaultParams()
+	if err != nil {
+		return nil, err
+	}
+
+	return createEncryption(app.password, app.cipher, app.kdf, app.isLegacy), nil
 }
@@ -0,0 +1,27 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+func (obj Cipher) aead(key []byte) (cipher.AEAD, error) {
+	switch obj {
+	case AESGCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return cipher.NewGCM(block)
+	case ChaCha20Poly1305:
+		return chacha20poly1305.New(key)
+	}
+
+	str := fmt.Sprintf("the cipher (%d) is not supported", obj)
+	return nil, errors.New(str)
+}
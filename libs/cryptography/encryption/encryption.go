@@ -5,49 +5,206 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
+	"sync"
 )
 
+// the header of the first version contains: cipher (1 byte), kdf (1 byte), kdf params (3 x 4 bytes), salt
+const versionOneHeaderSize = 2 + 3*4 + saltSize
+
 type encryption struct {
-	hashedPass []byte
+	password      []byte
+	cipher        Cipher
+	kdf           KeyDerivation
+	sealingHeader []byte
+	sealing       cipher.AEAD
+	opening       map[string]cipher.AEAD
+	mutex         sync.Mutex
+	isLegacy      bool
 }
 
-func createEncryption(hashedPass []byte) Encryption {
+func createEncryption(
+	password []byte,
+	ciph Cipher,
+	kdf KeyDerivation,
+	isLegacy bool,
+) Encryption {
 	out := encryption{
-		hashedPass: hashedPass,
+		password:      password,
+		cipher:        ciph,
+		kdf:           kdf,
+		sealingHeader: nil,
+		sealing:       nil,
+		opening:       map[string]cipher.AEAD{},
+		isLegacy:      isLegacy,
 	}
 
 	return &out
 }
 
-// Encrypt encrypts a message
+// Encrypt encrypts a message.  The key is derived once per instance, from a random salt, and every message is sealed
+// with a random nonce
 func (obj *encryption) Encrypt(message []byte) (string, error) {
-	block, blockErr := aes.NewCipher(obj.hashedPass)
-	if blockErr != nil {
-		return "", blockErr
+	header, aead, err := obj.sealingAEAD()
+	if err != nil {
+		return "", err
 	}
 
-	ciphertext := make([]byte, aes.BlockSize+len(message))
-	iv := ciphertext[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 		return "", err
 	}
 
-	stream := cipher.NewCFBEncrypter(block, iv)
-	stream.XORKeyStream(ciphertext[aes.BlockSize:], message)
-
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	// the header is authenticated, so its params cannot be tampered with:
+	sealed := aead.Seal(nil, nonce, message, obj.additionalData(header))
+	payload := append(append(append([]byte{}, header...), nonce...), sealed...)
+	return fmt.Sprintf("%s%s%s", versionOne, versionDelimiter, base64.StdEncoding.EncodeToString(payload)), nil
 }
 
-// Decrypt decrypts a message
+// Decrypt decrypts a message.  The legacy ciphertexts are only decrypted if allowed, since their decryption never fails
 func (obj *encryption) Decrypt(encryptedText string) ([]byte, error) {
+	// legacy ciphertexts are plain base64, which never contains the delimiter:
+	if !strings.Contains(encryptedText, versionDelimiter) {
+		if !obj.isLegacy {
+			return nil, errors.New("the encrypted text is not versioned, and the legacy decryption is not allowed")
+		}
+
+		return obj.decryptLegacy(encryptedText)
+	}
+
+	sections := strings.SplitN(encryptedText, versionDelimiter, 2)
+	if sections[0] != versionOne {
+		str := fmt.Sprintf("the encrypted text version (%s) is not supported", sections[0])
+		return nil, errors.New(str)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(sections[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) < versionOneHeaderSize {
+		return nil, errors.New("the encrypted text is too short to contain a valid header")
+	}
+
+	header := payload[:versionOneHeaderSize]
+	ciph := Cipher(header[0])
+	kdf := KeyDerivation(header[1])
+	params := keyDerivationParams{}
+	for index := range params {
+		offset := 2 + index*4
+		params[index] = binary.BigEndian.Uint32(header[offset : offset+4])
+	}
+
+	salt := header[versionOneHeaderSize-saltSize:]
+	aead, err := obj.openingAEAD(header, ciph, kdf, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := payload[versionOneHeaderSize:]
+	if len(remaining) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("the encrypted text is too short to contain a valid nonce and authentication tag")
+	}
+
+	nonce := remaining[:aead.NonceSize()]
+	message, err := aead.Open(nil, nonce, remaining[aead.NonceSize():], obj.additionalData(header))
+	if err != nil {
+		return nil, errors.New("the encrypted text cannot be decrypted: the password is invalid or the encrypted text has been tampered with")
+	}
+
+	return message, nil
+}
+
+// sealingAEAD returns the header and the cipher used to encrypt, deriving its key on the first call
+func (obj *encryption) sealingAEAD() ([]byte, cipher.AEAD, error) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if obj.sealing != nil {
+		return obj.sealingHeader, obj.sealing, nil
+	}
+
+	params, err := obj.kdf.defaultParams()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, nil, err
+	}
+
+	header := []byte{
+		byte(obj.cipher),
+		byte(obj.kdf),
+	}
+
+	for _, oneParam := range params {
+		paramBytes := make([]byte, 4)
+		binary.BigEndian.PutUint32(paramBytes, oneParam)
+		header = append(header, paramBytes...)
+	}
+
+	header = append(header, salt...)
+	aead, err := obj.aead(obj.cipher, obj.kdf, salt, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	obj.sealingHeader = header
+	obj.sealing = aead
+	obj.opening[string(header)] = aead
+	return header, aead, nil
+}
+
+// openingAEAD returns the cipher used to decrypt the messages of a header, deriving its key once per header
+func (obj *encryption) openingAEAD(header []byte, ciph Cipher, kdf KeyDerivation, salt []byte, params keyDerivationParams) (cipher.AEAD, error) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if aead, ok := obj.opening[string(header)]; ok {
+		return aead, nil
+	}
+
+	aead, err := obj.aead(ciph, kdf, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(obj.opening) < maxOpeningKeys {
+		obj.opening[string(header)] = aead
+	}
+
+	return aead, nil
+}
+
+func (obj *encryption) aead(ciph Cipher, kdf KeyDerivation, salt []byte, params keyDerivationParams) (cipher.AEAD, error) {
+	key, err := kdf.derive(obj.password, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return ciph.aead(key)
+}
+
+func (obj *encryption) additionalData(header []byte) []byte {
+	return append([]byte(versionOne), header...)
+}
+
+func (obj *encryption) decryptLegacy(encryptedText string) ([]byte, error) {
 	cipherText, cipherTextErr := base64.StdEncoding.DecodeString(encryptedText)
 	if cipherTextErr != nil {
 		return nil, cipherTextErr
 	}
 
-	block, blockErr := aes.NewCipher(obj.hashedPass)
+	hasher := curve.Hash()
+	hasher.Write(obj.password)
+	block, blockErr := aes.NewCipher(hasher.Sum(nil))
 	if blockErr != nil {
 		return nil, blockErr
 	}
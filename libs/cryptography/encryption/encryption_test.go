@@ -0,0 +1,233 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+)
+
+func encryptLegacyForTests(pass string, message []byte) string {
+	hasher := curve.Hash()
+	hasher.Write([]byte(pass))
+	block, err := aes.NewCipher(hasher.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+
+	ciphertext := make([]byte, aes.BlockSize+len(message))
+	iv := ciphertext[:aes.BlockSize]
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		panic(err)
+	}
+
+	stream := cipher.NewCFBEncrypter(block, iv)
+	stream.XORKeyStream(ciphertext[aes.BlockSize:], message)
+	return base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+func TestEncryption_ciphersAndKeyDerivations_Success(t *testing.T) {
+	ciphers := []Cipher{
+		AESGCM,
+		ChaCha20Poly1305,
+	}
+
+	kdfs := []KeyDerivation{
+		Scrypt,
+		Argon2,
+	}
+
+	message := []byte("this is a secret message")
+	for _, oneCipher := range ciphers {
+		for _, oneKDF := range kdfs {
+			enc, err := NewBuilder().Create().WithPassword([]byte("my password")).WithCipher(oneCipher).WithKeyDerivation(oneKDF).Now()
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			encrypted, err := enc.Encrypt(message)
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			decrypted, err := enc.Decrypt(encrypted)
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+
+			if bytes.Compare(message, decrypted) != 0 {
+				t.Errorf("the decrypted message was expected to be %s, %s returned", message, decrypted)
+				return
+			}
+
+			// a different builder configuration still decrypts, since the params are in the header:
+			_, err = NewEncryption("my password").Decrypt(encrypted)
+			if err != nil {
+				t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+				return
+			}
+		}
+	}
+}
+
+func TestEncryption_derivesKeyOncePerInstance_Success(t *testing.T) {
+	enc := NewEncryption("my password")
+	first, err := enc.Encrypt([]byte("first message"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	second, err := enc.Encrypt([]byte("first message"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the header, and its salt, is shared while the nonce is not:
+	firstPayload, _ := base64.StdEncoding.DecodeString(strings.SplitN(first, versionDelimiter, 2)[1])
+	secondPayload, _ := base64.StdEncoding.DecodeString(strings.SplitN(second, versionDelimiter, 2)[1])
+	if !bytes.Equal(firstPayload[:versionOneHeaderSize], secondPayload[:versionOneHeaderSize]) {
+		t.Errorf("the messages of an instance were expected to share their header")
+		return
+	}
+
+	if first == second {
+		t.Errorf("the messages of an instance were expected to be sealed with different nonces")
+		return
+	}
+
+	// another instance derives the key from the header:
+	message, err := NewEncryption("my password").Decrypt(second)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(message) != "first message" {
+		t.Errorf("the decrypted message is invalid")
+		return
+	}
+
+	if !IsVersioned(first) || IsVersioned(encryptLegacyForTests("my password", []byte("message"))) {
+		t.Errorf("only the messages in the versioned format were expected to be versioned")
+		return
+	}
+}
+
+func TestEncryption_wrongPassword_returnsError(t *testing.T) {
+	encrypted, err := NewEncryption("my password").Encrypt([]byte("this is a secret message"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = NewEncryption("another password").Decrypt(encrypted)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestEncryption_tampered_returnsError(t *testing.T) {
+	enc := NewEncryption("my password")
+	encrypted, err := enc.Encrypt([]byte("this is a secret message"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	sections := strings.SplitN(encrypted, versionDelimiter, 2)
+	payload, _ := base64.StdEncoding.DecodeString(sections[1])
+	for _, oneIndex := range []int{0, 2, versionOneHeaderSize - 1, len(payload) - 1} {
+		tampered := make([]byte, len(payload))
+		copy(tampered, payload)
+		tampered[oneIndex] ^= 0x01
+
+		tamperedText := sections[0] + versionDelimiter + base64.StdEncoding.EncodeToString(tampered)
+		_, err = enc.Decrypt(tamperedText)
+		if err == nil {
+			t.Errorf("the error was expected to be valid when the byte (index: %d) is tampered with, nil returned", oneIndex)
+			return
+		}
+	}
+}
+
+func TestEncryption_legacy_Success(t *testing.T) {
+	message := []byte("this is a legacy secret message")
+	encrypted := encryptLegacyForTests("my password", message)
+	enc, err := NewBuilder().Create().WithPassword([]byte("my password")).WithLegacyDecryption().Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	decrypted, err := enc.Decrypt(encrypted)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(message, decrypted) != 0 {
+		t.Errorf("the decrypted message was expected to be %s, %s returned", message, decrypted)
+		return
+	}
+}
+
+func TestEncryption_legacy_notAllowed_returnsError(t *testing.T) {
+	// a legacy ciphertext, and a plain hex text, are both valid base64:
+	texts := []string{
+		encryptLegacyForTests("my password", []byte("this is a legacy secret message")),
+		"0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9",
+	}
+
+	for _, oneText := range texts {
+		_, err := NewEncryption("my password").Decrypt(oneText)
+		if err == nil {
+			t.Errorf("the error was expected to be valid, nil returned")
+			return
+		}
+	}
+}
+
+func TestKeyDerivation_paramsOutOfRange_returnsError(t *testing.T) {
+	cases := []struct {
+		name   string
+		kdf    KeyDerivation
+		params keyDerivationParams
+	}{
+		{name: "scrypt without cost", kdf: Scrypt, params: keyDerivationParams{0, 8, 1}},
+		{name: "scrypt cost", kdf: Scrypt, params: keyDerivationParams{maxScryptLogCost + 1, 8, 1}},
+		{name: "scrypt block size", kdf: Scrypt, params: keyDerivationParams{15, maxScryptBlockSize + 1, 1}},
+		{name: "scrypt parallelism", kdf: Scrypt, params: keyDerivationParams{15, 8, maxScryptParallelism + 1}},
+		{name: "scrypt memory", kdf: Scrypt, params: keyDerivationParams{maxScryptLogCost, maxScryptBlockSize, 1}},
+		{name: "argon2 without time", kdf: Argon2, params: keyDerivationParams{0, 64 * 1024, 4}},
+		{name: "argon2 time", kdf: Argon2, params: keyDerivationParams{maxArgon2Time + 1, 64 * 1024, 4}},
+		{name: "argon2 memory", kdf: Argon2, params: keyDerivationParams{1, maxArgon2Memory + 1, 4}},
+		{name: "argon2 threads", kdf: Argon2, params: keyDerivationParams{1, 64 * 1024, maxArgon2Threads + 1}},
+	}
+
+	salt := make([]byte, saltSize)
+	for _, oneCase := range cases {
+		_, err := oneCase.kdf.derive([]byte("my password"), salt, oneCase.params)
+		if err == nil {
+			t.Errorf("%s: the error was expected to be valid, nil returned", oneCase.name)
+			return
+		}
+	}
+}
+
+func TestBuilder_invalidCipher_returnsError(t *testing.T) {
+	_, err := NewBuilder().Create().WithPassword([]byte("my password")).WithCipher(Cipher(0)).Now()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
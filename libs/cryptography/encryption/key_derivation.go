@@ -0,0 +1,64 @@
+package encryption
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// the params of scrypt are: log2(N), r, p
+// the params of argon2 are: time, memory (KiB), threads
+type keyDerivationParams [3]uint32
+
+// the params are read from the ciphertext, so they are bounded to prevent exhausting the cpu and memory:
+const maxScryptLogCost = 20
+
+const maxScryptBlockSize = 32
+
+const maxScryptParallelism = 16
+
+// maxKeyDerivationMemory represents the max memory a key derivation can use, in bytes
+const maxKeyDerivationMemory = 256 * 1024 * 1024
+
+const maxArgon2Time = 8
+
+const maxArgon2Memory = maxKeyDerivationMemory / 1024
+
+const maxArgon2Threads = 16
+
+func (obj KeyDerivation) defaultParams() (keyDerivationParams, error) {
+	switch obj {
+	case Scrypt:
+		return keyDerivationParams{15, 8, 1}, nil
+	case Argon2:
+		return keyDerivationParams{1, 64 * 1024, 4}, nil
+	}
+
+	str := fmt.Sprintf("the key derivation function (%d) is not supported", obj)
+	return keyDerivationParams{}, errors.New(str)
+}
+
+func (obj KeyDerivation) derive(password []byte, salt []byte, params keyDerivationParams) ([]byte, error) {
+	switch obj {
+	case Scrypt:
+		isInvalid := params[0] <= 0 || params[0] > maxScryptLogCost || params[1] <= 0 || params[1] > maxScryptBlockSize || params[2] <= 0 || params[2] > maxScryptParallelism
+		if isInvalid || uint64(128)*uint64(params[1])<<params[0] > maxKeyDerivationMemory {
+			str := fmt.Sprintf("the scrypt params (log2 cost: %d, block size: %d, parallelism: %d) are invalid", params[0], params[1], params[2])
+			return nil, errors.New(str)
+		}
+
+		return scrypt.Key(password, salt, 1<<params[0], int(params[1]), int(params[2]), keySize)
+	case Argon2:
+		if params[0] <= 0 || params[0] > maxArgon2Time || params[1] <= 0 || params[1] > maxArgon2Memory || params[2] <= 0 || params[2] > maxArgon2Threads {
+			str := fmt.Sprintf("the argon2 params (time: %d, memory: %d, threads: %d) are invalid", params[0], params[1], params[2])
+			return nil, errors.New(str)
+		}
+
+		return argon2.IDKey(password, salt, params[0], params[1], uint8(params[2]), keySize), nil
+	}
+
+	str := fmt.Sprintf("the key derivation function (%d) is not supported", obj)
+	return nil, errors.New(str)
+}
@@ -1,16 +1,58 @@
 package encryption
 
 import (
+	"fmt"
+	"strings"
+
 	"go.dedis.ch/kyber/v3/group/edwards25519"
 )
 
+const (
+	// AESGCM represents the AES-256 cipher in GCM mode
+	AESGCM Cipher = iota + 1
+
+	// ChaCha20Poly1305 represents the ChaCha20-Poly1305 cipher
+	ChaCha20Poly1305
+)
+
+const (
+	// Scrypt represents the scrypt key derivation function
+	Scrypt KeyDerivation = iota + 1
+
+	// Argon2 represents the argon2id key derivation function
+	Argon2
+)
+
+// DefaultCipher represents the default cipher
+const DefaultCipher = AESGCM
+
+// DefaultKeyDerivation represents the default key derivation function
+const DefaultKeyDerivation = Scrypt
+
+// versionOne represents the first version of the authenticated encryption format
+const versionOne = "v1"
+
+// versionDelimiter separates the version from the encoded ciphertext
+const versionDelimiter = ":"
+
+const saltSize = 16
+
+const keySize = 32
+
+// maxOpeningKeys represents the maximum amount of derived keys kept to decrypt, by header
+const maxOpeningKeys = 64
+
 var curve = edwards25519.NewBlakeSHA256Ed25519()
 
-// NewEncryption creates a new encryption instance
+// NewEncryption creates a new encryption instance, using the default cipher and key derivation function; the legacy
+// ciphertexts are not decrypted
 func NewEncryption(pass string) Encryption {
-	hasher := curve.Hash()
-	hasher.Write([]byte(pass))
-	return createEncryption(hasher.Sum(nil))
+	return createEncryption([]byte(pass), DefaultCipher, DefaultKeyDerivation, false)
+}
+
+// IsVersioned returns true if the text is in a versioned encryption format, whether or not it can be decrypted
+func IsVersioned(text string) bool {
+	return strings.HasPrefix(text, fmt.Sprintf("%s%s", versionOne, versionDelimiter))
 }
 
 // NewBuilder creates a new builder instance
@@ -18,10 +60,19 @@ func NewBuilder() Builder {
 	return createBuilder()
 }
 
+// Cipher represents an authenticated cipher
+type Cipher uint8
+
+// KeyDerivation represents a key derivation function
+type KeyDerivation uint8
+
 // Builder represents the builder
 type Builder interface {
 	Create() Builder
 	WithPassword(password []byte) Builder
+	WithCipher(cipher Cipher) Builder
+	WithKeyDerivation(kdf KeyDerivation) Builder
+	WithLegacyDecryption() Builder
 	Now() (Encryption, error)
 }
 
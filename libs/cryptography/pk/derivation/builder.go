@@ -0,0 +1,55 @@
+package derivation
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+type builder struct {
+	signatureAdapter  signature.PrivateKeyAdapter
+	encryptionBuilder ecies.Builder
+	seed              string
+}
+
+func createBuilder(
+	signatureAdapter signature.PrivateKeyAdapter,
+	encryptionBuilder ecies.Builder,
+) Builder {
+	out := builder{
+		signatureAdapter:  signatureAdapter,
+		encryptionBuilder: encryptionBuilder,
+		seed:              "",
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *builder) Create() Builder {
+	return createBuilder(app.signatureAdapter, app.encryptionBuilder)
+}
+
+// WithSeed adds a seed to the builder.  The seed is expected to contain at least 128 bits of entropy, like a mnemonic of
+// 12 words: it is stretched before the master key is computed, which slows guessing it but cannot make a weak seed safe
+func (app *builder) WithSeed(seed string) Builder {
+	app.seed = seed
+	return app
+}
+
+// Now builds a new Master instance
+func (app *builder) Now() (Master, error) {
+	if app.seed == "" {
+		return nil, errors.New("the seed is mandatory in order to build a Master instance")
+	}
+
+	stretched := pbkdf2.Key([]byte(app.seed), []byte(seedSalt), seedIterations, sha512.Size, sha512.New)
+	mac := hmac.New(sha512.New, []byte(masterKey))
+	mac.Write(stretched)
+	sum := mac.Sum(nil)
+	return createMaster(app.signatureAdapter, app.encryptionBuilder, sum[:32], sum[32:]), nil
+}
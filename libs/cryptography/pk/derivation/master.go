@@ -0,0 +1,84 @@
+package derivation
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type master struct {
+	signatureAdapter  signature.PrivateKeyAdapter
+	encryptionBuilder ecies.Builder
+	key               []byte
+	chain             []byte
+}
+
+func createMaster(
+	signatureAdapter signature.PrivateKeyAdapter,
+	encryptionBuilder ecies.Builder,
+	key []byte,
+	chain []byte,
+) Master {
+	out := master{
+		signatureAdapter:  signatureAdapter,
+		encryptionBuilder: encryptionBuilder,
+		key:               key,
+		chain:             chain,
+	}
+
+	return &out
+}
+
+// Signature derives a signature PrivateKey at the given path
+func (obj *master) Signature(path Path) (signature.PrivateKey, error) {
+	purpose := path.Purpose()
+	if purpose != PurposeSignature && purpose != PurposeTransfer {
+		str := fmt.Sprintf("the path (%s) cannot be used to derive a signature key", path.String())
+		return nil, errors.New(str)
+	}
+
+	scalar := obj.derive(path)
+	return obj.signatureAdapter.ToPrivateKey(scalar.String())
+}
+
+// Encryption derives an encryption PrivateKey at the given path
+func (obj *master) Encryption(path Path) (ecies.PrivateKey, error) {
+	if path.Purpose() != PurposeEncryption {
+		str := fmt.Sprintf("the path (%s) cannot be used to derive an encryption key", path.String())
+		return nil, errors.New(str)
+	}
+
+	scalar := obj.derive(path)
+	return obj.encryptionBuilder.Create().WithPK(scalar).Now()
+}
+
+func (obj *master) derive(path Path) kyber.Scalar {
+	index := make([]byte, 4)
+	binary.BigEndian.PutUint32(index, path.Index())
+
+	key, chain := obj.key, obj.chain
+	for _, data := range [][]byte{
+		path.Government().Bytes(),
+		{byte(path.Purpose())},
+		index,
+	} {
+		key, chain = deriveChild(key, chain, data)
+	}
+
+	return curve.Scalar().Pick(curve.XOF(key))
+}
+
+func deriveChild(key []byte, chain []byte, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, chain)
+	mac.Write([]byte{0x00})
+	mac.Write(key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
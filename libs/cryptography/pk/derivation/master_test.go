@@ -0,0 +1,175 @@
+package derivation
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestMaster_sameSeed_deriveSameKeys_Success(t *testing.T) {
+	gov := uuid.NewV4()
+	path, err := NewPathBuilder().Create().WithGovernment(&gov).WithPurpose(PurposeSignature).WithIndex(0).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// encode to string, back and forth:
+	retPath, err := NewPathAdapter().ToPath(path.String())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if retPath.String() != path.String() {
+		t.Errorf("the path was expected to be %s, %s returned", path.String(), retPath.String())
+		return
+	}
+
+	first, err := NewBuilder().Create().WithSeed("this is my seed").Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	second, err := NewBuilder().Create().WithSeed("this is my seed").Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	firstPK, err := first.Signature(path)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondPK, err := second.Signature(retPath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !firstPK.PublicKey().Equals(secondPK.PublicKey()) {
+		t.Errorf("the same seed and path were expected to derive the same signature key")
+		return
+	}
+
+	sig, err := secondPK.Sign("this is a message")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !sig.PublicKey("this is a message").Equals(firstPK.PublicKey()) {
+		t.Errorf("the signature was expected to be signed by the derived key")
+		return
+	}
+
+	// another index derives another key:
+	nextPath, _ := NewPathBuilder().Create().WithGovernment(&gov).WithPurpose(PurposeTransfer).WithIndex(1).Now()
+	nextPK, err := first.Signature(nextPath)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if nextPK.PublicKey().Equals(firstPK.PublicKey()) {
+		t.Errorf("another path was expected to derive another signature key")
+		return
+	}
+
+	// another seed derives another key:
+	other, _ := NewBuilder().Create().WithSeed("this is another seed").Now()
+	otherPK, _ := other.Signature(path)
+	if otherPK.PublicKey().Equals(firstPK.PublicKey()) {
+		t.Errorf("another seed was expected to derive another signature key")
+		return
+	}
+}
+
+func TestMaster_encryption_Success(t *testing.T) {
+	gov := uuid.NewV4()
+	path, _ := NewPathBuilder().Create().WithGovernment(&gov).WithPurpose(PurposeEncryption).WithIndex(3).Now()
+	master, err := NewBuilder().Create().WithSeed("this is my seed").Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pk, err := master.Encryption(path)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// rebuild the identity, on another machine:
+	rebuilt, _ := NewBuilder().Create().WithSeed("this is my seed").Now()
+	rebuiltPK, err := rebuilt.Encryption(path)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	msg := []byte("this is a message")
+	cipher, err := pk.Public().Encrypt(msg)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	decrypted, err := rebuiltPK.Decrypt(cipher)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(msg, decrypted) != 0 {
+		t.Errorf("the decrypted message was expected to be %s, %s returned", msg, decrypted)
+		return
+	}
+
+	// a signature key cannot be derived from an encryption path:
+	_, err = master.Signature(path)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestMaster_seedIsStretched_Success(t *testing.T) {
+	gov := uuid.NewV4()
+	path, _ := NewPathBuilder().Create().WithGovernment(&gov).WithPurpose(PurposeSignature).WithIndex(0).Now()
+	master, err := NewBuilder().Create().WithSeed("this is my seed").Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pk, err := master.Signature(path)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the master key computed directly from the seed, without stretching it:
+	mac := hmac.New(sha512.New, []byte(masterKey))
+	mac.Write([]byte("this is my seed"))
+	sum := mac.Sum(nil)
+	unstretched := createMaster(signature.NewPrivateKeyAdapter(), ecies.NewBuilder(), sum[:32], sum[32:])
+	unstretchedPK, err := unstretched.Signature(path)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if pk.PublicKey().Equals(unstretchedPK.PublicKey()) {
+		t.Errorf("the seed was expected to be stretched before the master key is computed")
+		return
+	}
+}
@@ -0,0 +1,53 @@
+package derivation
+
+import (
+	"fmt"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type path struct {
+	gov     *uuid.UUID
+	purpose Purpose
+	index   uint32
+}
+
+func createPath(
+	gov *uuid.UUID,
+	purpose Purpose,
+	index uint32,
+) Path {
+	out := path{
+		gov:     gov,
+		purpose: purpose,
+		index:   index,
+	}
+
+	return &out
+}
+
+// Government returns the government ID
+func (obj *path) Government() *uuid.UUID {
+	return obj.gov
+}
+
+// Purpose returns the purpose
+func (obj *path) Purpose() Purpose {
+	return obj.purpose
+}
+
+// Index returns the index
+func (obj *path) Index() uint32 {
+	return obj.index
+}
+
+// String returns the string representation of the path
+func (obj *path) String() string {
+	return strings.Join([]string{
+		pathPrefix,
+		obj.gov.String(),
+		fmt.Sprintf("%d", obj.purpose),
+		fmt.Sprintf("%d", obj.index),
+	}, pathDelimiter)
+}
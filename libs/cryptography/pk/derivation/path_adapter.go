@@ -0,0 +1,50 @@
+package derivation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type pathAdapter struct {
+	builder PathBuilder
+}
+
+func createPathAdapter(
+	builder PathBuilder,
+) PathAdapter {
+	out := pathAdapter{
+		builder: builder,
+	}
+
+	return &out
+}
+
+// ToPath converts a string to a Path instance
+func (app *pathAdapter) ToPath(str string) (Path, error) {
+	sections := strings.Split(str, pathDelimiter)
+	if len(sections) != 4 || sections[0] != pathPrefix {
+		str := fmt.Sprintf("the path (%s) was expected to be formatted as %s/<government>/<purpose>/<index>", str, pathPrefix)
+		return nil, errors.New(str)
+	}
+
+	gov, err := uuid.FromString(sections[1])
+	if err != nil {
+		return nil, err
+	}
+
+	purpose, err := strconv.ParseUint(sections[2], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := strconv.ParseUint(sections[3], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.builder.Create().WithGovernment(&gov).WithPurpose(Purpose(purpose)).WithIndex(uint32(index)).Now()
+}
@@ -0,0 +1,61 @@
+package derivation
+
+import (
+	"errors"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type pathBuilder struct {
+	gov     *uuid.UUID
+	purpose Purpose
+	index   uint32
+}
+
+func createPathBuilder() PathBuilder {
+	out := pathBuilder{
+		gov:     nil,
+		purpose: 0,
+		index:   0,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *pathBuilder) Create() PathBuilder {
+	return createPathBuilder()
+}
+
+// WithGovernment adds a government ID to the builder
+func (app *pathBuilder) WithGovernment(gov *uuid.UUID) PathBuilder {
+	app.gov = gov
+	return app
+}
+
+// WithPurpose adds a purpose to the builder
+func (app *pathBuilder) WithPurpose(purpose Purpose) PathBuilder {
+	app.purpose = purpose
+	return app
+}
+
+// WithIndex adds an index to the builder
+func (app *pathBuilder) WithIndex(index uint32) PathBuilder {
+	app.index = index
+	return app
+}
+
+// Now builds a new Path instance
+func (app *pathBuilder) Now() (Path, error) {
+	if app.gov == nil {
+		return nil, errors.New("the government ID is mandatory in order to build a Path instance")
+	}
+
+	if app.purpose < PurposeSignature || app.purpose > PurposeTransfer {
+		str := fmt.Sprintf("the purpose (%d) is invalid", app.purpose)
+		return nil, errors.New(str)
+	}
+
+	return createPath(app.gov, app.purpose, app.index), nil
+}
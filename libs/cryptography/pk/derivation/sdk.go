@@ -0,0 +1,97 @@
+package derivation
+
+import (
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+	uuid "github.com/satori/go.uuid"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+/*
+ * s = PBKDF2-HMAC-SHA512(seed, seedSalt, seedIterations)
+ * (k, c) = HMAC-SHA512(masterKey, s)
+ * (ki, ci) = HMAC-SHA512(c, 0x00 || k || data)
+ * x = Pick(XOF(k))
+ * where ...
+ * 1. s is the stretched seed, like the seed of a BIP39 mnemonic
+ * 2. k is the key and c is the chain code of a node
+ * 3. data is the government ID, the purpose and then the index, one per level of the path
+ * 4. x is the scalar of the derived private key
+ *
+ * Every level is hardened: a child key cannot be linked to its parent without the seed.
+ */
+
+// Purpose represents the purpose of a derived key
+type Purpose uint8
+
+const (
+	// PurposeSignature represents the purpose of a signature key
+	PurposeSignature Purpose = iota + 1
+
+	// PurposeEncryption represents the purpose of an encryption key
+	PurposeEncryption
+
+	// PurposeTransfer represents the purpose of a one-time signature key, used for a single transfer
+	PurposeTransfer
+)
+
+const masterKey = "deepvalue-network seed"
+const seedSalt = "deepvalue-network mnemonic"
+const seedIterations = 2048
+const pathPrefix = "m"
+const pathDelimiter = "/"
+
+var curve = edwards25519.NewBlakeSHA256Ed25519()
+
+// NewBuilder creates a new master builder
+func NewBuilder() Builder {
+	signatureAdapter := signature.NewPrivateKeyAdapter()
+	encryptionBuilder := ecies.NewBuilder()
+	return createBuilder(signatureAdapter, encryptionBuilder)
+}
+
+// NewPathBuilder creates a new path builder
+func NewPathBuilder() PathBuilder {
+	return createPathBuilder()
+}
+
+// NewPathAdapter creates a new path adapter
+func NewPathAdapter() PathAdapter {
+	builder := NewPathBuilder()
+	return createPathAdapter(builder)
+}
+
+// Builder represents a master builder
+type Builder interface {
+	Create() Builder
+	WithSeed(seed string) Builder
+	Now() (Master, error)
+}
+
+// Master represents the root of the keys derived from a seed
+type Master interface {
+	Signature(path Path) (signature.PrivateKey, error)
+	Encryption(path Path) (ecies.PrivateKey, error)
+}
+
+// PathAdapter represents a path adapter
+type PathAdapter interface {
+	ToPath(str string) (Path, error)
+}
+
+// PathBuilder represents a path builder
+type PathBuilder interface {
+	Create() PathBuilder
+	WithGovernment(gov *uuid.UUID) PathBuilder
+	WithPurpose(purpose Purpose) PathBuilder
+	WithIndex(index uint32) PathBuilder
+	Now() (Path, error)
+}
+
+// Path represents a derivation path: per government, per purpose, per index
+type Path interface {
+	Government() *uuid.UUID
+	Purpose() Purpose
+	Index() uint32
+	String() string
+}
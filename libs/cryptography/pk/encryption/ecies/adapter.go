@@ -0,0 +1,50 @@
+package ecies
+
+import (
+	"encoding/base64"
+)
+
+type adapter struct {
+	builder Builder
+}
+
+func createAdapter(builder Builder) Adapter {
+	out := adapter{
+		builder: builder,
+	}
+
+	return &out
+}
+
+// FromBytes converts []byte to PrivateKey
+func (app *adapter) FromBytes(bytes []byte) (PrivateKey, error) {
+	pk := curve.Scalar()
+	err := pk.UnmarshalBinary(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.builder.Create().WithPK(pk).Now()
+}
+
+// FromEncoded converts an encoded string to PrivateKey
+func (app *adapter) FromEncoded(encoded string) (PrivateKey, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.FromBytes(decoded)
+}
+
+// ToBytes converts a PrivateKey to []byte
+func (app *adapter) ToBytes(pk PrivateKey) []byte {
+	bytes, _ := pk.Key().MarshalBinary()
+	return bytes
+}
+
+// ToEncoded converts a PrivateKey to an encoded string
+func (app *adapter) ToEncoded(pk PrivateKey) string {
+	bytes := app.ToBytes(pk)
+	return base64.StdEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,52 @@
+package ecies
+
+import (
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies/public"
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type builder struct {
+	pubKeyBuilder public.Builder
+	pk            kyber.Scalar
+}
+
+func createBuilder(pubKeyBuilder public.Builder) Builder {
+	out := builder{
+		pubKeyBuilder: pubKeyBuilder,
+		pk:            nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *builder) Create() Builder {
+	return createBuilder(app.pubKeyBuilder)
+}
+
+// WithPK adds a privateKey to the builder
+func (app *builder) WithPK(pk kyber.Scalar) Builder {
+	app.pk = pk
+	return app
+}
+
+// Now builds a new PrivateKey instance
+func (app *builder) Now() (PrivateKey, error) {
+	if app.pk == nil {
+		return nil, errors.New("the curve scalar is mandatory in order to build an ecies PrivateKey instance")
+	}
+
+	if app.pk.Equal(curve.Scalar().Zero()) {
+		return nil, errors.New("the curve scalar of an ecies PrivateKey cannot be zero (0)")
+	}
+
+	point := curve.Point().Mul(app.pk, nil)
+	pubKey, err := app.pubKeyBuilder.Create().WithKey(point).Now()
+	if err != nil {
+		return nil, err
+	}
+
+	return createPrivateKey(app.pk, pubKey), nil
+}
@@ -0,0 +1,19 @@
+package ecies
+
+type factory struct {
+	builder Builder
+}
+
+func createFactory(builder Builder) Factory {
+	out := factory{
+		builder: builder,
+	}
+
+	return &out
+}
+
+// Create generates a new PrivateKey instance
+func (app *factory) Create() (PrivateKey, error) {
+	pk := curve.Scalar().Pick(curve.RandomStream())
+	return app.builder.Create().WithPK(pk).Now()
+}
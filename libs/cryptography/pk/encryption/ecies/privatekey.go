@@ -0,0 +1,74 @@
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies/public"
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type privateKey struct {
+	key    kyber.Scalar
+	pubKey public.Key
+}
+
+func createPrivateKey(key kyber.Scalar, pubKey public.Key) PrivateKey {
+	out := privateKey{
+		key:    key,
+		pubKey: pubKey,
+	}
+
+	return &out
+}
+
+// Key returns the key
+func (obj *privateKey) Key() kyber.Scalar {
+	return obj.key
+}
+
+// Public returns the public key
+func (obj *privateKey) Public() public.Key {
+	return obj.pubKey
+}
+
+// Decrypt decrypts a cipher
+func (obj *privateKey) Decrypt(ciphertext []byte) ([]byte, error) {
+	pointSize := curve.PointLen()
+	if len(ciphertext) < pointSize+public.NonceSize {
+		return nil, errors.New("the cipher is too short to contain an ephemeral point and a nonce")
+	}
+
+	ephemeralBytes := ciphertext[:pointSize]
+	ephemeral := curve.Point()
+	err := ephemeral.UnmarshalBinary(ephemeralBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// S = x * R
+	shared := curve.Point().Mul(obj.key, ephemeral)
+	sharedKey, err := public.SharedKey(ephemeral, shared, obj.pubKey.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(sharedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := ciphertext[pointSize : pointSize+public.NonceSize]
+	decrypted, err := aead.Open(nil, nonce, ciphertext[pointSize+public.NonceSize:], ephemeralBytes)
+	if err != nil {
+		return nil, errors.New("the cipher cannot be decrypted using this PrivateKey")
+	}
+
+	return decrypted, nil
+}
@@ -0,0 +1,64 @@
+package ecies
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies/public"
+)
+
+func TestPrivateKey_encryptDecrypt_Success(t *testing.T) {
+	pk, err := NewFactory().Create()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// encode to string, back and forth:
+	retPK, err := NewAdapter().FromEncoded(NewAdapter().ToEncoded(pk))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pubKey, err := public.NewAdapter().FromEncoded(public.NewAdapter().ToEncoded(retPK.Public()))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	msg := []byte("this is a message to encrypt")
+	cipher, err := pubKey.Encrypt(msg)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	decrypted, err := pk.Decrypt(cipher)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(msg, decrypted) != 0 {
+		t.Errorf("the decrypted message was expected to be %s, %s returned", msg, decrypted)
+		return
+	}
+
+	// tamper the cipher:
+	cipher[len(cipher)-1] ^= 0x01
+	_, err = pk.Decrypt(cipher)
+	if err == nil {
+		t.Errorf("the error was expected to be valid when the cipher is tampered with, nil returned")
+		return
+	}
+
+	// another key:
+	otherPK, _ := NewFactory().Create()
+	otherCipher, _ := pubKey.Encrypt(msg)
+	_, err = otherPK.Decrypt(otherCipher)
+	if err == nil {
+		t.Errorf("the error was expected to be valid when using another PrivateKey, nil returned")
+		return
+	}
+}
@@ -0,0 +1,60 @@
+package public
+
+import (
+	"encoding/base64"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type adapter struct {
+	hashAdapter hash.Adapter
+	builder     Builder
+}
+
+func createAdapter(hashAdapter hash.Adapter, builder Builder) Adapter {
+	out := adapter{
+		hashAdapter: hashAdapter,
+		builder:     builder,
+	}
+
+	return &out
+}
+
+// FromBytes converts []byte to Key
+func (app *adapter) FromBytes(input []byte) (Key, error) {
+	point := curve.Point()
+	err := point.UnmarshalBinary(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.builder.Create().WithKey(point).Now()
+}
+
+// FromEncoded converts an encoded string to Key
+func (app *adapter) FromEncoded(encoded string) (Key, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.FromBytes(decoded)
+}
+
+// ToBytes converts Key to []byte
+func (app *adapter) ToBytes(key Key) []byte {
+	bytes, _ := key.Key().MarshalBinary()
+	return bytes
+}
+
+// ToEncoded converts a Key to an encoded string
+func (app *adapter) ToEncoded(key Key) string {
+	bytes := app.ToBytes(key)
+	return base64.StdEncoding.EncodeToString(bytes)
+}
+
+// ToHash converts a Key to an hash
+func (app *adapter) ToHash(key Key) (*hash.Hash, error) {
+	bytes := app.ToBytes(key)
+	return app.hashAdapter.Hash(bytes)
+}
@@ -0,0 +1,43 @@
+package public
+
+import (
+	"errors"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type builder struct {
+	key kyber.Point
+}
+
+func createBuilder() Builder {
+	out := builder{
+		key: nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *builder) Create() Builder {
+	return createBuilder()
+}
+
+// WithKey adds a key to the builder
+func (app *builder) WithKey(key kyber.Point) Builder {
+	app.key = key
+	return app
+}
+
+// Now builds a new key instance
+func (app *builder) Now() (Key, error) {
+	if app.key == nil {
+		return nil, errors.New("the curve point is mandatory in order to build a PublicKey instance")
+	}
+
+	if !isValidPoint(app.key) {
+		return nil, errors.New("the curve point of the PublicKey cannot be the identity or have a small order")
+	}
+
+	return createKey(app.key), nil
+}
@@ -0,0 +1,86 @@
+package public
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type key struct {
+	ky kyber.Point
+}
+
+func createKey(ky kyber.Point) Key {
+	out := key{
+		ky: ky,
+	}
+
+	return &out
+}
+
+// Key returns the public key
+func (obj *key) Key() kyber.Point {
+	return obj.ky
+}
+
+// Encrypt encrypts a message using the public key
+func (obj *key) Encrypt(msg []byte) ([]byte, error) {
+	// R = r * G, S = r * P
+	r := curve.Scalar().Pick(curve.RandomStream())
+	ephemeral := curve.Point().Mul(r, nil)
+	shared := curve.Point().Mul(r, obj.ky)
+	sharedKey, err := createSharedKey(ephemeral, shared, obj.ky)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(sharedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, NonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	ephemeralBytes, err := ephemeral.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	// the output is: R || nonce || ciphertext:
+	out := append(ephemeralBytes, nonce...)
+	return aead.Seal(out, nonce, msg, ephemeralBytes), nil
+}
+
+func createSharedKey(ephemeral kyber.Point, shared kyber.Point, pubKey kyber.Point) ([]byte, error) {
+	if !isValidPoint(ephemeral) {
+		return nil, errors.New("the ephemeral point cannot be the identity or have a small order")
+	}
+
+	hasher := curve.Hash()
+	for _, onePoint := range []kyber.Point{ephemeral, shared, pubKey} {
+		_, err := onePoint.MarshalTo(hasher)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+func isValidPoint(point kyber.Point) bool {
+	// the cofactor of edwards25519 is 8:
+	cleared := curve.Point().Mul(curve.Scalar().SetInt64(8), point)
+	return !cleared.Equal(curve.Point().Null())
+}
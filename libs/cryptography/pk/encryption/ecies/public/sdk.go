@@ -0,0 +1,51 @@
+package public
+
+import (
+	"github.com/deepvalue-network/software/libs/hash"
+	kyber "go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+// NonceSize represents the size of the nonce of an encrypted message
+const NonceSize = 12
+
+var curve = edwards25519.NewBlakeSHA256Ed25519()
+
+// NewAdapter returns a new ecies public adapter
+func NewAdapter() Adapter {
+	hashAdapter := hash.NewAdapter()
+	builder := NewBuilder()
+	return createAdapter(hashAdapter, builder)
+}
+
+// NewBuilder returns a new ecies public builder
+func NewBuilder() Builder {
+	return createBuilder()
+}
+
+// SharedKey derives the symmetric key shared by the ephemeral point and the public key point
+func SharedKey(ephemeral kyber.Point, shared kyber.Point, pubKey kyber.Point) ([]byte, error) {
+	return createSharedKey(ephemeral, shared, pubKey)
+}
+
+// Adapter represents a public key adapter
+type Adapter interface {
+	FromBytes(input []byte) (Key, error)
+	FromEncoded(encoded string) (Key, error)
+	ToBytes(key Key) []byte
+	ToEncoded(key Key) string
+	ToHash(key Key) (*hash.Hash, error)
+}
+
+// Builder represents a publicKey builder
+type Builder interface {
+	Create() Builder
+	WithKey(key kyber.Point) Builder
+	Now() (Key, error)
+}
+
+// Key represents an ecies encryption public key
+type Key interface {
+	Key() kyber.Point
+	Encrypt(msg []byte) ([]byte, error)
+}
@@ -0,0 +1,54 @@
+package ecies
+
+import (
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies/public"
+	kyber "go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+var curve = edwards25519.NewBlakeSHA256Ed25519()
+
+// NewFactory returns a new ecies privatekey factory
+func NewFactory() Factory {
+	builder := NewBuilder()
+	return createFactory(builder)
+}
+
+// NewAdapter returns a new ecies privatekey adapter
+func NewAdapter() Adapter {
+	builder := NewBuilder()
+	return createAdapter(builder)
+}
+
+// NewBuilder returns a new ecies privatekey builder
+func NewBuilder() Builder {
+	pubKeyBuilder := public.NewBuilder()
+	return createBuilder(pubKeyBuilder)
+}
+
+// Factory represents a privateKey factory
+type Factory interface {
+	Create() (PrivateKey, error)
+}
+
+// Adapter represents a privateKey adapter
+type Adapter interface {
+	FromBytes(bytes []byte) (PrivateKey, error)
+	FromEncoded(encoded string) (PrivateKey, error)
+	ToBytes(pk PrivateKey) []byte
+	ToEncoded(pk PrivateKey) string
+}
+
+// Builder represents a privateKey builder
+type Builder interface {
+	Create() Builder
+	WithPK(pk kyber.Scalar) Builder
+	Now() (PrivateKey, error)
+}
+
+// PrivateKey represents an ecies encryption private key
+type PrivateKey interface {
+	Key() kyber.Scalar
+	Public() public.Key
+	Decrypt(cipher []byte) ([]byte, error)
+}
@@ -0,0 +1,116 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type envelope struct {
+	keys    [][]byte
+	nonce   []byte
+	payload []byte
+}
+
+func createEnvelopeFromString(str string) (Envelope, error) {
+	sections := strings.Split(str, envelopeDelimiter)
+	if len(sections) != 3 {
+		str := fmt.Sprintf("the envelope string was expected to have %d sections, %d found", 3, len(sections))
+		return nil, errors.New(str)
+	}
+
+	keys := [][]byte{}
+	for _, oneKeyStr := range strings.Split(sections[0], envelopeElementDelimiter) {
+		if oneKeyStr == "" {
+			continue
+		}
+
+		key, err := base64.StdEncoding.DecodeString(oneKeyStr)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(sections[1])
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(sections[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return createEnvelope(keys, nonce, payload), nil
+}
+
+func createEnvelope(keys [][]byte, nonce []byte, payload []byte) Envelope {
+	out := envelope{
+		keys:    keys,
+		nonce:   nonce,
+		payload: payload,
+	}
+
+	return &out
+}
+
+// Keys returns the content key, encrypted for each recipient
+func (obj *envelope) Keys() [][]byte {
+	return obj.keys
+}
+
+// Nonce returns the nonce of the sealed payload
+func (obj *envelope) Nonce() []byte {
+	return obj.nonce
+}
+
+// Payload returns the sealed payload
+func (obj *envelope) Payload() []byte {
+	return obj.payload
+}
+
+// Open opens the envelope using the PrivateKey of one of its recipients
+func (obj *envelope) Open(pk Decrypter) ([]byte, error) {
+	// the recipients are anonymous, so every key is tried:
+	for _, oneKey := range obj.keys {
+		contentKey, err := pk.Decrypt(oneKey)
+		if err != nil || len(contentKey) != envelopeKeySize {
+			continue
+		}
+
+		aead, err := createEnvelopeAEAD(contentKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(obj.nonce) != aead.NonceSize() {
+			return nil, errors.New("the nonce of the envelope is invalid")
+		}
+
+		payload, err := aead.Open(nil, obj.nonce, obj.payload, nil)
+		if err != nil {
+			return nil, errors.New("the payload of the envelope has been tampered with")
+		}
+
+		return payload, nil
+	}
+
+	return nil, errors.New("the envelope cannot be opened using this PrivateKey: it is not one of its recipients")
+}
+
+// String returns the string representation of the envelope
+func (obj *envelope) String() string {
+	keys := []string{}
+	for _, oneKey := range obj.keys {
+		keys = append(keys, base64.StdEncoding.EncodeToString(oneKey))
+	}
+
+	return strings.Join([]string{
+		strings.Join(keys, envelopeElementDelimiter),
+		base64.StdEncoding.EncodeToString(obj.nonce),
+		base64.StdEncoding.EncodeToString(obj.payload),
+	}, envelopeDelimiter)
+}
@@ -0,0 +1,96 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+const envelopeKeySize = 32
+
+type envelopeBuilder struct {
+	recipients []Recipient
+	payload    []byte
+}
+
+func createEnvelopeBuilder() EnvelopeBuilder {
+	out := envelopeBuilder{
+		recipients: nil,
+		payload:    nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *envelopeBuilder) Create() EnvelopeBuilder {
+	return createEnvelopeBuilder()
+}
+
+// WithRecipients add recipients to the builder
+func (app *envelopeBuilder) WithRecipients(recipients []Recipient) EnvelopeBuilder {
+	app.recipients = recipients
+	return app
+}
+
+// WithPayload adds a payload to the builder
+func (app *envelopeBuilder) WithPayload(payload []byte) EnvelopeBuilder {
+	app.payload = payload
+	return app
+}
+
+// Now builds a new Envelope instance
+func (app *envelopeBuilder) Now() (Envelope, error) {
+	if app.recipients != nil && len(app.recipients) <= 0 {
+		app.recipients = nil
+	}
+
+	if app.recipients == nil {
+		return nil, errors.New("the recipients are mandatory in order to build an Envelope instance")
+	}
+
+	if app.payload == nil {
+		return nil, errors.New("the payload is mandatory in order to build an Envelope instance")
+	}
+
+	// the payload is sealed once, using a random content key:
+	contentKey := make([]byte, envelopeKeySize)
+	if _, err := io.ReadFull(rand.Reader, contentKey); err != nil {
+		return nil, err
+	}
+
+	aead, err := createEnvelopeAEAD(contentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	// then the content key is encrypted for every recipient:
+	keys := [][]byte{}
+	for _, oneRecipient := range app.recipients {
+		encryptedKey, err := oneRecipient.Encrypt(contentKey)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, encryptedKey)
+	}
+
+	sealed := aead.Seal(nil, nonce, app.payload, nil)
+	return createEnvelope(keys, nonce, sealed), nil
+}
+
+func createEnvelopeAEAD(contentKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
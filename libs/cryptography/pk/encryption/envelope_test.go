@@ -0,0 +1,72 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+)
+
+func TestEnvelope_rsaAndECIESRecipients_Success(t *testing.T) {
+	rsaPK, err := NewFactory(1024).Create()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	firstPK, err := ecies.NewFactory().Create()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondPK, err := ecies.NewFactory().Create()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	payload := []byte("this is a payload shared by every owner")
+	env, err := NewEnvelopeBuilder().Create().WithPayload(payload).WithRecipients([]Recipient{
+		rsaPK.Public(),
+		firstPK.Public(),
+		secondPK.Public(),
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// encode to string, back and forth:
+	retEnv, err := ToEnvelope(env.String())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for _, onePK := range []Decrypter{rsaPK, firstPK, secondPK} {
+		opened, err := retEnv.Open(onePK)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if bytes.Compare(payload, opened) != 0 {
+			t.Errorf("the opened payload was expected to be %s, %s returned", payload, opened)
+			return
+		}
+	}
+
+	outsider, err := ecies.NewFactory().Create()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = retEnv.Open(outsider)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
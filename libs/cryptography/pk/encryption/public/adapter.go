@@ -56,5 +56,5 @@ func (app *adapter) ToEncoded(key Key) string {
 // ToHash converts a Key to an hash
 func (app *adapter) ToHash(key Key) (*hash.Hash, error) {
 	bytes := app.ToBytes(key)
-	return app.hashAdapter.FromBytes(bytes)
+	return app.hashAdapter.Hash(bytes)
 }
@@ -6,6 +6,19 @@ import (
 	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/public"
 )
 
+const envelopeDelimiter = "#"
+const envelopeElementDelimiter = "|"
+
+// NewEnvelopeBuilder returns a new envelope builder
+func NewEnvelopeBuilder() EnvelopeBuilder {
+	return createEnvelopeBuilder()
+}
+
+// ToEnvelope converts the string representation of an envelope to an Envelope instance
+func ToEnvelope(str string) (Envelope, error) {
+	return createEnvelopeFromString(str)
+}
+
 // NewFactory returns a new encryption's privatekey factory
 func NewFactory(bitrate int) Factory {
 	builder := NewBuilder()
@@ -50,3 +63,30 @@ type PrivateKey interface {
 	Public() public.Key
 	Decrypt(cipher []byte) ([]byte, error)
 }
+
+// Recipient represents a public key an envelope can be sealed for, such as an rsa or ecies public key
+type Recipient interface {
+	Encrypt(msg []byte) ([]byte, error)
+}
+
+// Decrypter represents a private key that can open an envelope, such as an rsa or ecies private key
+type Decrypter interface {
+	Decrypt(cipher []byte) ([]byte, error)
+}
+
+// EnvelopeBuilder represents an envelope builder
+type EnvelopeBuilder interface {
+	Create() EnvelopeBuilder
+	WithRecipients(recipients []Recipient) EnvelopeBuilder
+	WithPayload(payload []byte) EnvelopeBuilder
+	Now() (Envelope, error)
+}
+
+// Envelope represents a payload sealed once for multiple recipients
+type Envelope interface {
+	Keys() [][]byte
+	Nonce() []byte
+	Payload() []byte
+	Open(pk Decrypter) ([]byte, error)
+	String() string
+}
@@ -3,6 +3,7 @@ package signature
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 
 	kyber "go.dedis.ch/kyber/v3"
 )
@@ -49,3 +50,30 @@ func fromStringToPoint(str string) (kyber.Point, error) {
 
 	return p, nil
 }
+
+func createHashToPoint(p kyber.Point) kyber.Point {
+	// the xof is seeded with the point, so the picked point is deterministic and nobody knows its discrete log:
+	xof := curve.XOF([]byte(p.String()))
+	return curve.Point().Pick(xof)
+}
+
+func isInPrimeOrderSubGroup(p kyber.Point) bool {
+	// clearing the cofactor and multiplying back by its inverse only returns the same point if it has no torsion:
+	cofactor := curve.Scalar().SetInt64(8)
+	cleared := curve.Point().Mul(cofactor, p)
+	if cleared.Equal(curve.Point().Null()) {
+		return false
+	}
+
+	inverse := curve.Scalar().Inv(cofactor)
+	return curve.Point().Mul(inverse, cleared).Equal(p)
+}
+
+func ringToString(ring []PublicKey) string {
+	ringStr := ""
+	for _, onePubKey := range ring {
+		ringStr = fmt.Sprintf("%s%s%s", ringStr, onePubKey.String(), elementDelimiter)
+	}
+
+	return ringStr
+}
@@ -0,0 +1,86 @@
+package signature
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type linkableRingSignature struct {
+	ring     []PublicKey
+	keyImage kyber.Point
+	s        []kyber.Scalar
+	c        kyber.Scalar
+}
+
+func createLinkableRingSignature(ring []PublicKey, keyImage kyber.Point, s []kyber.Scalar, c kyber.Scalar) LinkableRingSignature {
+	out := linkableRingSignature{
+		ring:     ring,
+		keyImage: keyImage,
+		s:        s,
+		c:        c,
+	}
+
+	return &out
+}
+
+// Ring returns ring pubKeys
+func (app *linkableRingSignature) Ring() []PublicKey {
+	return app.ring
+}
+
+// KeyImage returns the key image, the same for every signature of the same signer
+func (app *linkableRingSignature) KeyImage() kyber.Point {
+	return app.keyImage
+}
+
+// Verify verifies if the message has been signed by at least 1 shared signature
+func (app *linkableRingSignature) Verify(msg string) bool {
+	amount := len(app.ring)
+	if amount <= 0 || len(app.s) != amount {
+		return false
+	}
+
+	// a key image with torsion could be used to sign twice without being linked:
+	if !isInPrimeOrderSubGroup(app.keyImage) {
+		return false
+	}
+
+	// random base:
+	g := curve.Point().Base()
+
+	// first c:
+	c := app.c
+
+	// c = H(L || I || m || s[i] * G + c * P[i] || s[i] * Hp(P[i]) + c * I)
+	ringStr := ringToString(app.ring)
+	for i := 0; i < amount; i++ {
+		sg := curve.Point().Mul(app.s[i], g)
+		cp := curve.Point().Mul(c, app.ring[i].Point())
+		sh := curve.Point().Mul(app.s[i], createHashToPoint(app.ring[i].Point()))
+		ci := curve.Point().Mul(c, app.keyImage)
+		c = createLinkableHash(ringStr, app.keyImage, msg, curve.Point().Add(sg, cp), curve.Point().Add(sh, ci))
+	}
+
+	return app.c.Equal(c)
+}
+
+// String returns the string representation of the linkable ring signature
+func (app *linkableRingSignature) String() string {
+	sScalarStr := ""
+	for _, oneScalar := range app.s {
+		sScalarStr = fmt.Sprintf("%s%s%s", sScalarStr, oneScalar.String(), elementDelimiter)
+	}
+
+	str := fmt.Sprintf("%s%s%s%s%s%s%s", ringToString(app.ring), delimiter, app.keyImage.String(), delimiter, sScalarStr, delimiter, app.c.String())
+	return base64.StdEncoding.EncodeToString([]byte(str))
+}
+
+func createLinkableHash(ringStr string, keyImage kyber.Point, msg string, l kyber.Point, r kyber.Point) kyber.Scalar {
+	return createHash(fmt.Sprintf("%s%s%s%s%s%s%s%s%s", ringStr, delimiter, keyImage.String(), delimiter, msg, delimiter, l.String(), delimiter, r.String()))
+}
+
+func isLinked(first LinkableRingSignature, second LinkableRingSignature) bool {
+	return first.KeyImage().Equal(second.KeyImage())
+}
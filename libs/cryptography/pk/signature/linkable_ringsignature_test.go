@@ -0,0 +1,146 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func TestLinkableRingSignature_Success(t *testing.T) {
+	// variables:
+	msg := "this is a vote on a proposition"
+	hashAdapter := hash.NewAdapter()
+	linkableAdapter := NewLinkableRingSignatureAdapter()
+	pk := NewPrivateKeyFactory().Create()
+	secondPK := NewPrivateKeyFactory().Create()
+	thirdPK := NewPrivateKeyFactory().Create()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+		thirdPK.PublicKey(),
+	}
+
+	ringPubKeyHashes := []hash.Hash{}
+	for _, onePubKey := range ringPubKeys {
+		hsh, _ := hashAdapter.Hash([]byte(onePubKey.String()))
+		ringPubKeyHashes = append(ringPubKeyHashes, *hsh)
+	}
+
+	firstRing, err := pk.LinkableRingSign(msg, ringPubKeys)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned; %s", err.Error())
+		return
+	}
+
+	secondRing, err := secondPK.LinkableRingSign(msg, ringPubKeys)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned; %s", err.Error())
+		return
+	}
+
+	if !firstRing.Verify(msg) {
+		t.Errorf("the first ring was expected to be verified")
+		return
+	}
+
+	if firstRing.Verify("this is another message") {
+		t.Errorf("the first ring was expected to be invalid on another message")
+		return
+	}
+
+	verified, err := linkableAdapter.ToVerification(secondRing, msg, ringPubKeyHashes)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned; %s", err.Error())
+		return
+	}
+
+	if !verified {
+		t.Errorf("the second ring was expected to be deep verified")
+		return
+	}
+
+	if IsLinked(firstRing, secondRing) {
+		t.Errorf("the rings of different signers were not expected to be linked")
+		return
+	}
+
+	// the same signer, on another message in another ring, is linked:
+	otherRingPubKeys := []PublicKey{
+		thirdPK.PublicKey(),
+		pk.PublicKey(),
+	}
+
+	otherRing, err := pk.LinkableRingSign("this is a second vote", otherRingPubKeys)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned; %s", err.Error())
+		return
+	}
+
+	if !otherRing.Verify("this is a second vote") {
+		t.Errorf("the other ring was expected to be verified")
+		return
+	}
+
+	if !IsLinked(firstRing, otherRing) {
+		t.Errorf("the rings of the same signer were expected to be linked")
+		return
+	}
+
+	// encode to string, back and forth:
+	firstRingStr := firstRing.String()
+	newRing, err := linkableAdapter.ToSignature(firstRingStr)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if firstRingStr != newRing.String() {
+		t.Errorf("the rings were expected to be the same.  Expected: %s, Actual: %s", firstRingStr, newRing.String())
+		return
+	}
+
+	if !newRing.Verify(msg) || !IsLinked(firstRing, newRing) {
+		t.Errorf("the decoded ring was expected to be verified and linked")
+		return
+	}
+}
+
+func TestLinkableRingSignature_forgedKeyImage_isInvalid(t *testing.T) {
+	msg := "this is a vote on a proposition"
+	pk := NewPrivateKeyFactory().Create()
+	secondPK := NewPrivateKeyFactory().Create()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	}
+
+	ring, err := pk.LinkableRingSign(msg, ringPubKeys)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned; %s", err.Error())
+		return
+	}
+
+	sig := ring.(*linkableRingSignature)
+	forged := createLinkableRingSignature(sig.ring, secondPK.KeyImage(), sig.s, sig.c)
+	if forged.Verify(msg) {
+		t.Errorf("the ring with a forged key image was expected to be invalid")
+		return
+	}
+}
+
+func TestLinkableRingSignature_PubKeyIsNotInTheRing_returnsError(t *testing.T) {
+	msg := "this is a message to sign"
+	pk := NewPrivateKeyFactory().Create()
+	secondPK := NewPrivateKeyFactory().Create()
+	invalidPK := NewPrivateKeyFactory().Create()
+	ringPubKeys := []PublicKey{
+		pk.PublicKey(),
+		secondPK.PublicKey(),
+	}
+
+	_, err := invalidPK.LinkableRingSign(msg, ringPubKeys)
+	if err == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
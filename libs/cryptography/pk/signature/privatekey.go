@@ -81,6 +81,73 @@ func (app *privateKey) RingSign(msg string, ringPubKeys []PublicKey) (RingSignat
 	return out, nil
 }
 
+// KeyImage returns the key image of the PrivateKey, used to link its linkable ring signatures
+func (app *privateKey) KeyImage() kyber.Point {
+	// I = x * Hp(P)
+	return curve.Point().Mul(app.x, createHashToPoint(app.PublicKey().Point()))
+}
+
+// LinkableRingSign signs a linkable ring signature on the given message, in the given ring pubKey
+func (app *privateKey) LinkableRingSign(msg string, ringPubKeys []PublicKey) (LinkableRingSignature, error) {
+	// retrieve our signerIndex:
+	pubKey := app.PublicKey()
+	signerIndex := -1
+	for index, oneRingPubKey := range ringPubKeys {
+		if oneRingPubKey.Equals(pubKey) {
+			signerIndex = index
+			break
+		}
+	}
+
+	if signerIndex == -1 {
+		return nil, errors.New("the signer PublicKey is not in the ring")
+	}
+
+	// the nonces depend on the ring, so signing the same message in another ring never reuses them:
+	ringStr := ringToString(ringPubKeys)
+	seed := fmt.Sprintf("%s%s%s%s", linkableNoncePrefix, ringStr, delimiter, msg)
+
+	// generate alpha:
+	alpha := genK(app.x, seed)
+
+	// random base:
+	g := curve.Point().Base()
+
+	// key image:
+	keyImage := app.KeyImage()
+
+	// length:
+	r := len(ringPubKeys)
+
+	// initialize:
+	cs := make([]kyber.Scalar, r)
+	ss := make([]kyber.Scalar, r)
+	beginIndex := (signerIndex + 1) % r
+
+	// c = H(L || I || m || alpha * G || alpha * Hp(P))
+	signerHp := createHashToPoint(pubKey.Point())
+	cs[beginIndex] = createLinkableHash(ringStr, keyImage, msg, curve.Point().Mul(alpha, g), curve.Point().Mul(alpha, signerHp))
+
+	// loop:
+	for i := beginIndex; i != signerIndex; i = (i + 1) % r {
+		// si = random value
+		ss[i] = genK(app.x, fmt.Sprintf("%s%d", seed, i))
+
+		// ciPlus1ModR = H(L || I || m || si * G + ci * Pi || si * Hp(Pi) + ci * I)
+		sg := curve.Point().Mul(ss[i], g)
+		cp := curve.Point().Mul(cs[i], ringPubKeys[i].Point())
+		sh := curve.Point().Mul(ss[i], createHashToPoint(ringPubKeys[i].Point()))
+		ci := curve.Point().Mul(cs[i], keyImage)
+		cs[(i+1)%r] = createLinkableHash(ringStr, keyImage, msg, curve.Point().Add(sg, cp), curve.Point().Add(sh, ci))
+	}
+
+	// close the ring:
+	csx := curve.Scalar().Mul(cs[signerIndex], app.x)
+	ss[signerIndex] = curve.Scalar().Sub(alpha, csx)
+	out := createLinkableRingSignature(ringPubKeys, keyImage, ss, cs[0])
+	return out, nil
+}
+
 // Sign signs a message
 func (app *privateKey) Sign(msg string) (Signature, error) {
 	// generate k:
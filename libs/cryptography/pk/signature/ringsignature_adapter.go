@@ -87,7 +87,7 @@ func (app *ringSignatureAdapter) ToVerification(sig RingSignature, msg string, p
 	}
 
 	for index, oneRingPubKey := range ringPubKeys {
-		ringPubKeyHash, err := app.hashAdapter.FromString(oneRingPubKey.String())
+		ringPubKeyHash, err := app.hashAdapter.Hash([]byte(oneRingPubKey.String()))
 		if err != nil {
 			str := fmt.Sprintf("there was an error while hashing a ring PublicKey: %s", err.Error())
 			return false, errors.New(str)
@@ -21,7 +21,7 @@ func TestRingSignature_Success(t *testing.T) {
 
 	ringPubKeyHashes := []hash.Hash{}
 	for _, onePubKey := range ringPubKeys {
-		hsh, _ := hashAdapter.FromString(onePubKey.String())
+		hsh, _ := hashAdapter.Hash([]byte(onePubKey.String()))
 		ringPubKeyHashes = append(ringPubKeyHashes, *hsh)
 	}
 
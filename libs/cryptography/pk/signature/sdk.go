@@ -22,10 +22,35 @@ import (
  * 6. G is the random base
  * 7. k is a number chosen randomly.  A new one every time we sign must be generated
  * 8. x is the private key
+ *
+ * Linkable ring signatures (LSAG):
+ * I = x * Hp(P)
+ * c = H(L || I || m || s * G + c * P || s * Hp(P) + c * I)
+ * s = alpha – c * x
+ * where ...
+ * 1. Hp is a hash function that returns a point, nobody knows its discrete log
+ * 2. I is the key image, the same for every signature of x, whatever the ring
+ * 3. L is the ring of public keys
+ * 4. alpha is a number chosen randomly for each message and ring
+ *
+ * Threshold signatures (FROST):
+ * Y = f(0) * G, Yi = f(i) * G, where f is a secret polynomial of degree t-1
+ * rho(i) = H(i || m || B)
+ * R = sum(Di + rho(i) * Ei)
+ * c = H(R || Y || m)
+ * zi = di + ei * rho(i) + lambda(i) * f(i) * c
+ * z * G = R + c * Y, where z = sum(zi)
+ * where ...
+ * 1. t is the threshold, the amount of shares needed to sign
+ * 2. Di = di * G and Ei = ei * G are the commitments of the single-use nonces di and ei
+ * 3. B is the list of commitments of the signers
+ * 4. lambda(i) is the lagrange coefficient of i in the signers
  */
 
 const delimiter = "#"
 const elementDelimiter = "|"
+const linkableNoncePrefix = "linkable"
+const thresholdBindingPrefix = "threshold"
 
 var curve = edwards25519.NewBlakeSHA256Ed25519()
 
@@ -55,6 +80,27 @@ func NewRingSignatureAdapter() RingSignatureAdapter {
 	return createRingSignatureAdapter(hashAdapter)
 }
 
+// NewLinkableRingSignatureAdapter creates a linkable ring signature adapter
+func NewLinkableRingSignatureAdapter() LinkableRingSignatureAdapter {
+	hashAdapter := hash.NewAdapter()
+	return createLinkableRingSignatureAdapter(hashAdapter)
+}
+
+// IsLinked returns true if both linkable ring signatures have been signed by the same PrivateKey, false otherwise
+func IsLinked(first LinkableRingSignature, second LinkableRingSignature) bool {
+	return isLinked(first, second)
+}
+
+// NewThresholdKeyFactory creates a threshold key factory
+func NewThresholdKeyFactory() ThresholdKeyFactory {
+	return createThresholdKeyFactory()
+}
+
+// NewThresholdSignatureAdapter creates a threshold signature adapter
+func NewThresholdSignatureAdapter() ThresholdSignatureAdapter {
+	return createThresholdSignatureAdapter()
+}
+
 // PrivateKeyFactory represents a privateKey factory
 type PrivateKeyFactory interface {
 	Create() PrivateKey
@@ -70,6 +116,8 @@ type PrivateKey interface {
 	PublicKey() PublicKey
 	Sign(msg string) (Signature, error)
 	RingSign(msg string, ringPubKeys []PublicKey) (RingSignature, error)
+	LinkableRingSign(msg string, ringPubKeys []PublicKey) (LinkableRingSignature, error)
+	KeyImage() kyber.Point
 	String() string
 }
 
@@ -109,3 +157,72 @@ type RingSignature interface {
 	Verify(msg string) bool
 	String() string
 }
+
+// LinkableRingSignatureAdapter represents a linkable ring signature adapter
+type LinkableRingSignatureAdapter interface {
+	ToSignature(sig string) (LinkableRingSignature, error)
+	ToVerification(sig LinkableRingSignature, msg string, pubKeyHashes []hash.Hash) (bool, error)
+}
+
+// LinkableRingSignature represents a linkable ring signature (LSAG)
+type LinkableRingSignature interface {
+	Ring() []PublicKey
+	KeyImage() kyber.Point
+	Verify(msg string) bool
+	String() string
+}
+
+// ThresholdKeyFactory represents a threshold key factory, acting as the trusted dealer of the shares
+type ThresholdKeyFactory interface {
+	Create(threshold uint, amount uint) (ThresholdGroup, []ThresholdShare, error)
+}
+
+// ThresholdGroup represents the public side of a group of shares
+type ThresholdGroup interface {
+	PublicKey() PublicKey
+	Threshold() uint
+	VerificationShares() []PublicKey
+	VerifyPartial(msg string, commitments []ThresholdCommitment, partial ThresholdPartialSignature) bool
+	Aggregate(msg string, commitments []ThresholdCommitment, partials []ThresholdPartialSignature) (ThresholdSignature, error)
+}
+
+// ThresholdShare represents the share of a signer in a group
+type ThresholdShare interface {
+	Index() uint
+	PublicKey() PublicKey
+	GroupPublicKey() PublicKey
+	Commit() ThresholdNonce
+	Sign(msg string, nonce ThresholdNonce, commitments []ThresholdCommitment) (ThresholdPartialSignature, error)
+}
+
+// ThresholdNonce represents the secret single-use nonces of a signer
+type ThresholdNonce interface {
+	Commitment() ThresholdCommitment
+	IsUsed() bool
+}
+
+// ThresholdCommitment represents the public commitment of a ThresholdNonce
+type ThresholdCommitment interface {
+	Index() uint
+	Hiding() kyber.Point
+	Binding() kyber.Point
+}
+
+// ThresholdPartialSignature represents the partial signature of a signer
+type ThresholdPartialSignature interface {
+	Index() uint
+	Z() kyber.Scalar
+}
+
+// ThresholdSignatureAdapter represents a threshold signature adapter
+type ThresholdSignatureAdapter interface {
+	ToSignature(sig string) (ThresholdSignature, error)
+}
+
+// ThresholdSignature represents a signature made by a threshold of shares of a group
+type ThresholdSignature interface {
+	R() kyber.Point
+	Z() kyber.Scalar
+	Verify(msg string, pubKey PublicKey) bool
+	String() string
+}
@@ -0,0 +1,36 @@
+package signature
+
+import (
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdCommitment struct {
+	index   uint
+	hiding  kyber.Point
+	binding kyber.Point
+}
+
+func createThresholdCommitment(index uint, hiding kyber.Point, binding kyber.Point) ThresholdCommitment {
+	out := thresholdCommitment{
+		index:   index,
+		hiding:  hiding,
+		binding: binding,
+	}
+
+	return &out
+}
+
+// Index returns the index of the share that committed
+func (obj *thresholdCommitment) Index() uint {
+	return obj.index
+}
+
+// Hiding returns the hiding nonce commitment (D = d * G)
+func (obj *thresholdCommitment) Hiding() kyber.Point {
+	return obj.hiding
+}
+
+// Binding returns the binding nonce commitment (E = e * G)
+func (obj *thresholdCommitment) Binding() kyber.Point {
+	return obj.binding
+}
@@ -0,0 +1,126 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdGroup struct {
+	pubKey             PublicKey
+	threshold          uint
+	verificationShares []PublicKey
+}
+
+func createThresholdGroup(pubKey PublicKey, threshold uint, verificationShares []PublicKey) ThresholdGroup {
+	out := thresholdGroup{
+		pubKey:             pubKey,
+		threshold:          threshold,
+		verificationShares: verificationShares,
+	}
+
+	return &out
+}
+
+// PublicKey returns the aggregate PublicKey of the group
+func (obj *thresholdGroup) PublicKey() PublicKey {
+	return obj.pubKey
+}
+
+// Threshold returns the amount of shares needed to sign
+func (obj *thresholdGroup) Threshold() uint {
+	return obj.threshold
+}
+
+// VerificationShares returns the PublicKey of each share, in the order of their index
+func (obj *thresholdGroup) VerificationShares() []PublicKey {
+	return obj.verificationShares
+}
+
+// VerifyPartial returns true if the partial signature has been made by its share, false otherwise
+func (obj *thresholdGroup) VerifyPartial(msg string, commitments []ThresholdCommitment, partial ThresholdPartialSignature) bool {
+	session, err := obj.session(msg, commitments)
+	if err != nil {
+		return false
+	}
+
+	return obj.verifyPartial(session, partial)
+}
+
+// Aggregate executes the last round: it combines the partial signatures of the signers in a ThresholdSignature
+func (obj *thresholdGroup) Aggregate(msg string, commitments []ThresholdCommitment, partials []ThresholdPartialSignature) (ThresholdSignature, error) {
+	session, err := obj.session(msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(partials) != len(session.indexes) {
+		str := fmt.Sprintf("the amount of partial signatures (%d) was expected to match the amount of commitments (%d)", len(partials), len(session.indexes))
+		return nil, errors.New(str)
+	}
+
+	// z = sum(z)
+	z := curve.Scalar().Zero()
+	signed := map[uint]bool{}
+	for _, onePartial := range partials {
+		index := onePartial.Index()
+		if _, ok := signed[index]; ok {
+			str := fmt.Sprintf("the partial signature (index: %d) is duplicated", index)
+			return nil, errors.New(str)
+		}
+
+		if !obj.verifyPartial(session, onePartial) {
+			str := fmt.Sprintf("the partial signature (index: %d) is invalid", index)
+			return nil, errors.New(str)
+		}
+
+		signed[index] = true
+		z = curve.Scalar().Add(z, onePartial.Z())
+	}
+
+	return createThresholdSignature(session.r, z), nil
+}
+
+func (obj *thresholdGroup) session(msg string, commitments []ThresholdCommitment) (*thresholdSession, error) {
+	if uint(len(commitments)) < obj.threshold {
+		str := fmt.Sprintf("the amount of commitments (%d) cannot be smaller than the threshold (%d)", len(commitments), obj.threshold)
+		return nil, errors.New(str)
+	}
+
+	for _, oneCommitment := range commitments {
+		if oneCommitment.Index() > uint(len(obj.verificationShares)) {
+			str := fmt.Sprintf("the commitment (index: %d) is not part of the group (amount: %d)", oneCommitment.Index(), len(obj.verificationShares))
+			return nil, errors.New(str)
+		}
+	}
+
+	return createThresholdSession(obj.pubKey, msg, commitments)
+}
+
+// z * G = D + rho * E + lambda * c * Y
+func (obj *thresholdGroup) verifyPartial(session *thresholdSession, partial ThresholdPartialSignature) bool {
+	index := partial.Index()
+	commitment, ok := session.commitments[index]
+	if !ok {
+		return false
+	}
+
+	g := curve.Point().Base()
+	y := obj.verificationShares[index-1].Point()
+	lambdaC := curve.Scalar().Mul(session.lagrange(index), session.challenge)
+	expected := curve.Point().Add(
+		curve.Point().Add(commitment.Hiding(), curve.Point().Mul(session.bindingFactors[index], commitment.Binding())),
+		curve.Point().Mul(lambdaC, y),
+	)
+
+	return curve.Point().Mul(partial.Z(), g).Equal(expected)
+}
+
+func verifyThresholdSignature(r kyber.Point, z kyber.Scalar, msg string, pubKey PublicKey) bool {
+	// z * G = R + c * Y
+	g := curve.Point().Base()
+	c := createThresholdChallenge(r, pubKey, msg)
+	expected := curve.Point().Add(r, curve.Point().Mul(c, pubKey.Point()))
+	return curve.Point().Mul(z, g).Equal(expected)
+}
@@ -0,0 +1,53 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdKeyFactory struct {
+}
+
+func createThresholdKeyFactory() ThresholdKeyFactory {
+	out := thresholdKeyFactory{}
+	return &out
+}
+
+// Create splits a new group PrivateKey in shares, any threshold of them can sign on behalf of the group
+func (app *thresholdKeyFactory) Create(threshold uint, amount uint) (ThresholdGroup, []ThresholdShare, error) {
+	if threshold <= 0 {
+		return nil, nil, errors.New("the threshold must be greater than zero (0)")
+	}
+
+	if amount < threshold {
+		str := fmt.Sprintf("the amount of shares (%d) cannot be smaller than the threshold (%d)", amount, threshold)
+		return nil, nil, errors.New(str)
+	}
+
+	// f(z) = a0 + a1 * z + ... + a(t-1) * z^(t-1), where a0 is the group private key:
+	coefficients := []kyber.Scalar{}
+	for i := uint(0); i < threshold; i++ {
+		coefficients = append(coefficients, curve.Scalar().Pick(curve.RandomStream()))
+	}
+
+	g := curve.Point().Base()
+	pubKey := createPublicKey(curve.Point().Mul(coefficients[0], g))
+	shares := []ThresholdShare{}
+	verificationShares := []PublicKey{}
+	for index := uint(1); index <= amount; index++ {
+		// s = f(index), evaluated using horner's method:
+		z := curve.Scalar().SetInt64(int64(index))
+		s := curve.Scalar().Zero()
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			s = curve.Scalar().Add(curve.Scalar().Mul(s, z), coefficients[i])
+		}
+
+		shares = append(shares, createThresholdShare(index, s, pubKey))
+		verificationShares = append(verificationShares, createPublicKey(curve.Point().Mul(s, g)))
+	}
+
+	group := createThresholdGroup(pubKey, threshold, verificationShares)
+	return group, shares, nil
+}
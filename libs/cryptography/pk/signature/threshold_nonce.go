@@ -0,0 +1,49 @@
+package signature
+
+import (
+	"errors"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdNonce struct {
+	index uint
+	d     kyber.Scalar
+	e     kyber.Scalar
+}
+
+func createThresholdNonce(index uint, d kyber.Scalar, e kyber.Scalar) *thresholdNonce {
+	out := thresholdNonce{
+		index: index,
+		d:     d,
+		e:     e,
+	}
+
+	return &out
+}
+
+// Commitment returns the public commitment of the nonce
+func (obj *thresholdNonce) Commitment() ThresholdCommitment {
+	if obj.d == nil || obj.e == nil {
+		return nil
+	}
+
+	g := curve.Point().Base()
+	return createThresholdCommitment(obj.index, curve.Point().Mul(obj.d, g), curve.Point().Mul(obj.e, g))
+}
+
+// IsUsed returns true if the nonce has already been used to sign, false otherwise
+func (obj *thresholdNonce) IsUsed() bool {
+	return obj.d == nil || obj.e == nil
+}
+
+func (obj *thresholdNonce) consume() (kyber.Scalar, kyber.Scalar, error) {
+	if obj.IsUsed() {
+		return nil, nil, errors.New("the nonce has already been used to sign")
+	}
+
+	d, e := obj.d, obj.e
+	obj.d = nil
+	obj.e = nil
+	return d, e, nil
+}
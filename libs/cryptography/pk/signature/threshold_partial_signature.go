@@ -0,0 +1,29 @@
+package signature
+
+import (
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdPartialSignature struct {
+	index uint
+	z     kyber.Scalar
+}
+
+func createThresholdPartialSignature(index uint, z kyber.Scalar) ThresholdPartialSignature {
+	out := thresholdPartialSignature{
+		index: index,
+		z:     z,
+	}
+
+	return &out
+}
+
+// Index returns the index of the share that signed
+func (obj *thresholdPartialSignature) Index() uint {
+	return obj.index
+}
+
+// Z returns the partial signature scalar
+func (obj *thresholdPartialSignature) Z() kyber.Scalar {
+	return obj.z
+}
@@ -0,0 +1,96 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdSession struct {
+	indexes        []uint
+	commitments    map[uint]ThresholdCommitment
+	bindingFactors map[uint]kyber.Scalar
+	r              kyber.Point
+	challenge      kyber.Scalar
+}
+
+func createThresholdSession(pubKey PublicKey, msg string, commitments []ThresholdCommitment) (*thresholdSession, error) {
+	if len(commitments) <= 0 {
+		return nil, errors.New("at least one commitment is mandatory in order to sign")
+	}
+
+	indexes := []uint{}
+	commitmentsByIndex := map[uint]ThresholdCommitment{}
+	for _, oneCommitment := range commitments {
+		index := oneCommitment.Index()
+		if index <= 0 {
+			return nil, errors.New("the commitment index must be greater than zero (0)")
+		}
+
+		if _, ok := commitmentsByIndex[index]; ok {
+			str := fmt.Sprintf("the commitment (index: %d) is duplicated", index)
+			return nil, errors.New(str)
+		}
+
+		indexes = append(indexes, index)
+		commitmentsByIndex[index] = oneCommitment
+	}
+
+	sort.Slice(indexes, func(i int, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	// B = the encoded list of commitments, in the order of their index:
+	encoded := ""
+	for _, oneIndex := range indexes {
+		commitment := commitmentsByIndex[oneIndex]
+		encoded = fmt.Sprintf("%s%d%s%s%s%s%s", encoded, oneIndex, elementDelimiter, commitment.Hiding().String(), elementDelimiter, commitment.Binding().String(), delimiter)
+	}
+
+	// rho = H(i || m || B), R = sum(D + rho * E)
+	r := curve.Point().Null()
+	bindingFactors := map[uint]kyber.Scalar{}
+	for _, oneIndex := range indexes {
+		commitment := commitmentsByIndex[oneIndex]
+		rho := createHash(fmt.Sprintf("%s%s%d%s%s%s%s", thresholdBindingPrefix, delimiter, oneIndex, delimiter, msg, delimiter, encoded))
+		bindingFactors[oneIndex] = rho
+
+		rhoE := curve.Point().Mul(rho, commitment.Binding())
+		r = curve.Point().Add(r, curve.Point().Add(commitment.Hiding(), rhoE))
+	}
+
+	out := thresholdSession{
+		indexes:        indexes,
+		commitments:    commitmentsByIndex,
+		bindingFactors: bindingFactors,
+		r:              r,
+		challenge:      createThresholdChallenge(r, pubKey, msg),
+	}
+
+	return &out, nil
+}
+
+// lambda = product(j / (j - i)), for every other signer j
+func (obj *thresholdSession) lagrange(index uint) kyber.Scalar {
+	numerator := curve.Scalar().One()
+	denominator := curve.Scalar().One()
+	for _, oneIndex := range obj.indexes {
+		if oneIndex == index {
+			continue
+		}
+
+		j := curve.Scalar().SetInt64(int64(oneIndex))
+		i := curve.Scalar().SetInt64(int64(index))
+		numerator = curve.Scalar().Mul(numerator, j)
+		denominator = curve.Scalar().Mul(denominator, curve.Scalar().Sub(j, i))
+	}
+
+	return curve.Scalar().Div(numerator, denominator)
+}
+
+// c = H(R || Y || m)
+func createThresholdChallenge(r kyber.Point, pubKey PublicKey, msg string) kyber.Scalar {
+	return createHash(fmt.Sprintf("%s%s%s%s%s", r.String(), delimiter, pubKey.String(), delimiter, msg))
+}
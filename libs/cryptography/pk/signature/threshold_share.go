@@ -0,0 +1,82 @@
+package signature
+
+import (
+	"errors"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdShare struct {
+	index  uint
+	s      kyber.Scalar
+	pubKey PublicKey
+}
+
+func createThresholdShare(index uint, s kyber.Scalar, pubKey PublicKey) ThresholdShare {
+	out := thresholdShare{
+		index:  index,
+		s:      s,
+		pubKey: pubKey,
+	}
+
+	return &out
+}
+
+// Index returns the index of the share in its group
+func (app *thresholdShare) Index() uint {
+	return app.index
+}
+
+// PublicKey returns the verification share of the share
+func (app *thresholdShare) PublicKey() PublicKey {
+	g := curve.Point().Base()
+	return createPublicKey(curve.Point().Mul(app.s, g))
+}
+
+// GroupPublicKey returns the aggregate PublicKey of the group
+func (app *thresholdShare) GroupPublicKey() PublicKey {
+	return app.pubKey
+}
+
+// Commit executes the first round: it creates the single-use nonces, whose commitment is sent to the other signers
+func (app *thresholdShare) Commit() ThresholdNonce {
+	d := curve.Scalar().Pick(curve.RandomStream())
+	e := curve.Scalar().Pick(curve.RandomStream())
+	return createThresholdNonce(app.index, d, e)
+}
+
+// Sign executes the second round: it creates the partial signature of the message, using the commitments of all the signers
+func (app *thresholdShare) Sign(msg string, nonce ThresholdNonce, commitments []ThresholdCommitment) (ThresholdPartialSignature, error) {
+	ins, ok := nonce.(*thresholdNonce)
+	if !ok || ins.index != app.index {
+		return nil, errors.New("the nonce was not created by this share")
+	}
+
+	// the nonces can only be used once, otherwise the share could be extracted:
+	if ins.IsUsed() {
+		return nil, errors.New("the nonce has already been used to sign")
+	}
+
+	session, err := createThresholdSession(app.pubKey, msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+
+	// our commitment must be part of the session:
+	commitment, ok := session.commitments[app.index]
+	if !ok || !commitment.Hiding().Equal(ins.Commitment().Hiding()) || !commitment.Binding().Equal(ins.Commitment().Binding()) {
+		return nil, errors.New("the commitment of the nonce is not part of the signing commitments")
+	}
+
+	d, e, err := ins.consume()
+	if err != nil {
+		return nil, err
+	}
+
+	// z = d + e * rho + lambda * s * c
+	lambda := session.lagrange(app.index)
+	ed := curve.Scalar().Add(d, curve.Scalar().Mul(e, session.bindingFactors[app.index]))
+	lsc := curve.Scalar().Mul(curve.Scalar().Mul(lambda, app.s), session.challenge)
+	z := curve.Scalar().Add(ed, lsc)
+	return createThresholdPartialSignature(app.index, z), nil
+}
@@ -0,0 +1,43 @@
+package signature
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	kyber "go.dedis.ch/kyber/v3"
+)
+
+type thresholdSignature struct {
+	r kyber.Point
+	z kyber.Scalar
+}
+
+func createThresholdSignature(r kyber.Point, z kyber.Scalar) ThresholdSignature {
+	out := thresholdSignature{
+		r: r,
+		z: z,
+	}
+
+	return &out
+}
+
+// R returns the group commitment
+func (obj *thresholdSignature) R() kyber.Point {
+	return obj.r
+}
+
+// Z returns the aggregated scalar
+func (obj *thresholdSignature) Z() kyber.Scalar {
+	return obj.z
+}
+
+// Verify verifies if the message has been signed by the group of the given aggregate PublicKey
+func (obj *thresholdSignature) Verify(msg string, pubKey PublicKey) bool {
+	return verifyThresholdSignature(obj.r, obj.z, msg, pubKey)
+}
+
+// String returns the string representation of the threshold signature
+func (obj *thresholdSignature) String() string {
+	str := fmt.Sprintf("%s%s%s", obj.r.String(), delimiter, obj.z.String())
+	return base64.StdEncoding.EncodeToString([]byte(str))
+}
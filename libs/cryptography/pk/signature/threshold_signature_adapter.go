@@ -0,0 +1,42 @@
+package signature
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type thresholdSignatureAdapter struct {
+}
+
+func createThresholdSignatureAdapter() ThresholdSignatureAdapter {
+	out := thresholdSignatureAdapter{}
+	return &out
+}
+
+// ToSignature converts a string to a ThresholdSignature
+func (app *thresholdSignatureAdapter) ToSignature(sig string) (ThresholdSignature, error) {
+	decoded, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	splitted := strings.Split(string(decoded), delimiter)
+	if len(splitted) != 2 {
+		str := fmt.Sprintf("the threshold signature string was expected to have %d sections, %d found", 2, len(splitted))
+		return nil, errors.New(str)
+	}
+
+	r, err := fromStringToPoint(splitted[0])
+	if err != nil {
+		return nil, err
+	}
+
+	z, err := fromStringToScalar(splitted[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return createThresholdSignature(r, z), nil
+}
@@ -0,0 +1,159 @@
+package signature
+
+import (
+	"testing"
+)
+
+func signWithThresholdForTests(t *testing.T, msg string, shares []ThresholdShare) ([]ThresholdCommitment, []ThresholdPartialSignature) {
+	// first round:
+	nonces := []ThresholdNonce{}
+	commitments := []ThresholdCommitment{}
+	for _, oneShare := range shares {
+		nonce := oneShare.Commit()
+		nonces = append(nonces, nonce)
+		commitments = append(commitments, nonce.Commitment())
+	}
+
+	// second round:
+	partials := []ThresholdPartialSignature{}
+	for index, oneShare := range shares {
+		partial, err := oneShare.Sign(msg, nonces[index], commitments)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return nil, nil
+		}
+
+		partials = append(partials, partial)
+	}
+
+	return commitments, partials
+}
+
+func TestThresholdSignature_threeOfFive_Success(t *testing.T) {
+	msg := "this is a proposition signed by a company"
+	group, shares, err := NewThresholdKeyFactory().Create(3, 5)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	subsets := [][]ThresholdShare{
+		{shares[0], shares[1], shares[2]},
+		{shares[4], shares[1], shares[3]},
+		{shares[0], shares[1], shares[2], shares[3], shares[4]},
+	}
+
+	for _, oneSubset := range subsets {
+		commitments, partials := signWithThresholdForTests(t, msg, oneSubset)
+		if partials == nil {
+			return
+		}
+
+		sig, err := group.Aggregate(msg, commitments, partials)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !sig.Verify(msg, group.PublicKey()) {
+			t.Errorf("the threshold signature was expected to be valid")
+			return
+		}
+
+		if sig.Verify("this is another message", group.PublicKey()) {
+			t.Errorf("the threshold signature was expected to be invalid on another message")
+			return
+		}
+
+		if sig.Verify(msg, NewPrivateKeyFactory().Create().PublicKey()) {
+			t.Errorf("the threshold signature was expected to be invalid using another PublicKey")
+			return
+		}
+
+		// encode to string, back and forth:
+		decoded, err := NewThresholdSignatureAdapter().ToSignature(sig.String())
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !decoded.Verify(msg, oneSubset[0].GroupPublicKey()) {
+			t.Errorf("the decoded threshold signature was expected to be valid")
+			return
+		}
+	}
+}
+
+func TestThresholdSignature_belowThreshold_returnsError(t *testing.T) {
+	msg := "this is a proposition signed by a company"
+	group, shares, err := NewThresholdKeyFactory().Create(3, 5)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commitments, partials := signWithThresholdForTests(t, msg, shares[:2])
+	if partials == nil {
+		return
+	}
+
+	_, err = group.Aggregate(msg, commitments, partials)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestThresholdSignature_invalidPartial_returnsError(t *testing.T) {
+	msg := "this is a proposition signed by a company"
+	group, shares, err := NewThresholdKeyFactory().Create(2, 3)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commitments, partials := signWithThresholdForTests(t, msg, shares[:2])
+	if partials == nil {
+		return
+	}
+
+	forged := createThresholdPartialSignature(partials[1].Index(), curve.Scalar().Add(partials[1].Z(), curve.Scalar().One()))
+	if group.VerifyPartial(msg, commitments, forged) {
+		t.Errorf("the forged partial signature was expected to be invalid")
+		return
+	}
+
+	_, err = group.Aggregate(msg, commitments, []ThresholdPartialSignature{partials[0], forged})
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestThresholdSignature_reusedNonce_returnsError(t *testing.T) {
+	msg := "this is a proposition signed by a company"
+	_, shares, err := NewThresholdKeyFactory().Create(2, 3)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	first := shares[0].Commit()
+	second := shares[1].Commit()
+	commitments := []ThresholdCommitment{
+		first.Commitment(),
+		second.Commitment(),
+	}
+
+	_, err = shares[0].Sign(msg, first, commitments)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = shares[0].Sign("this is another message", first, commitments)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -11,6 +11,9 @@ import (
 type builder struct {
 	hashAdapter hash.Adapter
 	identifier  int
+	priority    int
+	isAsync     bool
+	isAborting  bool
 	onEnter     EventFn
 	onExit      EventFn
 }
@@ -21,6 +24,9 @@ func createBuilder(
 	out := builder{
 		hashAdapter: hashAdapter,
 		identifier:  -1,
+		priority:    0,
+		isAsync:     false,
+		isAborting:  false,
 		onEnter:     nil,
 		onExit:      nil,
 	}
@@ -39,6 +45,24 @@ func (app *builder) WithIdentifier(identifier int) Builder {
 	return app
 }
 
+// WithPriority adds a priority to the builder
+func (app *builder) WithPriority(priority int) Builder {
+	app.priority = priority
+	return app
+}
+
+// IsAsync flags the builder as async
+func (app *builder) IsAsync() Builder {
+	app.isAsync = true
+	return app
+}
+
+// IsAborting flags the builder as aborting: a failure of its funcs stops the trigger and is returned by it
+func (app *builder) IsAborting() Builder {
+	app.isAborting = true
+	return app
+}
+
 // OnEnter adds an onEnter func to the builder
 func (app *builder) OnEnter(onEnter EventFn) Builder {
 	app.onEnter = onEnter
@@ -57,8 +81,15 @@ func (app *builder) Now() (Event, error) {
 		return nil, errors.New("the identifier is mandatory in order to build an Event instance")
 	}
 
+	if app.isAsync && app.isAborting {
+		return nil, errors.New("an async Event cannot abort its trigger")
+	}
+
 	data := [][]byte{
 		[]byte(strconv.Itoa(app.identifier)),
+		[]byte(strconv.Itoa(app.priority)),
+		[]byte(strconv.FormatBool(app.isAsync)),
+		[]byte(strconv.FormatBool(app.isAborting)),
 	}
 
 	if app.onEnter != nil {
@@ -79,15 +110,15 @@ func (app *builder) Now() (Event, error) {
 	}
 
 	if app.onEnter != nil && app.onExit != nil {
-		return createEventWithOnEnterAndOnExit(*hsh, app.identifier, app.onEnter, app.onExit), nil
+		return createEventWithOnEnterAndOnExit(*hsh, app.identifier, app.priority, app.isAsync, app.isAborting, app.onEnter, app.onExit), nil
 	}
 
 	if app.onEnter != nil {
-		return createEventWithOnEnter(*hsh, app.identifier, app.onEnter), nil
+		return createEventWithOnEnter(*hsh, app.identifier, app.priority, app.isAsync, app.isAborting, app.onEnter), nil
 	}
 
 	if app.onExit != nil {
-		return createEventWithOnExit(*hsh, app.identifier, app.onExit), nil
+		return createEventWithOnExit(*hsh, app.identifier, app.priority, app.isAsync, app.isAborting, app.onExit), nil
 	}
 
 	return nil, errors.New("the Event is invalid")
@@ -0,0 +1,44 @@
+package events
+
+type deadLetter struct {
+	evt    Event
+	stage  Stage
+	data   interface{}
+	reason error
+}
+
+func createDeadLetter(
+	evt Event,
+	stage Stage,
+	data interface{},
+	reason error,
+) DeadLetter {
+	out := deadLetter{
+		evt:    evt,
+		stage:  stage,
+		data:   data,
+		reason: reason,
+	}
+
+	return &out
+}
+
+// Event returns the event
+func (obj *deadLetter) Event() Event {
+	return obj.evt
+}
+
+// Stage returns the stage
+func (obj *deadLetter) Stage() Stage {
+	return obj.stage
+}
+
+// Data returns the data
+func (obj *deadLetter) Data() interface{} {
+	return obj.data
+}
+
+// Reason returns the reason of the failure
+func (obj *deadLetter) Reason() error {
+	return obj.reason
+}
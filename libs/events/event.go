@@ -5,6 +5,9 @@ import "github.com/deepvalue-network/software/libs/hash"
 type event struct {
 	hash       hash.Hash
 	identifier int
+	priority   int
+	isAsync    bool
+	isAborting bool
 	onEnter    EventFn
 	onExit     EventFn
 }
@@ -12,37 +15,52 @@ type event struct {
 func createEventWithOnEnter(
 	hash hash.Hash,
 	identifier int,
+	priority int,
+	isAsync bool,
+	isAborting bool,
 	onEnter EventFn,
 ) Event {
-	return createEventInternally(hash, identifier, onEnter, nil)
+	return createEventInternally(hash, identifier, priority, isAsync, isAborting, onEnter, nil)
 }
 
 func createEventWithOnExit(
 	hash hash.Hash,
 	identifier int,
+	priority int,
+	isAsync bool,
+	isAborting bool,
 	onExit EventFn,
 ) Event {
-	return createEventInternally(hash, identifier, nil, onExit)
+	return createEventInternally(hash, identifier, priority, isAsync, isAborting, nil, onExit)
 }
 
 func createEventWithOnEnterAndOnExit(
 	hash hash.Hash,
 	identifier int,
+	priority int,
+	isAsync bool,
+	isAborting bool,
 	onEnter EventFn,
 	onExit EventFn,
 ) Event {
-	return createEventInternally(hash, identifier, onEnter, onExit)
+	return createEventInternally(hash, identifier, priority, isAsync, isAborting, onEnter, onExit)
 }
 
 func createEventInternally(
 	hash hash.Hash,
 	identifier int,
+	priority int,
+	isAsync bool,
+	isAborting bool,
 	onEnter EventFn,
 	onExit EventFn,
 ) Event {
 	out := event{
 		hash:       hash,
 		identifier: identifier,
+		priority:   priority,
+		isAsync:    isAsync,
+		isAborting: isAborting,
 		onEnter:    onEnter,
 		onExit:     onExit,
 	}
@@ -60,6 +78,21 @@ func (obj *event) Identifier() int {
 	return obj.identifier
 }
 
+// Priority returns the priority
+func (obj *event) Priority() int {
+	return obj.priority
+}
+
+// IsAsync returns true if the event is delivered asynchronously, false otherwise
+func (obj *event) IsAsync() bool {
+	return obj.isAsync
+}
+
+// IsAborting returns true if a failure of the event funcs stops the trigger, false otherwise
+func (obj *event) IsAborting() bool {
+	return obj.isAborting
+}
+
 // HasOnEnter returns true if there is an onEnter func, false otherwise
 func (obj *event) HasOnEnter() bool {
 	return obj.onEnter != nil
@@ -3,17 +3,30 @@ package events
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 )
 
 type manager struct {
-	evts []map[string]Event
+	queueSize     uint
+	subscriptions map[int][]*subscription
+	index         uint
+	deadLetters   []DeadLetter
+	mutex         sync.RWMutex
+	pendingCond   *sync.Cond
+	pending       uint
 }
 
 func createManager(
-	evts []map[string]Event,
+	queueSize uint,
 ) Manager {
 	out := manager{
-		evts: evts,
+		queueSize:     queueSize,
+		subscriptions: map[int][]*subscription{},
+		index:         0,
+		deadLetters:   []DeadLetter{},
+		pendingCond:   sync.NewCond(new(sync.Mutex)),
+		pending:       0,
 	}
 
 	return &out
@@ -21,27 +34,8 @@ func createManager(
 
 // Add adds an event to the manager
 func (app *manager) Add(evt Event) error {
-	length := len(app.evts)
-	id := evt.Identifier()
-	keyname := evt.Hash().String()
-
-	if id < length {
-		if _, ok := app.evts[id][keyname]; ok {
-			str := fmt.Sprintf("the event (identifier: %d, hash: %s) already exists", id, keyname)
-			return errors.New(str)
-		}
-
-		app.evts[id][keyname] = evt
-		return nil
-	}
-
-	diff := (id - length) + 1
-	for i := 0; i < diff; i++ {
-		app.evts = append(app.evts, map[string]Event{})
-	}
-
-	app.evts[id][keyname] = evt
-	return nil
+	_, err := app.Subscribe(evt)
+	return err
 }
 
 // AddList add events to the manager
@@ -56,21 +50,52 @@ func (app *manager) AddList(evts []Event) error {
 	return nil
 }
 
+// Subscribe adds an event to the manager and returns its subscription
+func (app *manager) Subscribe(evt Event) (Subscription, error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	id := evt.Identifier()
+	keyname := evt.Hash().String()
+	for _, oneSubscription := range app.subscriptions[id] {
+		if oneSubscription.evt.Hash().Compare(evt.Hash()) {
+			str := fmt.Sprintf("the event (identifier: %d, hash: %s) already exists", id, keyname)
+			return nil, errors.New(str)
+		}
+	}
+
+	out := createSubscription(app, evt, app.index, app.queueSize)
+	app.index++
+
+	list := append(app.subscriptions[id], out)
+	sort.SliceStable(list, func(i int, j int) bool {
+		if list[i].evt.Priority() != list[j].evt.Priority() {
+			return list[i].evt.Priority() > list[j].evt.Priority()
+		}
+
+		return list[i].index < list[j].index
+	})
+
+	app.subscriptions[id] = list
+	return out, nil
+}
+
 // Trigger triggers an event
 func (app *manager) Trigger(identifier int, data interface{}, triggerFn TriggerFn) error {
-	length := len(app.evts)
-	if identifier >= length {
-		return triggerFn()
-	}
+	app.mutex.RLock()
+	list := make([]*subscription, len(app.subscriptions[identifier]))
+	copy(list, app.subscriptions[identifier])
+	app.mutex.RUnlock()
 
 	// executes onEnter:
-	for _, oneEvt := range app.evts[identifier] {
-		if oneEvt.HasOnEnter() {
-			enterFn := oneEvt.OnEnter()
-			err := enterFn(data, oneEvt)
-			if err != nil {
-				return err
-			}
+	for _, oneSubscription := range list {
+		if !oneSubscription.evt.HasOnEnter() {
+			continue
+		}
+
+		err := oneSubscription.deliver(StageEnter, data)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -81,15 +106,86 @@ func (app *manager) Trigger(identifier int, data interface{}, triggerFn TriggerF
 	}
 
 	// executes the onExit:
-	for _, oneEvt := range app.evts[identifier] {
-		if oneEvt.HasOnExit() {
-			exitFn := oneEvt.OnExit()
-			err := exitFn(data, oneEvt)
-			if err != nil {
-				return err
-			}
+	for _, oneSubscription := range list {
+		if !oneSubscription.evt.HasOnExit() {
+			continue
+		}
+
+		err := oneSubscription.deliver(StageExit, data)
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
+
+// Flush blocks until every pending async delivery is executed
+func (app *manager) Flush() {
+	app.pendingCond.L.Lock()
+	defer app.pendingCond.L.Unlock()
+	for app.pending > 0 {
+		app.pendingCond.Wait()
+	}
+}
+
+// DeadLetters returns the latest failed deliveries, in their failure order
+func (app *manager) DeadLetters() []DeadLetter {
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+
+	out := make([]DeadLetter, len(app.deadLetters))
+	copy(out, app.deadLetters)
+	return out
+}
+
+// ClearDeadLetters removes the failed deliveries
+func (app *manager) ClearDeadLetters() {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.deadLetters = []DeadLetter{}
+}
+
+func (app *manager) remove(sub *subscription) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	id := sub.evt.Identifier()
+	list := app.subscriptions[id]
+	for index, oneSubscription := range list {
+		if oneSubscription != sub {
+			continue
+		}
+
+		app.subscriptions[id] = append(list[:index:index], list[index+1:]...)
+		return nil
+	}
+
+	str := fmt.Sprintf("the event (identifier: %d, hash: %s) is not subscribed", id, sub.evt.Hash().String())
+	return errors.New(str)
+}
+
+func (app *manager) fail(evt Event, stage Stage, data interface{}, reason error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	if len(app.deadLetters) >= MaxDeadLetters {
+		app.deadLetters = app.deadLetters[len(app.deadLetters)-MaxDeadLetters+1:]
+	}
+
+	app.deadLetters = append(app.deadLetters, createDeadLetter(evt, stage, data, reason))
+}
+
+func (app *manager) addPending() {
+	app.pendingCond.L.Lock()
+	defer app.pendingCond.L.Unlock()
+	app.pending++
+}
+
+func (app *manager) donePending() {
+	app.pendingCond.L.Lock()
+	defer app.pendingCond.L.Unlock()
+	app.pending--
+	if app.pending == 0 {
+		app.pendingCond.Broadcast()
+	}
+}
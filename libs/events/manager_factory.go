@@ -10,6 +10,10 @@ func createManagerFactory() ManagerFactory {
 
 // Create creates a new manager instance
 func (app *managerFactory) Create() Manager {
-	evts := []map[string]Event{}
-	return createManager(evts)
+	return app.CreateWithQueueSize(DefaultQueueSize)
+}
+
+// CreateWithQueueSize creates a new manager instance with the given queue size for its async events
+func (app *managerFactory) CreateWithQueueSize(queueSize uint) Manager {
+	return createManager(queueSize)
 }
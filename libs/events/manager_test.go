@@ -0,0 +1,265 @@
+package events
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestManager_priorities_Success(t *testing.T) {
+	order := []string{}
+	appendFn := func(name string) EventFn {
+		return func(data interface{}, event Event) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	builder := NewBuilder()
+	low, _ := builder.Create().WithIdentifier(0).WithPriority(-1).OnEnter(appendFn("low")).Now()
+	first, _ := builder.Create().WithIdentifier(0).OnEnter(appendFn("first")).OnExit(appendFn("exit")).Now()
+	second, _ := builder.Create().WithIdentifier(0).OnEnter(appendFn("second")).Now()
+	high, _ := builder.Create().WithIdentifier(0).WithPriority(10).OnEnter(appendFn("high")).Now()
+
+	manager := NewManagerFactory().Create()
+	err := manager.AddList([]Event{
+		low,
+		first,
+		second,
+		high,
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = manager.Trigger(0, nil, func() error {
+		order = append(order, "trigger")
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	expected := []string{"high", "first", "second", "low", "trigger", "exit"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("the execution order was expected to be %v, %v returned", expected, order)
+		return
+	}
+
+	// the same event cannot be added twice:
+	err = manager.Add(high)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestManager_failure_isDeadLetter_Success(t *testing.T) {
+	executed := false
+	failing, _ := NewBuilder().Create().WithIdentifier(2).WithPriority(1).OnEnter(func(data interface{}, event Event) error {
+		return errors.New("failing")
+	}).Now()
+
+	next, _ := NewBuilder().Create().WithIdentifier(2).OnEnter(func(data interface{}, event Event) error {
+		executed = true
+		return nil
+	}).Now()
+
+	manager := NewManagerFactory().Create()
+	manager.AddList([]Event{failing, next})
+
+	err := manager.Trigger(2, "data", func() error {
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !executed {
+		t.Errorf("the event after the failing one was expected to be executed")
+		return
+	}
+
+	deadLetters := manager.DeadLetters()
+	if len(deadLetters) != 1 {
+		t.Errorf("%d dead letters were expected, %d returned", 1, len(deadLetters))
+		return
+	}
+
+	if deadLetters[0].Stage() != StageEnter || deadLetters[0].Data() != "data" || !deadLetters[0].Event().Hash().Compare(failing.Hash()) {
+		t.Errorf("the dead letter is invalid")
+		return
+	}
+
+	manager.ClearDeadLetters()
+	if len(manager.DeadLetters()) != 0 {
+		t.Errorf("the dead letters were expected to be cleared")
+		return
+	}
+}
+
+func TestManager_async_Success(t *testing.T) {
+	mutex := sync.Mutex{}
+	received := []int{}
+	async, _ := NewBuilder().Create().WithIdentifier(1).IsAsync().OnExit(func(data interface{}, event Event) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		received = append(received, data.(int))
+		return nil
+	}).Now()
+
+	manager := NewManagerFactory().Create()
+	subscription, err := manager.Subscribe(async)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	expected := []int{}
+	for i := 0; i < DefaultQueueSize; i++ {
+		expected = append(expected, i)
+		manager.Trigger(1, i, func() error {
+			return nil
+		})
+	}
+
+	manager.Flush()
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("the async deliveries were expected to be %v, %v returned", expected, received)
+		return
+	}
+
+	err = subscription.Unsubscribe()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	manager.Trigger(1, -1, func() error {
+		return nil
+	})
+
+	manager.Flush()
+	if len(received) != len(expected) {
+		t.Errorf("the unsubscribed event was not expected to be executed")
+		return
+	}
+
+	err = subscription.Unsubscribe()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestManager_async_fullQueue_isDeadLetter_Success(t *testing.T) {
+	release := make(chan struct{})
+	blocking, _ := NewBuilder().Create().WithIdentifier(0).IsAsync().OnEnter(func(data interface{}, event Event) error {
+		<-release
+		return nil
+	}).Now()
+
+	manager := NewManagerFactory().CreateWithQueueSize(1)
+	manager.Add(blocking)
+
+	// the first one is executing, the second one is queued, the third one does not fit:
+	for i := 0; i < 3; i++ {
+		manager.Trigger(0, i, func() error {
+			return nil
+		})
+	}
+
+	close(release)
+	manager.Flush()
+
+	deadLetters := manager.DeadLetters()
+	if len(deadLetters) < 1 {
+		t.Errorf("at least %d dead letter was expected, %d returned", 1, len(deadLetters))
+		return
+	}
+
+	if deadLetters[len(deadLetters)-1].Data() != 2 {
+		t.Errorf("the last delivery was expected to be a dead letter")
+		return
+	}
+}
+
+func TestManager_aborting_failure_stopsTrigger_Success(t *testing.T) {
+	executed := []string{}
+	failing, _ := NewBuilder().Create().WithIdentifier(3).WithPriority(1).IsAborting().OnEnter(func(data interface{}, event Event) error {
+		return errors.New("failing")
+	}).Now()
+
+	next, _ := NewBuilder().Create().WithIdentifier(3).OnEnter(func(data interface{}, event Event) error {
+		executed = append(executed, "next")
+		return nil
+	}).Now()
+
+	manager := NewManagerFactory().Create()
+	manager.AddList([]Event{failing, next})
+
+	err := manager.Trigger(3, "data", func() error {
+		executed = append(executed, "trigger")
+		return nil
+	})
+
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	if len(executed) != 0 {
+		t.Errorf("nothing was expected to be executed after the aborting failure, executed: %v", executed)
+		return
+	}
+
+	if len(manager.DeadLetters()) != 0 {
+		t.Errorf("the aborting failure was not expected to be a dead letter")
+		return
+	}
+}
+
+func TestManager_deadLetters_areCapped_Success(t *testing.T) {
+	failing, _ := NewBuilder().Create().WithIdentifier(4).OnEnter(func(data interface{}, event Event) error {
+		return errors.New("failing")
+	}).Now()
+
+	manager := NewManagerFactory().Create()
+	manager.Add(failing)
+
+	amount := MaxDeadLetters + 10
+	for i := 0; i < amount; i++ {
+		manager.Trigger(4, i, func() error {
+			return nil
+		})
+	}
+
+	deadLetters := manager.DeadLetters()
+	if len(deadLetters) != MaxDeadLetters {
+		t.Errorf("%d dead letters were expected, %d returned", MaxDeadLetters, len(deadLetters))
+		return
+	}
+
+	if deadLetters[0].Data() != amount-MaxDeadLetters || deadLetters[len(deadLetters)-1].Data() != amount-1 {
+		t.Errorf("the oldest dead letters were expected to be dropped")
+		return
+	}
+}
+
+func TestBuilder_asyncAndAborting_returnsError(t *testing.T) {
+	_, err := NewBuilder().Create().WithIdentifier(5).IsAsync().IsAborting().OnEnter(func(data interface{}, event Event) error {
+		return nil
+	}).Now()
+
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
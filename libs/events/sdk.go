@@ -4,6 +4,23 @@ import (
 	"github.com/deepvalue-network/software/libs/hash"
 )
 
+const (
+	// StageEnter represents the stage executed before the trigger func
+	StageEnter Stage = iota + 1
+
+	// StageExit represents the stage executed after the trigger func
+	StageExit
+)
+
+// DefaultQueueSize represents the default amount of pending deliveries of an async event
+const DefaultQueueSize = 64
+
+// MaxDeadLetters represents the maximum amount of dead letters kept by a manager; the oldest ones are dropped first
+const MaxDeadLetters = 1024
+
+// Stage represents the stage of an event func
+type Stage uint8
+
 // NewManagerFactory creates a new manager factory
 func NewManagerFactory() ManagerFactory {
 	return createManagerFactory()
@@ -24,19 +41,44 @@ type TriggerFn func() error
 // ManagerFactory represents a manager factory
 type ManagerFactory interface {
 	Create() Manager
+	CreateWithQueueSize(queueSize uint) Manager
 }
 
-// Manager represents an event manager
+// Manager represents an event manager.  The event funcs of an identifier are executed by descending priority, then
+// in their subscription order.  A failing event func does not stop the others: its failure is kept as a dead letter,
+// unless the event is aborting: then Trigger stops and returns the failure.  The async events are delivered in order,
+// on their own bounded queue; a delivery to a full queue is a dead letter
 type Manager interface {
 	Add(evt Event) error
 	AddList(evts []Event) error
+	Subscribe(evt Event) (Subscription, error)
 	Trigger(identifier int, data interface{}, triggerFn TriggerFn) error
+	Flush()
+	DeadLetters() []DeadLetter
+	ClearDeadLetters()
+}
+
+// Subscription represents the subscription of an event to a manager
+type Subscription interface {
+	Event() Event
+	Unsubscribe() error
+}
+
+// DeadLetter represents a failed delivery of an event
+type DeadLetter interface {
+	Event() Event
+	Stage() Stage
+	Data() interface{}
+	Reason() error
 }
 
 // Builder represents an event builder
 type Builder interface {
 	Create() Builder
 	WithIdentifier(identifier int) Builder
+	WithPriority(priority int) Builder
+	IsAsync() Builder
+	IsAborting() Builder
 	OnEnter(onEnter EventFn) Builder
 	OnExit(onExit EventFn) Builder
 	Now() (Event, error)
@@ -46,6 +88,9 @@ type Builder interface {
 type Event interface {
 	Hash() hash.Hash
 	Identifier() int
+	Priority() int
+	IsAsync() bool
+	IsAborting() bool
 	HasOnEnter() bool
 	OnEnter() EventFn
 	HasOnExit() bool
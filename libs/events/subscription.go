@@ -0,0 +1,120 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type delivery struct {
+	stage Stage
+	data  interface{}
+}
+
+type subscription struct {
+	manager  *manager
+	evt      Event
+	index    uint
+	queue    chan delivery
+	mutex    sync.Mutex
+	isClosed bool
+}
+
+func createSubscription(
+	manager *manager,
+	evt Event,
+	index uint,
+	queueSize uint,
+) *subscription {
+	out := subscription{
+		manager:  manager,
+		evt:      evt,
+		index:    index,
+		queue:    nil,
+		isClosed: false,
+	}
+
+	if evt.IsAsync() {
+		out.queue = make(chan delivery, queueSize)
+		go out.listen()
+	}
+
+	return &out
+}
+
+// Event returns the event
+func (obj *subscription) Event() Event {
+	return obj.evt
+}
+
+// Unsubscribe removes the event from its manager; the pending async deliveries are still executed
+func (obj *subscription) Unsubscribe() error {
+	err := obj.manager.remove(obj)
+	if err != nil {
+		return err
+	}
+
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	obj.isClosed = true
+	if obj.queue != nil {
+		close(obj.queue)
+	}
+
+	return nil
+}
+
+func (obj *subscription) deliver(stage Stage, data interface{}) error {
+	if obj.evt.IsAborting() {
+		return obj.call(stage, data)
+	}
+
+	if !obj.evt.IsAsync() {
+		obj.execute(stage, data)
+		return nil
+	}
+
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	if obj.isClosed {
+		return nil
+	}
+
+	obj.manager.addPending()
+	select {
+	case obj.queue <- delivery{stage: stage, data: data}:
+	default:
+		obj.manager.donePending()
+		str := fmt.Sprintf("the queue (size: %d) of the event (identifier: %d) is full", cap(obj.queue), obj.evt.Identifier())
+		obj.manager.fail(obj.evt, stage, data, errors.New(str))
+	}
+
+	return nil
+}
+
+func (obj *subscription) listen() {
+	for oneDelivery := range obj.queue {
+		obj.execute(oneDelivery.stage, oneDelivery.data)
+		obj.manager.donePending()
+	}
+}
+
+func (obj *subscription) execute(stage Stage, data interface{}) {
+	err := obj.call(stage, data)
+	if err != nil {
+		obj.manager.fail(obj.evt, stage, data, err)
+	}
+}
+
+func (obj *subscription) call(stage Stage, data interface{}) error {
+	fn := obj.evt.OnEnter()
+	if stage == StageExit {
+		fn = obj.evt.OnExit()
+	}
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(data, obj.evt)
+}
@@ -5,10 +5,13 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-// Repository represents a file repository
+// Repository represents a file repository.  The pages are returned in a stable order, the returned cursor fetches the next page
+// and is empty once the last page is returned
 type Repository interface {
 	List() ([]hash.Hash, error)
+	ListPage(cursor string, amount uint) ([]hash.Hash, string, error)
 	ListIDs() ([]*uuid.UUID, error)
+	ListIDsPage(cursor string, amount uint) ([]*uuid.UUID, string, error)
 	Retrieve(name string) (interface{}, error)
 }
 
@@ -17,4 +20,22 @@ type Service interface {
 	Insert(name string, data interface{}) error
 	Update(name string, data interface{}) error
 	Delete(name string) error
+	Begin() Transaction
+}
+
+// Transaction represents a batch of file changes, applied all together on commit or not at all.  A transaction only spans
+// the files of the service that began it: the changes made by several services, like the blockchain cascades across the
+// blocks, links and mined directories, are atomic per service only
+type Transaction interface {
+	Insert(name string, data interface{}) error
+	Update(name string, data interface{}) error
+	Upsert(name string, data interface{}) error
+	Delete(name string) error
+	Commit() error
+	Rollback() error
+}
+
+// Migration represents a migration of the files to the latest version
+type Migration interface {
+	Execute() (uint, error)
 }
@@ -1,8 +1,12 @@
 package disks
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/deepvalue-network/software/libs/hash"
 	uuid "github.com/satori/go.uuid"
@@ -27,51 +31,186 @@ func fileExists(path string) bool {
 	return true
 }
 
+// filePath returns the path of a named file; the files named by an hash or an id are sharded in sub folders, by the
+// prefix of their digest
+func filePath(basePath string, name string) string {
+	if !isSharded(name) {
+		return filepath.Join(basePath, name)
+	}
+
+	prefix := shardPrefix(name)
+	elements := []string{basePath}
+	for i := 0; i < shardDepth; i++ {
+		elements = append(elements, prefix[i*shardWidth:(i+1)*shardWidth])
+	}
+
+	return filepath.Join(append(elements, name)...)
+}
+
+func isSharded(name string) bool {
+	return isHash(name) || isID(name)
+}
+
+func isHash(name string) bool {
+	_, err := hash.NewAdapter().FromString(name)
+	return err == nil
+}
+
+func isID(name string) bool {
+	_, err := uuid.FromString(name)
+	return err == nil
+}
+
+// shardPrefix returns the letters of the name used to shard it; the algorithm of an hash is skipped
+func shardPrefix(name string) string {
+	digest := name[strings.LastIndex(name, ":")+1:]
+	return digest[:shardWidth*shardDepth]
+}
+
+// sortKey returns the key that orders the sharded files the way they are walked
+func sortKey(name string) string {
+	return shardPrefix(name) + name
+}
+
 func listFilesForIDs(dirPath string) ([]*uuid.UUID, error) {
-	files, err := ioutil.ReadDir(dirPath)
+	out, _, err := listFilesForIDsPage(dirPath, "", 0)
+	return out, err
+}
+
+func listFilesForIDsPage(dirPath string, cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	names, next, err := listNames(dirPath, cursor, amount, isID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	out := []*uuid.UUID{}
-	for _, oneFile := range files {
-		if oneFile.IsDir() {
-			continue
-		}
-
-		name := oneFile.Name()
-		id, err := uuid.FromString(name)
+	for _, oneName := range names {
+		id, err := uuid.FromString(oneName)
 		if err != nil {
-			continue
+			return nil, "", err
 		}
 
 		out = append(out, &id)
 	}
 
-	return out, nil
+	return out, next, nil
 }
 
 func listFilesForHashes(dirPath string) ([]hash.Hash, error) {
-	files, err := ioutil.ReadDir(dirPath)
+	out, _, err := listFilesForHashesPage(dirPath, "", 0)
+	return out, err
+}
+
+func listFilesForHashesPage(dirPath string, cursor string, amount uint) ([]hash.Hash, string, error) {
+	names, next, err := listNames(dirPath, cursor, amount, isHash)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	out := []hash.Hash{}
 	hashAdapter := hash.NewAdapter()
-	for _, oneFile := range files {
-		if oneFile.IsDir() {
-			continue
+	for _, oneName := range names {
+		hsh, err := hashAdapter.FromString(oneName)
+		if err != nil {
+			return nil, "", err
 		}
 
+		out = append(out, *hsh)
+	}
+
+	return out, next, nil
+}
+
+// listNames returns a page of the sharded names accepted by the filter, after the cursor.  An amount of zero returns
+// every name
+func listNames(dirPath string, cursor string, amount uint, accept func(name string) bool) ([]string, string, error) {
+	after := ""
+	if cursor != "" {
+		if !isSharded(cursor) {
+			str := fmt.Sprintf(invalidCursorPattern, cursor)
+			return nil, "", errors.New(str)
+		}
+
+		after = sortKey(cursor)
+	}
+
+	names := []string{}
+	_, err := walkShards(dirPath, 0, "", after, func(name string) bool {
+		if !isSharded(name) || !accept(name) || sortKey(name) <= after {
+			return true
+		}
+
+		names = append(names, name)
+		return amount <= 0 || uint(len(names)) <= amount
+	})
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	// an extra name was read to know if another page follows:
+	if amount > 0 && uint(len(names)) > amount {
+		names = names[:amount]
+		return names, names[amount-1], nil
+	}
+
+	return names, "", nil
+}
+
+// walkShards walks the sharded files in order, skipping the shards before the cursor, until the callback returns false
+func walkShards(dirPath string, depth int, shard string, after string, fn func(name string) bool) (bool, error) {
+	list, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		return false, err
+	}
+
+	for _, oneFile := range list {
 		name := oneFile.Name()
-		hsh, err := hashAdapter.FromString(name)
-		if err != nil {
+		if depth >= shardDepth {
+			if oneFile.IsDir() {
+				continue
+			}
+
+			if !fn(name) {
+				return false, nil
+			}
+
 			continue
 		}
 
-		out = append(out, *hsh)
+		if !oneFile.IsDir() || len(name) != shardWidth {
+			continue
+		}
+
+		subShard := shard + name
+		if len(after) >= len(subShard) && subShard < after[:len(subShard)] {
+			continue
+		}
+
+		isContinuing, err := walkShards(filepath.Join(dirPath, name), depth+1, subShard, after, fn)
+		if err != nil || !isContinuing {
+			return isContinuing, err
+		}
 	}
 
-	return out, nil
+	return true, nil
+}
+
+// walkFiles calls the callback on every file of a directory, including the sharded ones, skipping the journal
+func walkFiles(dirPath string, fn func(path string, info os.FileInfo) error) error {
+	return filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == journalDirName {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		return fn(path, info)
+	})
 }
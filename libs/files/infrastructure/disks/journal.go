@@ -0,0 +1,78 @@
+package disks
+
+import (
+	"os"
+)
+
+const (
+	operationInsert uint8 = iota + 1
+	operationUpdate
+	operationDelete
+	operationUpsert
+)
+
+type operation struct {
+	kind uint8
+	name string
+	data []byte
+}
+
+type journal struct {
+	Operations []journalOperation `json:"operations"`
+}
+
+type journalOperation struct {
+	Kind uint8  `json:"kind"`
+	Name string `json:"name"`
+	Temp string `json:"temp"`
+}
+
+// collapse keeps the last operation of every name, in their order
+func collapse(operations []operation) []operation {
+	last := map[string]int{}
+	for index, oneOperation := range operations {
+		last[oneOperation.name] = index
+	}
+
+	out := []operation{}
+	for index, oneOperation := range operations {
+		if last[oneOperation.name] != index {
+			continue
+		}
+
+		out = append(out, oneOperation)
+	}
+
+	return out
+}
+
+func writeFileSynced(path string, data []byte, fileMode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,145 @@
+package disks
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+)
+
+type keyRotation struct {
+	basePath string
+	current  encryption.Encryption
+	next     encryption.Encryption
+}
+
+func createKeyRotation(
+	basePath string,
+	current encryption.Encryption,
+	next encryption.Encryption,
+) files.Migration {
+	out := keyRotation{
+		basePath: basePath,
+		current:  current,
+		next:     next,
+	}
+
+	return &out
+}
+
+// Execute re-encrypts every file of the directory, including the sharded ones, and returns the amount of rewritten
+// files.  Every file is replaced atomically, and the files already rotated by an interrupted execution are skipped
+func (app *keyRotation) Execute() (uint, error) {
+	if !fileExists(app.basePath) {
+		return 0, nil
+	}
+
+	journalPath := filepath.Join(app.basePath, journalDirName)
+	err := makeDirIfNotExists(journalPath, 0700)
+	if err != nil {
+		return 0, err
+	}
+
+	amount := uint(0)
+	err = walkFiles(app.basePath, func(path string, info os.FileInfo) error {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		isRotated, err := app.isRotated(path, data)
+		if err != nil {
+			return err
+		}
+
+		if isRotated {
+			return nil
+		}
+
+		plain, err := decrypt(app.current, data)
+		if err != nil {
+			return err
+		}
+
+		rotated, err := encrypt(app.next, plain)
+		if err != nil {
+			return err
+		}
+
+		temp := filepath.Join(journalPath, fmt.Sprintf("%s%s", info.Name(), tempExtension))
+		err = writeFileSynced(temp, rotated, info.Mode())
+		if err != nil {
+			return err
+		}
+
+		err = os.Rename(temp, path)
+		if err != nil {
+			return err
+		}
+
+		amount++
+		return syncDir(filepath.Dir(path))
+	})
+
+	return amount, err
+}
+
+// isRotated returns true when a file cannot be decrypted by the current encryption, but is in the format of the next
+// one.  A file that is in neither format is corrupted, or encrypted with another key
+func (app *keyRotation) isRotated(path string, data []byte) (bool, error) {
+	if app.current == nil {
+		return app.next != nil && canDecrypt(app.next, data), nil
+	}
+
+	if canDecrypt(app.current, data) {
+		return false, nil
+	}
+
+	// without a next encryption, the rotated files are plain:
+	if app.next == nil && !encryption.IsVersioned(string(data)) {
+		return true, nil
+	}
+
+	if app.next != nil && canDecrypt(app.next, data) {
+		return true, nil
+	}
+
+	str := fmt.Sprintf("the file (path: %s) cannot be decrypted by the current encryption: it is corrupted or encrypted with another key", path)
+	return false, errors.New(str)
+}
+
+func canDecrypt(enc encryption.Encryption, data []byte) bool {
+	_, err := decrypt(enc, data)
+	return err == nil
+}
+
+// decrypt decrypts the data of a file; the files are only decrypted if they are versioned, since the decryption of a
+// legacy ciphertext never fails, even on plain data
+func decrypt(enc encryption.Encryption, data []byte) ([]byte, error) {
+	if enc == nil {
+		return data, nil
+	}
+
+	if !encryption.IsVersioned(string(data)) {
+		return nil, errors.New("the file is not encrypted in a versioned format")
+	}
+
+	return enc.Decrypt(string(data))
+}
+
+func encrypt(enc encryption.Encryption, data []byte) ([]byte, error) {
+	if enc == nil {
+		return data, nil
+	}
+
+	cipher, err := enc.Encrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(cipher), nil
+}
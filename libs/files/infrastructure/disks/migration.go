@@ -0,0 +1,83 @@
+package disks
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
+)
+
+type migration struct {
+	hydroAdapter hydro.Adapter
+	basePath     string
+	ptr          interface{}
+	encryption   encryption.Encryption
+}
+
+func createMigration(
+	hydroAdapter hydro.Adapter,
+	basePath string,
+	ptr interface{},
+	encryption encryption.Encryption,
+) files.Migration {
+	out := migration{
+		hydroAdapter: hydroAdapter,
+		basePath:     basePath,
+		ptr:          ptr,
+		encryption:   encryption,
+	}
+
+	return &out
+}
+
+// Execute upgrades every file of the directory to the latest version, and returns the amount of rewritten files.  The
+// files are decrypted before being upgraded, then rewritten through the journal of a service that encrypts them again,
+// so an interrupted execution leaves every file either upgraded or not, and can be executed again
+func (app *migration) Execute() (uint, error) {
+	if !fileExists(app.basePath) {
+		return 0, nil
+	}
+
+	info, err := os.Stat(app.basePath)
+	if err != nil {
+		return 0, err
+	}
+
+	service := creatService(app.hydroAdapter, app.basePath, info.Mode().Perm(), hydro.JSON, app.encryption, observability.DefaultRegistry())
+	amount := uint(0)
+	err = walkFiles(app.basePath, func(path string, info os.FileInfo) error {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		data, err = decrypt(app.encryption, data)
+		if err != nil {
+			return err
+		}
+
+		upgraded, err := app.hydroAdapter.Upgrade(data, app.ptr)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(data, upgraded) {
+			return nil
+		}
+
+		err = service.Update(filepath.Base(path), upgraded)
+		if err != nil {
+			return err
+		}
+
+		amount++
+		return nil
+	})
+
+	return amount, err
+}
@@ -1,15 +1,15 @@
 package disks
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"path/filepath"
 
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
 	"github.com/deepvalue-network/software/libs/files/domain/files"
 	"github.com/deepvalue-network/software/libs/hash"
 	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -17,17 +17,23 @@ type repository struct {
 	hydroAdapter hydro.Adapter
 	basePath     string
 	ptr          interface{}
+	encryption   encryption.Encryption
+	readBytes    observability.Counter
 }
 
 func createRepository(
 	hydroAdapter hydro.Adapter,
 	basePath string,
 	ptr interface{},
+	encryption encryption.Encryption,
+	registry observability.Registry,
 ) files.Repository {
 	out := repository{
 		hydroAdapter: hydroAdapter,
 		basePath:     basePath,
 		ptr:          ptr,
+		encryption:   encryption,
+		readBytes:    registry.Counter(readBytesMetric, "The amount of bytes read from the disk files"),
 	}
 
 	return &out
@@ -38,14 +44,24 @@ func (app *repository) List() ([]hash.Hash, error) {
 	return listFilesForHashes(app.basePath)
 }
 
+// ListPage lists a page of hashes, after the cursor
+func (app *repository) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	return listFilesForHashesPage(app.basePath, cursor, amount)
+}
+
 // ListIDs list the ids
 func (app *repository) ListIDs() ([]*uuid.UUID, error) {
 	return listFilesForIDs(app.basePath)
 }
 
+// ListIDsPage lists a page of ids, after the cursor
+func (app *repository) ListIDsPage(cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	return listFilesForIDsPage(app.basePath, cursor, amount)
+}
+
 // Retrieve retrieves a file by name
 func (app *repository) Retrieve(name string) (interface{}, error) {
-	path := filepath.Join(app.basePath, name)
+	path := filePath(app.basePath, name)
 	if !fileExists(path) {
 		str := fmt.Sprintf(fileDoesNotExistsPattern, path)
 		return nil, errors.New(str)
@@ -56,15 +72,16 @@ func (app *repository) Retrieve(name string) (interface{}, error) {
 		return nil, err
 	}
 
-	if app.ptr == nil {
-		return data, nil
-	}
+	app.readBytes.Add(uint64(len(data)))
 
-	hydrated := app.ptr
-	err = json.Unmarshal(data, hydrated)
+	data, err = decrypt(app.encryption, data)
 	if err != nil {
 		return nil, err
 	}
 
-	return app.hydroAdapter.Dehydrate(hydrated)
+	if app.ptr == nil {
+		return data, nil
+	}
+
+	return app.hydroAdapter.Decode(data, app.ptr)
 }
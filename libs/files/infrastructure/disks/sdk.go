@@ -3,21 +3,55 @@ package disks
 import (
 	"os"
 
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
 	"github.com/deepvalue-network/software/libs/files/domain/files"
 	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
 const fileDoesNotExistsPattern = "the file (path: %s) does not exists"
 
 const fileAlreadyExistsPattern = "the file (path: %s) already exists"
 
+const transactionIsDoneErrorOutput = "the transaction has already been committed or rolled back"
+
+// the journal directory contains the write-ahead logs of the transactions:
+const journalDirName = ".journal"
+
+const journalExtension = ".log"
+
+const tempExtension = ".tmp"
+
+const invalidCursorPattern = "the cursor (%s) is not the name of a sharded file"
+
+// the files named by an hash or an id are stored in shardDepth levels of sub folders, each named by shardWidth letters:
+const shardWidth = 2
+
+const shardDepth = 2
+
+const readBytesMetric = "files_disk_read_bytes_total"
+
+const writtenBytesMetric = "files_disk_written_bytes_total"
+
+const commitSecondsMetric = "files_disk_commit_seconds"
+
 // NewRepository creates a new disk repository instance
 func NewRepository(
 	hydroAdapter hydro.Adapter,
 	basePath string,
 	ptr interface{},
 ) files.Repository {
-	return createRepository(hydroAdapter, basePath, ptr)
+	return createRepository(hydroAdapter, basePath, ptr, nil, observability.DefaultRegistry())
+}
+
+// NewEncryptedRepository creates a new disk repository instance that decrypts its files
+func NewEncryptedRepository(
+	hydroAdapter hydro.Adapter,
+	basePath string,
+	ptr interface{},
+	encryption encryption.Encryption,
+) files.Repository {
+	return createRepository(hydroAdapter, basePath, ptr, encryption, observability.DefaultRegistry())
 }
 
 // NewService creates a new service instance
@@ -26,5 +60,64 @@ func NewService(
 	basePath string,
 	fileMode os.FileMode,
 ) files.Service {
-	return creatService(hydroAdapter, basePath, fileMode)
+	return creatService(hydroAdapter, basePath, fileMode, hydro.JSON, nil, observability.DefaultRegistry())
+}
+
+// NewServiceWithFormat creates a new service instance that encodes its files in the given format
+func NewServiceWithFormat(
+	hydroAdapter hydro.Adapter,
+	basePath string,
+	fileMode os.FileMode,
+	format hydro.Format,
+) files.Service {
+	return creatService(hydroAdapter, basePath, fileMode, format, nil, observability.DefaultRegistry())
+}
+
+// NewEncryptedService creates a new service instance that encrypts its files, with the key the encryption derives once
+// from its salt, and a nonce per file
+func NewEncryptedService(
+	hydroAdapter hydro.Adapter,
+	basePath string,
+	fileMode os.FileMode,
+	format hydro.Format,
+	encryption encryption.Encryption,
+) files.Service {
+	return creatService(hydroAdapter, basePath, fileMode, format, encryption, observability.DefaultRegistry())
+}
+
+// NewKeyRotation creates a new migration instance, that re-encrypts the files of a directory with the next encryption.
+// A nil current encryption encrypts plain files, a nil next encryption decrypts them
+func NewKeyRotation(
+	basePath string,
+	current encryption.Encryption,
+	next encryption.Encryption,
+) files.Migration {
+	return createKeyRotation(basePath, current, next)
+}
+
+// NewShardMigration creates a new migration instance, that moves the files of a flat directory to their shard
+func NewShardMigration(
+	basePath string,
+) files.Migration {
+	return createShardMigration(basePath)
+}
+
+// NewMigration creates a new migration instance, that upgrades the files of a directory to the latest version of the pointer
+func NewMigration(
+	hydroAdapter hydro.Adapter,
+	basePath string,
+	ptr interface{},
+) files.Migration {
+	return createMigration(hydroAdapter, basePath, ptr, nil)
+}
+
+// NewEncryptedMigration creates a new migration instance, that upgrades the encrypted files of a directory to the latest
+// version of the pointer
+func NewEncryptedMigration(
+	hydroAdapter hydro.Adapter,
+	basePath string,
+	ptr interface{},
+	encryption encryption.Encryption,
+) files.Migration {
+	return createMigration(hydroAdapter, basePath, ptr, encryption)
 }
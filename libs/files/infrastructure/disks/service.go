@@ -7,31 +7,59 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
 	"github.com/deepvalue-network/software/libs/files/domain/files"
 	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/observability"
 )
 
 type service struct {
-	hydroAdapter hydro.Adapter
-	basePath     string
-	fileMode     os.FileMode
+	hydroAdapter   hydro.Adapter
+	basePath       string
+	journalPath    string
+	fileMode       os.FileMode
+	format         hydro.Format
+	encryption     encryption.Encryption
+	writtenBytes   observability.Counter
+	commitDuration observability.Histogram
+	mutex          *sync.Mutex
 }
 
 func creatService(
 	hydroAdapter hydro.Adapter,
 	basePath string,
 	fileMode os.FileMode,
+	format hydro.Format,
+	encryption encryption.Encryption,
+	registry observability.Registry,
 ) files.Service {
-	err := makeDirIfNotExists(basePath, fileMode)
+	journalPath := filepath.Join(basePath, journalDirName)
+	err := makeDirIfNotExists(journalPath, fileMode)
 	if err != nil {
 		panic(err)
 	}
 
 	out := service{
-		hydroAdapter: hydroAdapter,
-		basePath:     basePath,
-		fileMode:     fileMode,
+		hydroAdapter:   hydroAdapter,
+		basePath:       basePath,
+		journalPath:    journalPath,
+		fileMode:       fileMode,
+		format:         format,
+		encryption:     encryption,
+		writtenBytes:   registry.Counter(writtenBytesMetric, "The amount of bytes written in the disk files"),
+		commitDuration: registry.Histogram(commitSecondsMetric, "The duration of the disk transaction commits", observability.DefaultBuckets),
+		mutex:          new(sync.Mutex),
+	}
+
+	// recover the transactions interrupted by a crash:
+	err = out.recover()
+	if err != nil {
+		panic(err)
 	}
 
 	return &out
@@ -39,55 +67,258 @@ func creatService(
 
 // Insert inserts a file
 func (app *service) Insert(name string, ins interface{}) error {
-	path := filepath.Join(app.basePath, name)
-	if fileExists(path) {
-		str := fmt.Sprintf("the file (path: %s) already exists", path)
-		return errors.New(str)
+	trx := app.Begin()
+	err := trx.Insert(name, ins)
+	if err != nil {
+		return err
 	}
 
-	return app.save(path, ins)
+	return trx.Commit()
 }
 
 // Update updates a file
 func (app *service) Update(name string, ins interface{}) error {
-	path := filepath.Join(app.basePath, name)
-	if !fileExists(path) {
-		str := fmt.Sprintf(fileDoesNotExistsPattern, path)
-		return errors.New(str)
+	trx := app.Begin()
+	err := trx.Update(name, ins)
+	if err != nil {
+		return err
 	}
 
-	return app.save(path, ins)
+	return trx.Commit()
 }
 
 // Delete deletes a file
 func (app *service) Delete(name string) error {
-	path := filepath.Join(app.basePath, name)
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		str := fmt.Sprintf(fileDoesNotExistsPattern, path)
-		return errors.New(str)
+	trx := app.Begin()
+	err := trx.Delete(name)
+	if err != nil {
+		return err
 	}
 
-	return os.Remove(path)
+	return trx.Commit()
+}
+
+// Begin begins a transaction
+func (app *service) Begin() files.Transaction {
+	return createTransaction(app)
 }
 
-func (app *service) save(path string, ins interface{}) error {
+func (app *service) encode(ins interface{}) ([]byte, error) {
+	data, err := app.encodePlain(ins)
+	if err != nil {
+		return nil, err
+	}
+
+	return encrypt(app.encryption, data)
+}
+
+func (app *service) encodePlain(ins interface{}) ([]byte, error) {
 	if str, ok := ins.(string); ok {
-		return ioutil.WriteFile(path, []byte(str), app.fileMode)
+		return []byte(str), nil
 	}
 
 	if bytes, ok := ins.([]byte); ok {
-		return ioutil.WriteFile(path, bytes, app.fileMode)
+		return bytes, nil
 	}
 
-	hydrated, err := app.hydroAdapter.Hydrate(ins)
+	return app.hydroAdapter.EncodeWithFormat(ins, app.format)
+}
+
+// validate verifies that the operations can be applied on the current files
+func (app *service) validate(operations []operation) error {
+	exists := map[string]bool{}
+	for _, oneOperation := range operations {
+		path := filePath(app.basePath, oneOperation.name)
+		isExisting, ok := exists[oneOperation.name]
+		if !ok {
+			isExisting = fileExists(path)
+		}
+
+		if oneOperation.kind == operationUpsert {
+			exists[oneOperation.name] = true
+			continue
+		}
+
+		if oneOperation.kind == operationInsert && isExisting {
+			str := fmt.Sprintf(fileAlreadyExistsPattern, path)
+			return errors.New(str)
+		}
+
+		if oneOperation.kind != operationInsert && !isExisting {
+			str := fmt.Sprintf(fileDoesNotExistsPattern, path)
+			return errors.New(str)
+		}
+
+		exists[oneOperation.name] = oneOperation.kind != operationDelete
+	}
+
+	return nil
+}
+
+func (app *service) commit(id string, operations []operation) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	beginsOn := time.Now().UTC()
+	defer func() {
+		app.commitDuration.Observe(time.Now().UTC().Sub(beginsOn).Seconds())
+	}()
+
+	err := app.validate(operations)
+	if err != nil {
+		return err
+	}
+
+	err = app.write(id, operations)
+	if err != nil {
+		os.RemoveAll(filepath.Join(app.journalPath, id))
+		return err
+	}
+
+	return app.replay(id)
+}
+
+// write writes the content of the operations in temporary files, then the journal of the transaction.  Once the
+// journal is renamed to its final name, the transaction is committed and will be replayed after a crash.  Only the
+// last operation of every name is journaled, so that replaying a delete never removes a file written after it
+func (app *service) write(id string, operations []operation) error {
+	trxPath := filepath.Join(app.journalPath, id)
+	err := makeDirIfNotExists(trxPath, app.fileMode)
 	if err != nil {
 		return err
 	}
 
-	js, err := json.Marshal(hydrated)
+	journal := journal{
+		Operations: []journalOperation{},
+	}
+
+	for index, oneOperation := range collapse(operations) {
+		temp := ""
+		if oneOperation.kind != operationDelete {
+			temp = strconv.Itoa(index)
+			err := writeFileSynced(filepath.Join(trxPath, temp), oneOperation.data, app.fileMode)
+			if err != nil {
+				return err
+			}
+		}
+
+		journal.Operations = append(journal.Operations, journalOperation{
+			Kind: oneOperation.kind,
+			Name: oneOperation.name,
+			Temp: temp,
+		})
+	}
+
+	js, err := json.Marshal(journal)
 	if err != nil {
 		return err
 	}
 
-	return ioutil.WriteFile(path, js, app.fileMode)
+	journalPath := filepath.Join(app.journalPath, fmt.Sprintf("%s%s", id, journalExtension))
+	tempJournalPath := fmt.Sprintf("%s%s", journalPath, tempExtension)
+	err = writeFileSynced(tempJournalPath, js, app.fileMode)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tempJournalPath, journalPath)
+	if err != nil {
+		return err
+	}
+
+	return syncDir(app.journalPath)
+}
+
+// replay applies a committed journal; it can be replayed as many times as needed
+func (app *service) replay(id string) error {
+	trxPath := filepath.Join(app.journalPath, id)
+	journalPath := filepath.Join(app.journalPath, fmt.Sprintf("%s%s", id, journalExtension))
+	js, err := ioutil.ReadFile(journalPath)
+	if err != nil {
+		return err
+	}
+
+	journal := new(journal)
+	err = json.Unmarshal(js, journal)
+	if err != nil {
+		return err
+	}
+
+	dirs := map[string]bool{}
+	for _, oneOperation := range journal.Operations {
+		path := filePath(app.basePath, oneOperation.Name)
+		dirs[filepath.Dir(path)] = true
+		if oneOperation.Kind == operationDelete {
+			err := os.Remove(path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+
+			continue
+		}
+
+		// a missing temporary file was already moved by a previous replay:
+		temp := filepath.Join(trxPath, oneOperation.Temp)
+		if !fileExists(temp) {
+			continue
+		}
+
+		err := makeDirIfNotExists(filepath.Dir(path), app.fileMode)
+		if err != nil {
+			return err
+		}
+
+		err = os.Rename(temp, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	for oneDir := range dirs {
+		err := syncDir(oneDir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	err = os.Remove(journalPath)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(trxPath)
+}
+
+// recover replays the committed journals and removes the transactions that were never committed
+func (app *service) recover() error {
+	list, err := ioutil.ReadDir(app.journalPath)
+	if err != nil {
+		return err
+	}
+
+	for _, oneFile := range list {
+		name := oneFile.Name()
+		if oneFile.IsDir() || !strings.HasSuffix(name, journalExtension) {
+			continue
+		}
+
+		err := app.replay(strings.TrimSuffix(name, journalExtension))
+		if err != nil {
+			return err
+		}
+	}
+
+	list, err = ioutil.ReadDir(app.journalPath)
+	if err != nil {
+		return err
+	}
+
+	for _, oneFile := range list {
+		err := os.RemoveAll(filepath.Join(app.journalPath, oneFile.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
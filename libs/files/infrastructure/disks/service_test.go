@@ -0,0 +1,526 @@
+package disks
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+	"github.com/deepvalue-network/software/libs/hydro/internals"
+)
+
+func TestService_transaction_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	service := NewService(nil, basePath, 0777)
+	err := service.Insert("existing", "existing data")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	trx := service.Begin()
+	trx.Insert("first", "first data")
+	trx.Update("existing", "updated data")
+	err = trx.Insert("existing", "data")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	// nothing is written before the commit:
+	if fileExists(filepath.Join(basePath, "first")) {
+		t.Errorf("the file was not expected to be written before the commit")
+		return
+	}
+
+	err = trx.Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data, _ := ioutil.ReadFile(filepath.Join(basePath, "existing"))
+	if string(data) != "updated data" {
+		t.Errorf("the file was expected to be updated")
+		return
+	}
+
+	if !fileExists(filepath.Join(basePath, "first")) {
+		t.Errorf("the file was expected to be inserted")
+		return
+	}
+
+	err = trx.Commit()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	// upsert:
+	trx = service.Begin()
+	trx.Upsert("first", "upserted data")
+	trx.Upsert("second", "second data")
+	err = trx.Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for name, expected := range map[string]string{"first": "upserted data", "second": "second data"} {
+		data, _ := ioutil.ReadFile(filepath.Join(basePath, name))
+		if string(data) != expected {
+			t.Errorf("the file (name: %s) was expected to be upserted", name)
+			return
+		}
+	}
+
+	// rollback:
+	trx = service.Begin()
+	trx.Delete("first")
+	err = trx.Rollback()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !fileExists(filepath.Join(basePath, "first")) {
+		t.Errorf("the rolled back file was not expected to be deleted")
+		return
+	}
+}
+
+func TestService_recover_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	ins := NewService(nil, basePath, 0777)
+	ins.Insert("deleted", "data")
+	svc := ins.(*service)
+
+	// a committed transaction, interrupted before being applied:
+	err := svc.write("committed", []operation{
+		{kind: operationInsert, name: "inserted", data: []byte("inserted data")},
+		{kind: operationDelete, name: "deleted"},
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// a transaction interrupted before its commit:
+	uncommittedPath := filepath.Join(basePath, journalDirName, "uncommitted")
+	os.MkdirAll(uncommittedPath, 0777)
+	ioutil.WriteFile(filepath.Join(uncommittedPath, "0"), []byte("data"), 0777)
+
+	// restart:
+	NewService(nil, basePath, 0777)
+
+	data, _ := ioutil.ReadFile(filepath.Join(basePath, "inserted"))
+	if string(data) != "inserted data" {
+		t.Errorf("the committed transaction was expected to be replayed")
+		return
+	}
+
+	if fileExists(filepath.Join(basePath, "deleted")) {
+		t.Errorf("the committed delete was expected to be replayed")
+		return
+	}
+
+	list, _ := ioutil.ReadDir(filepath.Join(basePath, journalDirName))
+	if len(list) != 0 {
+		t.Errorf("the journal was expected to be empty, %d files remaining", len(list))
+		return
+	}
+}
+
+func TestService_recover_deleteThenInsert_afterApplied_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	ins := NewService(nil, basePath, 0777)
+	ins.Insert("replaced", "old data")
+	svc := ins.(*service)
+
+	// a committed transaction that deletes then inserts the same name:
+	err := svc.write("committed", []operation{
+		{kind: operationDelete, name: "replaced"},
+		{kind: operationInsert, name: "replaced", data: []byte("new data")},
+	})
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the transaction is applied, then the process crashes before removing its journal:
+	journalPath := filepath.Join(basePath, journalDirName, "committed"+journalExtension)
+	js, _ := ioutil.ReadFile(journalPath)
+	err = svc.replay("committed")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	ioutil.WriteFile(journalPath, js, 0777)
+
+	// restart:
+	NewService(nil, basePath, 0777)
+
+	data, _ := ioutil.ReadFile(filepath.Join(basePath, "replaced"))
+	if string(data) != "new data" {
+		t.Errorf("the inserted file was expected to be kept by the second replay, data: %s", data)
+		return
+	}
+}
+
+func TestService_encryption_withKeyRotation_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	first := encryption.NewEncryption("first")
+	second := encryption.NewEncryption("second")
+	service := NewEncryptedService(nil, basePath, 0777, hydro.JSON, first)
+	err := service.Insert("secret", "secret data")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data, _ := ioutil.ReadFile(filepath.Join(basePath, "secret"))
+	if strings.Contains(string(data), "secret data") {
+		t.Errorf("the file was expected to be encrypted on disk")
+		return
+	}
+
+	retData, err := NewEncryptedRepository(nil, basePath, nil, first).Retrieve("secret")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(retData.([]byte)) != "secret data" {
+		t.Errorf("the decrypted file is invalid")
+		return
+	}
+
+	amount, err := NewKeyRotation(basePath, first, second).Execute()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("%d file was expected to be rotated, %d rotated", 1, amount)
+		return
+	}
+
+	retData, err = NewEncryptedRepository(nil, basePath, nil, second).Retrieve("secret")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(retData.([]byte)) != "secret data" {
+		t.Errorf("the rotated file is invalid")
+		return
+	}
+
+	_, err = NewEncryptedRepository(nil, basePath, nil, first).Retrieve("secret")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	// the already rotated files are skipped:
+	amount, err = NewKeyRotation(basePath, first, second).Execute()
+	if err != nil || amount != 0 {
+		t.Errorf("no file was expected to be rotated again")
+		return
+	}
+}
+
+func TestService_encryption_withKeyRotation_fromPlainHex_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// a plain pointer file holds an hex hash, which is valid base64:
+	content := "0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"
+	err := NewService(nil, basePath, 0777).Insert("head.hash", []byte(content))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// even if the encryption decrypts the legacy ciphertexts, the files are only decrypted if versioned:
+	first, err := encryption.NewBuilder().Create().WithPassword([]byte("first")).WithLegacyDecryption().Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = NewEncryptedRepository(nil, basePath, nil, first).Retrieve("head.hash")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	amount, err := NewKeyRotation(basePath, nil, first).Execute()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("%d file was expected to be rotated, %d rotated", 1, amount)
+		return
+	}
+
+	data, _ := ioutil.ReadFile(filepath.Join(basePath, "head.hash"))
+	if !encryption.IsVersioned(string(data)) {
+		t.Errorf("the file was expected to be encrypted on disk")
+		return
+	}
+
+	retData, err := NewEncryptedRepository(nil, basePath, nil, first).Retrieve("head.hash")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(retData.([]byte)) != content {
+		t.Errorf("the rotated file is invalid")
+		return
+	}
+}
+
+func TestService_encryption_withKeyRotation_withOtherKey_returnsError(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	first := encryption.NewEncryption("first")
+	other := encryption.NewEncryption("other")
+	service := NewEncryptedService(nil, basePath, 0777, hydro.JSON, first)
+	err := service.Insert("secret", "secret data")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the file is neither plain, nor encrypted with the current key:
+	_, err = NewKeyRotation(basePath, other, nil).Execute()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	_, err = NewKeyRotation(basePath, other, encryption.NewEncryption("next")).Execute()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	retData, err := NewEncryptedRepository(nil, basePath, nil, first).Retrieve("secret")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(retData.([]byte)) != "secret data" {
+		t.Errorf("the file was not expected to be rewritten")
+		return
+	}
+}
+
+func TestService_sharding_withPages_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	hashAdapter := hash.NewAdapter()
+	service := NewService(nil, basePath, 0777)
+	names := []string{}
+	for i := 0; i < 5; i++ {
+		hsh, _ := hashAdapter.Hash([]byte(strconv.Itoa(i)))
+		names = append(names, hsh.String())
+		err := service.Insert(hsh.String(), "data")
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	// the named files stay at the root:
+	service.Insert("head", "data")
+
+	first := names[0]
+	if !fileExists(filepath.Join(basePath, first[0:2], first[2:4], first)) {
+		t.Errorf("the file was expected to be sharded")
+		return
+	}
+
+	// list every page:
+	repository := NewRepository(nil, basePath, nil)
+	listed := []string{}
+	cursor := ""
+	for {
+		page, next, err := repository.ListPage(cursor, 2)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		for _, oneHash := range page {
+			listed = append(listed, oneHash.String())
+		}
+
+		if next == "" {
+			break
+		}
+
+		cursor = next
+	}
+
+	sort.Strings(names)
+	if strings.Join(listed, ",") != strings.Join(names, ",") {
+		t.Errorf("the listed hashes were expected to be sorted and complete, returned: %v", listed)
+		return
+	}
+
+	_, err := repository.Retrieve(first)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// a flat file, written before the sharding:
+	hsh, _ := hashAdapter.Hash([]byte("flat"))
+	ioutil.WriteFile(filepath.Join(basePath, hsh.String()), []byte("data"), 0777)
+
+	amount, err := NewShardMigration(basePath).Execute()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("%d file was expected to be moved, %d moved", 1, amount)
+		return
+	}
+
+	_, err = repository.Retrieve(hsh.String())
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !fileExists(filepath.Join(basePath, "head")) {
+		t.Errorf("the named file was not expected to be moved")
+		return
+	}
+}
+
+func TestMigration_encrypted_Success(t *testing.T) {
+	basePath := "./test_files"
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	// the version 1 of the payload was naming the first field "one":
+	simpleBridge, err := hydro.NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.SimpleInterface)(nil)).
+		WithDehydratedConstructor(internals.NewSimple).
+		WithDehydratedPointer(new(internals.DehydrateSimpleStruct)).
+		WithHydratedPointer(new(internals.HydrateSimpleStruct)).
+		WithVersion(2).
+		WithMigration(1, func(payload map[string]interface{}) (map[string]interface{}, error) {
+			payload["first"] = payload["one"]
+			delete(payload, "one")
+			return payload, nil
+		}).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	manager := hydro.NewManagerFactory().Create()
+	manager.Register(simpleBridge)
+	hydroAdapter, err := hydro.NewAdapterBuilder().Create().WithManager(manager).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	enc := encryption.NewEncryption("first")
+	service := NewEncryptedService(hydroAdapter, basePath, 0777, hydro.JSON, enc)
+	err = service.Insert("simple", []byte(`{"one":"firstValue","second":"secondValue"}`))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	migration := NewEncryptedMigration(hydroAdapter, basePath, new(internals.HydrateSimpleStruct), enc)
+	amount, err := migration.Execute()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 1 {
+		t.Errorf("%d file was expected to be upgraded, %d upgraded", 1, amount)
+		return
+	}
+
+	// the upgraded file is encrypted again:
+	data, _ := ioutil.ReadFile(filepath.Join(basePath, "simple"))
+	if strings.Contains(string(data), "firstValue") {
+		t.Errorf("the upgraded file was expected to be encrypted on disk")
+		return
+	}
+
+	ins, err := NewEncryptedRepository(hydroAdapter, basePath, new(internals.HydrateSimpleStruct), enc).Retrieve("simple")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if ins.(internals.SimpleInterface).First() != "firstValue" {
+		t.Errorf("the upgraded file is invalid")
+		return
+	}
+
+	// the upgraded files are not rewritten again:
+	amount, err = migration.Execute()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if amount != 0 {
+		t.Errorf("no file was expected to be upgraded, %d upgraded", amount)
+		return
+	}
+}
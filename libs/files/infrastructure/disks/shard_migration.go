@@ -0,0 +1,76 @@
+package disks
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+)
+
+type shardMigration struct {
+	basePath string
+}
+
+func createShardMigration(
+	basePath string,
+) files.Migration {
+	out := shardMigration{
+		basePath: basePath,
+	}
+
+	return &out
+}
+
+// Execute moves the files named by an hash or an id, from the root of the directory to their shard, and returns the
+// amount of moved files.  Every file is moved atomically, so an interrupted execution can be executed again
+func (app *shardMigration) Execute() (uint, error) {
+	if !fileExists(app.basePath) {
+		return 0, nil
+	}
+
+	info, err := os.Stat(app.basePath)
+	if err != nil {
+		return 0, err
+	}
+
+	list, err := ioutil.ReadDir(app.basePath)
+	if err != nil {
+		return 0, err
+	}
+
+	amount := uint(0)
+	for _, oneFile := range list {
+		name := oneFile.Name()
+		if oneFile.IsDir() || !isSharded(name) {
+			continue
+		}
+
+		path := filePath(app.basePath, name)
+		if fileExists(path) {
+			str := fmt.Sprintf(fileAlreadyExistsPattern, path)
+			return amount, errors.New(str)
+		}
+
+		err := makeDirIfNotExists(filepath.Dir(path), info.Mode().Perm())
+		if err != nil {
+			return amount, err
+		}
+
+		err = os.Rename(filepath.Join(app.basePath, name), path)
+		if err != nil {
+			return amount, err
+		}
+
+		err = syncDir(filepath.Dir(path))
+		if err != nil {
+			return amount, err
+		}
+
+		amount++
+	}
+
+	return amount, syncDir(app.basePath)
+}
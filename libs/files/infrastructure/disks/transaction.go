@@ -0,0 +1,102 @@
+package disks
+
+import (
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	uuid "github.com/satori/go.uuid"
+)
+
+type transaction struct {
+	service    *service
+	id         string
+	operations []operation
+	isDone     bool
+}
+
+func createTransaction(
+	service *service,
+) files.Transaction {
+	out := transaction{
+		service:    service,
+		id:         uuid.NewV4().String(),
+		operations: []operation{},
+		isDone:     false,
+	}
+
+	return &out
+}
+
+// Insert adds a file insert to the transaction
+func (obj *transaction) Insert(name string, data interface{}) error {
+	return obj.add(operationInsert, name, data)
+}
+
+// Update adds a file update to the transaction
+func (obj *transaction) Update(name string, data interface{}) error {
+	return obj.add(operationUpdate, name, data)
+}
+
+// Upsert adds a file insert to the transaction, or a file update if the file already exists
+func (obj *transaction) Upsert(name string, data interface{}) error {
+	return obj.add(operationUpsert, name, data)
+}
+
+// Delete adds a file delete to the transaction
+func (obj *transaction) Delete(name string) error {
+	return obj.add(operationDelete, name, nil)
+}
+
+// Commit applies the operations of the transaction
+func (obj *transaction) Commit() error {
+	if obj.isDone {
+		return errors.New(transactionIsDoneErrorOutput)
+	}
+
+	obj.isDone = true
+	if len(obj.operations) <= 0 {
+		return nil
+	}
+
+	return obj.service.commit(obj.id, obj.operations)
+}
+
+// Rollback discards the operations of the transaction
+func (obj *transaction) Rollback() error {
+	if obj.isDone {
+		return errors.New(transactionIsDoneErrorOutput)
+	}
+
+	obj.isDone = true
+	obj.operations = []operation{}
+	return nil
+}
+
+func (obj *transaction) add(kind uint8, name string, data interface{}) error {
+	if obj.isDone {
+		return errors.New(transactionIsDoneErrorOutput)
+	}
+
+	op := operation{
+		kind: kind,
+		name: name,
+	}
+
+	if kind != operationDelete {
+		encoded, err := obj.service.encode(data)
+		if err != nil {
+			return err
+		}
+
+		op.data = encoded
+	}
+
+	operations := append(obj.operations[:len(obj.operations):len(obj.operations)], op)
+	err := obj.service.validate(operations)
+	if err != nil {
+		return err
+	}
+
+	obj.operations = operations
+	return nil
+}
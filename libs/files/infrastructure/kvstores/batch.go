@@ -0,0 +1,47 @@
+package kvstores
+
+type batch struct {
+	store      *store
+	operations []operation
+}
+
+func createBatch(
+	store *store,
+) Batch {
+	out := batch{
+		store:      store,
+		operations: []operation{},
+	}
+
+	return &out
+}
+
+// Put adds a put operation to the batch
+func (app *batch) Put(key string, value []byte) Batch {
+	app.operations = append(app.operations, operation{
+		kind:  operationPut,
+		key:   key,
+		value: value,
+	})
+
+	return app
+}
+
+// Delete adds a delete operation to the batch
+func (app *batch) Delete(key string) Batch {
+	app.operations = append(app.operations, operation{
+		kind: operationDelete,
+		key:  key,
+	})
+
+	return app
+}
+
+// Commit writes the batch to the store
+func (app *batch) Commit() error {
+	if len(app.operations) <= 0 {
+		return nil
+	}
+
+	return app.store.write(app.operations)
+}
@@ -0,0 +1,67 @@
+package kvstores
+
+import (
+	"bytes"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+type migration struct {
+	hydroAdapter hydro.Adapter
+	store        Store
+	prefix       string
+	ptr          interface{}
+}
+
+func createMigration(
+	hydroAdapter hydro.Adapter,
+	store Store,
+	prefix string,
+	ptr interface{},
+) files.Migration {
+	out := migration{
+		hydroAdapter: hydroAdapter,
+		store:        store,
+		prefix:       prefix,
+		ptr:          ptr,
+	}
+
+	return &out
+}
+
+// Execute upgrades every value of the prefix to the latest version, and returns the amount of rewritten values.  The
+// values are rewritten in one batch, so they are either all upgraded or not at all
+func (app *migration) Execute() (uint, error) {
+	amount := uint(0)
+	batch := app.store.Batch()
+	for _, oneKey := range app.store.Keys(toKey(app.prefix, "")) {
+		data, err := app.store.Retrieve(oneKey)
+		if err != nil {
+			return 0, err
+		}
+
+		upgraded, err := app.hydroAdapter.Upgrade(data, app.ptr)
+		if err != nil {
+			return 0, err
+		}
+
+		if bytes.Equal(data, upgraded) {
+			continue
+		}
+
+		batch.Put(oneKey, upgraded)
+		amount++
+	}
+
+	if amount <= 0 {
+		return 0, nil
+	}
+
+	err := batch.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,130 @@
+package kvstores
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+const (
+	operationPut uint8 = iota + 1
+	operationDelete
+)
+
+// the header of a record contains the length of its payload and the checksum of its payload:
+const recordHeaderSize = 8
+
+type operation struct {
+	kind  uint8
+	key   string
+	value []byte
+}
+
+// encodeRecord encodes operations to a record, and returns the offset of every put value in the record
+func encodeRecord(operations []operation) ([]byte, []int) {
+	payload := new(bytes.Buffer)
+	offsets := []int{}
+	writeUvarint(payload, uint64(len(operations)))
+	for _, oneOperation := range operations {
+		payload.WriteByte(oneOperation.kind)
+		writeUvarint(payload, uint64(len(oneOperation.key)))
+		payload.WriteString(oneOperation.key)
+		if oneOperation.kind != operationPut {
+			offsets = append(offsets, -1)
+			continue
+		}
+
+		writeUvarint(payload, uint64(len(oneOperation.value)))
+		offsets = append(offsets, recordHeaderSize+payload.Len())
+		payload.Write(oneOperation.value)
+	}
+
+	out := make([]byte, recordHeaderSize, recordHeaderSize+payload.Len())
+	binary.BigEndian.PutUint32(out[:4], uint32(payload.Len()))
+	binary.BigEndian.PutUint32(out[4:], crc32.ChecksumIEEE(payload.Bytes()))
+	return append(out, payload.Bytes()...), offsets
+}
+
+// decodeRecord decodes the payload of a record, and returns the offset of every put value in the record
+func decodeRecord(payload []byte) ([]operation, []int, error) {
+	reader := bytes.NewReader(payload)
+	amount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operations := []operation{}
+	offsets := []int{}
+	for i := uint64(0); i < amount; i++ {
+		kind, err := reader.ReadByte()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key, err := readLengthPrefixed(reader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if kind == operationDelete {
+			operations = append(operations, operation{kind: kind, key: string(key)})
+			offsets = append(offsets, -1)
+			continue
+		}
+
+		if kind != operationPut {
+			return nil, nil, errors.New("the record contains an invalid operation")
+		}
+
+		length, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// the value starts after its length prefix:
+		offset := recordHeaderSize + len(payload) - reader.Len()
+		value, err := readBytes(reader, length)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		operations = append(operations, operation{kind: kind, key: string(key), value: value})
+		offsets = append(offsets, offset)
+	}
+
+	return operations, offsets, nil
+}
+
+func readLengthPrefixed(reader *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return readBytes(reader, length)
+}
+
+func readBytes(reader *bytes.Reader, length uint64) ([]byte, error) {
+	if length > uint64(reader.Len()) {
+		return nil, errors.New("the record is truncated")
+	}
+
+	out := make([]byte, length)
+	if length == 0 {
+		return out, nil
+	}
+
+	_, err := reader.Read(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func writeUvarint(buffer *bytes.Buffer, number uint64) {
+	bytes := make([]byte, binary.MaxVarintLen64)
+	amount := binary.PutUvarint(bytes, number)
+	buffer.Write(bytes[:amount])
+}
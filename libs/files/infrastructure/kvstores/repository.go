@@ -0,0 +1,136 @@
+package kvstores
+
+import (
+	"strings"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+	uuid "github.com/satori/go.uuid"
+)
+
+type repository struct {
+	hashAdapter  hash.Adapter
+	hydroAdapter hydro.Adapter
+	store        Store
+	prefix       string
+	ptr          interface{}
+}
+
+func createRepository(
+	hashAdapter hash.Adapter,
+	hydroAdapter hydro.Adapter,
+	store Store,
+	prefix string,
+	ptr interface{},
+) files.Repository {
+	out := repository{
+		hashAdapter:  hashAdapter,
+		hydroAdapter: hydroAdapter,
+		store:        store,
+		prefix:       prefix,
+		ptr:          ptr,
+	}
+
+	return &out
+}
+
+// List lists the hashes, in the order of their keys
+func (app *repository) List() ([]hash.Hash, error) {
+	out, _, err := app.ListPage("", 0)
+	return out, err
+}
+
+// ListPage lists a page of hashes after the cursor, in the order of their keys
+func (app *repository) ListPage(cursor string, amount uint) ([]hash.Hash, string, error) {
+	out := []hash.Hash{}
+	names, next := app.page(cursor, amount, func(name string) bool {
+		_, err := app.hashAdapter.FromString(name)
+		return err == nil
+	})
+
+	for _, oneName := range names {
+		hsh, err := app.hashAdapter.FromString(oneName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		out = append(out, *hsh)
+	}
+
+	return out, next, nil
+}
+
+// ListIDs list the ids, in the order of their keys
+func (app *repository) ListIDs() ([]*uuid.UUID, error) {
+	out, _, err := app.ListIDsPage("", 0)
+	return out, err
+}
+
+// ListIDsPage lists a page of ids after the cursor, in the order of their keys
+func (app *repository) ListIDsPage(cursor string, amount uint) ([]*uuid.UUID, string, error) {
+	out := []*uuid.UUID{}
+	names, next := app.page(cursor, amount, func(name string) bool {
+		_, err := uuid.FromString(name)
+		return err == nil
+	})
+
+	for _, oneName := range names {
+		id, err := uuid.FromString(oneName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		out = append(out, &id)
+	}
+
+	return out, next, nil
+}
+
+// Retrieve retrieves a file by name
+func (app *repository) Retrieve(name string) (interface{}, error) {
+	data, err := app.store.Retrieve(toKey(app.prefix, name))
+	if err != nil {
+		return nil, err
+	}
+
+	if app.ptr == nil {
+		return data, nil
+	}
+
+	return app.hydroAdapter.Decode(data, app.ptr)
+}
+
+// page returns the names accepted by the filter after the cursor, and the cursor of the next page.  An amount of zero
+// returns every name
+func (app *repository) page(cursor string, amount uint, accept func(name string) bool) ([]string, string) {
+	keyPrefix := toKey(app.prefix, "")
+	after := toKey(app.prefix, cursor)
+	out := []string{}
+	for {
+		keys := app.store.KeysAfter(keyPrefix, after, amount)
+		for _, oneKey := range keys {
+			name := strings.TrimPrefix(oneKey, keyPrefix)
+			if !accept(name) {
+				continue
+			}
+
+			if amount > 0 && uint(len(out)) >= amount {
+				return out, out[len(out)-1]
+			}
+
+			out = append(out, name)
+		}
+
+		// the keys are exhausted:
+		if amount == 0 || uint(len(keys)) < amount {
+			return out, ""
+		}
+
+		after = keys[len(keys)-1]
+	}
+}
+
+func toKey(prefix string, name string) string {
+	return strings.Join([]string{prefix, name}, keyDelimiter)
+}
@@ -0,0 +1,84 @@
+package kvstores
+
+import (
+	"os"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+const keyDoesNotExistsPattern = "the key (%s) does not exists"
+
+const keyAlreadyExistsPattern = "the key (%s) already exists"
+
+const transactionIsDoneErrorOutput = "the transaction has already been committed or rolled back"
+
+const storeIsClosedErrorOutput = "the store is closed"
+
+const keyDelimiter = "/"
+
+// DefaultCompactionSize represents the default size, in bytes, under which a store is never compacted automatically
+const DefaultCompactionSize = 1024 * 1024
+
+// NewStore opens the store of the given file, creating it if it does not exists.  Once the file is bigger than the
+// DefaultCompactionSize, it is compacted after a batch when more than half of its bytes are dead
+func NewStore(path string, fileMode os.FileMode) (Store, error) {
+	return NewStoreWithCompactionSize(path, fileMode, DefaultCompactionSize)
+}
+
+// NewStoreWithCompactionSize opens the store of the given file, compacted automatically once bigger than the given size
+func NewStoreWithCompactionSize(path string, fileMode os.FileMode, compactionSize int64) (Store, error) {
+	return openStore(path, fileMode, compactionSize)
+}
+
+// NewRepository creates a new repository instance, on the keys of the given prefix
+func NewRepository(
+	hydroAdapter hydro.Adapter,
+	store Store,
+	prefix string,
+	ptr interface{},
+) files.Repository {
+	hashAdapter := hash.NewAdapter()
+	return createRepository(hashAdapter, hydroAdapter, store, prefix, ptr)
+}
+
+// NewService creates a new service instance, on the keys of the given prefix
+func NewService(
+	hydroAdapter hydro.Adapter,
+	store Store,
+	prefix string,
+	format hydro.Format,
+) files.Service {
+	return createService(hydroAdapter, store, prefix, format)
+}
+
+// NewMigration creates a new migration instance, that upgrades the values of the given prefix to the latest version of
+// the pointer
+func NewMigration(
+	hydroAdapter hydro.Adapter,
+	store Store,
+	prefix string,
+	ptr interface{},
+) files.Migration {
+	return createMigration(hydroAdapter, store, prefix, ptr)
+}
+
+// Store represents an embedded, single file, log-structured key-value store.  Every batch is appended to the file as
+// one checksummed record, so a batch is either entirely applied or not at all after a crash
+type Store interface {
+	Has(key string) bool
+	Retrieve(key string) ([]byte, error)
+	Keys(prefix string) []string
+	KeysAfter(prefix string, after string, amount uint) []string
+	Batch() Batch
+	Compact() error
+	Close() error
+}
+
+// Batch represents a batch of store changes
+type Batch interface {
+	Put(key string, value []byte) Batch
+	Delete(key string) Batch
+	Commit() error
+}
@@ -0,0 +1,139 @@
+package kvstores
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+type service struct {
+	hydroAdapter hydro.Adapter
+	store        Store
+	prefix       string
+	format       hydro.Format
+	mutex        *sync.Mutex
+}
+
+func createService(
+	hydroAdapter hydro.Adapter,
+	store Store,
+	prefix string,
+	format hydro.Format,
+) files.Service {
+	out := service{
+		hydroAdapter: hydroAdapter,
+		store:        store,
+		prefix:       prefix,
+		format:       format,
+		mutex:        new(sync.Mutex),
+	}
+
+	return &out
+}
+
+// Insert inserts a file
+func (app *service) Insert(name string, ins interface{}) error {
+	trx := app.Begin()
+	err := trx.Insert(name, ins)
+	if err != nil {
+		return err
+	}
+
+	return trx.Commit()
+}
+
+// Update updates a file
+func (app *service) Update(name string, ins interface{}) error {
+	trx := app.Begin()
+	err := trx.Update(name, ins)
+	if err != nil {
+		return err
+	}
+
+	return trx.Commit()
+}
+
+// Delete deletes a file
+func (app *service) Delete(name string) error {
+	trx := app.Begin()
+	err := trx.Delete(name)
+	if err != nil {
+		return err
+	}
+
+	return trx.Commit()
+}
+
+// Begin begins a transaction
+func (app *service) Begin() files.Transaction {
+	return createTransaction(app)
+}
+
+func (app *service) encode(ins interface{}) ([]byte, error) {
+	if str, ok := ins.(string); ok {
+		return []byte(str), nil
+	}
+
+	if bytes, ok := ins.([]byte); ok {
+		return bytes, nil
+	}
+
+	return app.hydroAdapter.EncodeWithFormat(ins, app.format)
+}
+
+// validate verifies that the operations can be applied on the current keys
+func (app *service) validate(operations []transactionOperation) error {
+	exists := map[string]bool{}
+	for _, oneOperation := range operations {
+		key := toKey(app.prefix, oneOperation.name)
+		isExisting, ok := exists[key]
+		if !ok {
+			isExisting = app.store.Has(key)
+		}
+
+		if oneOperation.kind == transactionUpsert {
+			exists[key] = true
+			continue
+		}
+
+		if oneOperation.kind == transactionInsert && isExisting {
+			str := fmt.Sprintf(keyAlreadyExistsPattern, key)
+			return errors.New(str)
+		}
+
+		if oneOperation.kind != transactionInsert && !isExisting {
+			str := fmt.Sprintf(keyDoesNotExistsPattern, key)
+			return errors.New(str)
+		}
+
+		exists[key] = oneOperation.kind != transactionDelete
+	}
+
+	return nil
+}
+
+func (app *service) commit(operations []transactionOperation) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	err := app.validate(operations)
+	if err != nil {
+		return err
+	}
+
+	batch := app.store.Batch()
+	for _, oneOperation := range operations {
+		key := toKey(app.prefix, oneOperation.name)
+		if oneOperation.kind == transactionDelete {
+			batch.Delete(key)
+			continue
+		}
+
+		batch.Put(key, oneOperation.data)
+	}
+
+	return batch.Commit()
+}
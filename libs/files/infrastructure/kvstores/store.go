@@ -0,0 +1,352 @@
+package kvstores
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type entry struct {
+	offset int64
+	length int
+}
+
+type store struct {
+	path           string
+	fileMode       os.FileMode
+	compactionSize int64
+	file           *os.File
+	size           int64
+	live           int64
+	index          map[string]entry
+	keys           []string
+	mutex          sync.RWMutex
+}
+
+func openStore(
+	path string,
+	fileMode os.FileMode,
+	compactionSize int64,
+) (Store, error) {
+	out := store{
+		path:           path,
+		fileMode:       fileMode,
+		compactionSize: compactionSize,
+		file:           nil,
+		size:           0,
+		live:           0,
+		index:          map[string]entry{},
+		keys:           []string{},
+	}
+
+	err := out.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// Has returns true if the key exists, false otherwise
+func (app *store) Has(key string) bool {
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+	_, ok := app.index[key]
+	return ok
+}
+
+// Retrieve retrieves the value of a key
+func (app *store) Retrieve(key string) ([]byte, error) {
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+
+	if app.file == nil {
+		return nil, errors.New(storeIsClosedErrorOutput)
+	}
+
+	if ent, ok := app.index[key]; ok {
+		out := make([]byte, ent.length)
+		_, err := app.file.ReadAt(out, ent.offset)
+		if err != nil {
+			return nil, err
+		}
+
+		return out, nil
+	}
+
+	str := fmt.Sprintf(keyDoesNotExistsPattern, key)
+	return nil, errors.New(str)
+}
+
+// Keys returns the keys that start with the prefix, in order
+func (app *store) Keys(prefix string) []string {
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+
+	out := []string{}
+	for i := sort.SearchStrings(app.keys, prefix); i < len(app.keys); i++ {
+		if !strings.HasPrefix(app.keys[i], prefix) {
+			break
+		}
+
+		out = append(out, app.keys[i])
+	}
+
+	return out
+}
+
+// KeysAfter returns, in order, the keys that start with the prefix and come after the given key.  An amount of zero
+// returns every key
+func (app *store) KeysAfter(prefix string, after string, amount uint) []string {
+	app.mutex.RLock()
+	defer app.mutex.RUnlock()
+
+	start := sort.SearchStrings(app.keys, prefix)
+	if after > prefix {
+		start = sort.SearchStrings(app.keys, after)
+	}
+
+	out := []string{}
+	for i := start; i < len(app.keys); i++ {
+		if !strings.HasPrefix(app.keys[i], prefix) {
+			break
+		}
+
+		if app.keys[i] == after {
+			continue
+		}
+
+		if amount > 0 && uint(len(out)) >= amount {
+			break
+		}
+
+		out = append(out, app.keys[i])
+	}
+
+	return out
+}
+
+// Batch creates a new batch
+func (app *store) Batch() Batch {
+	return createBatch(app)
+}
+
+// Compact rewrites the store with only its live values
+func (app *store) Compact() error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.file == nil {
+		return errors.New(storeIsClosedErrorOutput)
+	}
+
+	return app.compact()
+}
+
+func (app *store) compact() error {
+	operations := []operation{}
+	for _, oneKey := range app.keys {
+		ent := app.index[oneKey]
+		value := make([]byte, ent.length)
+		_, err := app.file.ReadAt(value, ent.offset)
+		if err != nil {
+			return err
+		}
+
+		operations = append(operations, operation{
+			kind:  operationPut,
+			key:   oneKey,
+			value: value,
+		})
+	}
+
+	tempPath := fmt.Sprintf("%s.tmp", app.path)
+	temp, err := os.OpenFile(tempPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, app.fileMode)
+	if err != nil {
+		return err
+	}
+
+	if len(operations) > 0 {
+		record, _ := encodeRecord(operations)
+		_, err = temp.Write(record)
+		if err != nil {
+			temp.Close()
+			return err
+		}
+	}
+
+	err = temp.Sync()
+	if err != nil {
+		temp.Close()
+		return err
+	}
+
+	err = temp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tempPath, app.path)
+	if err != nil {
+		return err
+	}
+
+	app.file.Close()
+	return app.open()
+}
+
+// Close closes the store
+func (app *store) Close() error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.file == nil {
+		return errors.New(storeIsClosedErrorOutput)
+	}
+
+	err := app.file.Close()
+	app.file = nil
+	return err
+}
+
+func (app *store) write(operations []operation) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.file == nil {
+		return errors.New(storeIsClosedErrorOutput)
+	}
+
+	record, offsets := encodeRecord(operations)
+	_, err := app.file.WriteAt(record, app.size)
+	if err != nil {
+		return err
+	}
+
+	err = app.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	app.apply(app.size, operations, offsets)
+	app.size += int64(len(record))
+	if !app.isCompactable() {
+		return nil
+	}
+
+	err = app.compact()
+	if err != nil {
+		str := fmt.Sprintf("the batch was committed but the store could not be compacted: %s", err.Error())
+		return errors.New(str)
+	}
+
+	return nil
+}
+
+// isCompactable returns true if the file is bigger than the compaction size and more than half of its bytes are dead.
+// The keys and values are the live bytes; the headers of the records are counted as dead
+func (app *store) isCompactable() bool {
+	if app.compactionSize <= 0 || app.size < app.compactionSize {
+		return false
+	}
+
+	return app.size-app.live > app.size/2
+}
+
+// open opens the file and rebuilds the index from its records.  A torn record at the end of the file, left by a
+// crash during a write, is truncated
+func (app *store) open() error {
+	file, err := os.OpenFile(app.path, os.O_RDWR|os.O_CREATE, app.fileMode)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	app.file = file
+	app.size = 0
+	app.live = 0
+	app.index = map[string]entry{}
+	app.keys = []string{}
+
+	header := make([]byte, recordHeaderSize)
+	for {
+		_, err := file.ReadAt(header, app.size)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		length := binary.BigEndian.Uint32(header[:4])
+		if int64(length) > info.Size()-app.size-recordHeaderSize {
+			break
+		}
+
+		payload := make([]byte, length)
+		_, err = file.ReadAt(payload, app.size+recordHeaderSize)
+		if err == io.EOF || crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		operations, offsets, err := decodeRecord(payload)
+		if err != nil {
+			break
+		}
+
+		app.apply(app.size, operations, offsets)
+		app.size += int64(recordHeaderSize + len(payload))
+	}
+
+	return file.Truncate(app.size)
+}
+
+func (app *store) apply(recordOffset int64, operations []operation, offsets []int) {
+	for index, oneOperation := range operations {
+		previous, exists := app.index[oneOperation.key]
+		if exists {
+			app.live -= int64(len(oneOperation.key) + previous.length)
+		}
+
+		if oneOperation.kind == operationDelete {
+			if !exists {
+				continue
+			}
+
+			delete(app.index, oneOperation.key)
+			position := sort.SearchStrings(app.keys, oneOperation.key)
+			app.keys = append(app.keys[:position], app.keys[position+1:]...)
+			continue
+		}
+
+		app.index[oneOperation.key] = entry{
+			offset: recordOffset + int64(offsets[index]),
+			length: len(oneOperation.value),
+		}
+
+		app.live += int64(len(oneOperation.key) + len(oneOperation.value))
+
+		if !exists {
+			position := sort.SearchStrings(app.keys, oneOperation.key)
+			app.keys = append(app.keys, "")
+			copy(app.keys[position+1:], app.keys[position:])
+			app.keys[position] = oneOperation.key
+		}
+	}
+}
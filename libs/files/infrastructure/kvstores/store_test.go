@@ -0,0 +1,315 @@
+package kvstores
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStore_Success(t *testing.T) {
+	basePath := "./test_files"
+	path := filepath.Join(basePath, "store.kv")
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, err := NewStore(path, 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = store.Batch().Put("b/2", []byte("b2")).Put("a/1", []byte("a1")).Put("b/1", []byte("b1")).Put("c/1", []byte("")).Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = store.Batch().Delete("a/1").Put("b/2", []byte("updated")).Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	verify := func(store Store) bool {
+		if !reflect.DeepEqual(store.Keys("b/"), []string{"b/1", "b/2"}) {
+			t.Errorf("the prefixed keys are invalid: %v", store.Keys("b/"))
+			return false
+		}
+
+		if !reflect.DeepEqual(store.Keys(""), []string{"b/1", "b/2", "c/1"}) {
+			t.Errorf("the keys are invalid: %v", store.Keys(""))
+			return false
+		}
+
+		value, err := store.Retrieve("b/2")
+		if err != nil || string(value) != "updated" {
+			t.Errorf("the value of the key was expected to be updated")
+			return false
+		}
+
+		if store.Has("a/1") {
+			t.Errorf("the deleted key was not expected to exist")
+			return false
+		}
+
+		return true
+	}
+
+	if !verify(store) {
+		return
+	}
+
+	// reopen:
+	store.Close()
+	store, err = NewStore(path, 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !verify(store) {
+		return
+	}
+
+	// compact:
+	before, _ := os.Stat(path)
+	err = store.Compact()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	after, _ := os.Stat(path)
+	if after.Size() >= before.Size() {
+		t.Errorf("the compacted store (%d bytes) was expected to be smaller than %d bytes", after.Size(), before.Size())
+		return
+	}
+
+	if !verify(store) {
+		return
+	}
+
+	store.Close()
+}
+
+func TestStore_tornRecord_isTruncated_Success(t *testing.T) {
+	basePath := "./test_files"
+	path := filepath.Join(basePath, "store.kv")
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, _ := NewStore(path, 0777)
+	store.Batch().Put("first", []byte("first")).Commit()
+	store.Batch().Put("second", []byte("second")).Put("third", []byte("third")).Commit()
+	store.Close()
+
+	// a crash in the middle of the last record:
+	info, _ := os.Stat(path)
+	os.Truncate(path, info.Size()-3)
+
+	store, err := NewStore(path, 0777)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer store.Close()
+	if !reflect.DeepEqual(store.Keys(""), []string{"first"}) {
+		t.Errorf("the torn batch was expected to be discarded entirely: %v", store.Keys(""))
+		return
+	}
+
+	err = store.Batch().Put("fourth", []byte("fourth")).Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	value, _ := store.Retrieve("fourth")
+	if string(value) != "fourth" {
+		t.Errorf("the value written after the truncation is invalid")
+		return
+	}
+}
+
+func TestStore_withDeadBytes_isCompacted_Success(t *testing.T) {
+	basePath := "./test_files"
+	path := filepath.Join(basePath, "store.kv")
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, err := NewStoreWithCompactionSize(path, 0777, 256)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer store.Close()
+
+	// overwrite the same key, so that every previous value is dead:
+	value := []byte("0123456789012345678901234567890123456789")
+	for i := 0; i < 100; i++ {
+		err := store.Batch().Put("key", value).Commit()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	info, _ := os.Stat(path)
+	if info.Size() >= 256 {
+		t.Errorf("the store (%d bytes) was expected to be compacted under %d bytes", info.Size(), 256)
+		return
+	}
+
+	retValue, _ := store.Retrieve("key")
+	if !reflect.DeepEqual(retValue, value) {
+		t.Errorf("the value was expected to be kept by the compaction")
+		return
+	}
+}
+
+func TestRepository_listPage_Success(t *testing.T) {
+	basePath := "./test_files"
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, _ := NewStore(filepath.Join(basePath, "store.kv"), 0777)
+	defer store.Close()
+
+	ids := []string{
+		"0dfeba3e-5e8c-4d7f-8b5d-4f4ab6f1e6a1",
+		"3a1f5e6a-7d8c-4f9e-9c3d-2b1f0a3d7c8b",
+		"7c8b1a2e-0a3d-4f9e-9c3d-2b1f5e6a7d8c",
+	}
+
+	// the names that are not ids, and the keys of other prefixes, are skipped:
+	batch := store.Batch().Put("ida/other", []byte("")).Put("ids/head", []byte("")).Put("ids/1-invalid", []byte(""))
+	for _, oneID := range ids {
+		batch.Put(toKey("ids", oneID), []byte(oneID))
+	}
+
+	batch.Commit()
+	repository := NewRepository(nil, store, "ids", nil)
+
+	listed := []string{}
+	cursor := ""
+	for {
+		page, next, err := repository.ListIDsPage(cursor, 2)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		for _, oneID := range page {
+			listed = append(listed, oneID.String())
+		}
+
+		if next == "" {
+			break
+		}
+
+		cursor = next
+	}
+
+	if !reflect.DeepEqual(listed, ids) {
+		t.Errorf("the ids were expected to be listed in order, once: %v", listed)
+		return
+	}
+}
+
+func TestService_Success(t *testing.T) {
+	basePath := "./test_files"
+	os.MkdirAll(basePath, 0777)
+	defer func() {
+		os.RemoveAll(basePath)
+	}()
+
+	store, _ := NewStore(filepath.Join(basePath, "store.kv"), 0777)
+	defer store.Close()
+
+	service := NewService(nil, store, "ids", 0)
+	repository := NewRepository(nil, store, "ids", nil)
+
+	first := "0dfeba3e-5e8c-4d7f-8b5d-4f4ab6f1e6a1"
+	second := "7c8b1a2e-0a3d-4f9e-9c3d-2b1f5e6a7d8c"
+	trx := service.Begin()
+	trx.Insert(second, "second")
+	trx.Insert(first, "first")
+	err := trx.Insert(first, "again")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	err = trx.Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	ids, err := repository.ListIDs()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(ids) != 2 || ids[0].String() != first || ids[1].String() != second {
+		t.Errorf("the ids were expected to be listed in order")
+		return
+	}
+
+	err = service.Update(first, "updated")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data, _ := repository.Retrieve(first)
+	if string(data.([]byte)) != "updated" {
+		t.Errorf("the file was expected to be updated")
+		return
+	}
+
+	trx = service.Begin()
+	trx.Upsert(first, "upserted")
+	trx.Upsert("3e2f8a1b-6c4d-4e5f-8a7b-9c0d1e2f3a4b", "third")
+	err = trx.Commit()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data, _ = repository.Retrieve(first)
+	if string(data.([]byte)) != "upserted" {
+		t.Errorf("the file was expected to be upserted")
+		return
+	}
+
+	ids, _ = repository.ListIDs()
+	if len(ids) != 3 {
+		t.Errorf("the upserted file was expected to be inserted")
+		return
+	}
+
+	err = service.Delete(second)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = service.Delete(second)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -0,0 +1,112 @@
+package kvstores
+
+import (
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+)
+
+const (
+	transactionInsert uint8 = iota + 1
+	transactionUpdate
+	transactionDelete
+	transactionUpsert
+)
+
+type transactionOperation struct {
+	kind uint8
+	name string
+	data []byte
+}
+
+type transaction struct {
+	service    *service
+	operations []transactionOperation
+	isDone     bool
+}
+
+func createTransaction(
+	service *service,
+) files.Transaction {
+	out := transaction{
+		service:    service,
+		operations: []transactionOperation{},
+		isDone:     false,
+	}
+
+	return &out
+}
+
+// Insert adds a file insert to the transaction
+func (obj *transaction) Insert(name string, data interface{}) error {
+	return obj.add(transactionInsert, name, data)
+}
+
+// Update adds a file update to the transaction
+func (obj *transaction) Update(name string, data interface{}) error {
+	return obj.add(transactionUpdate, name, data)
+}
+
+// Upsert adds a file insert to the transaction, or a file update if the file already exists
+func (obj *transaction) Upsert(name string, data interface{}) error {
+	return obj.add(transactionUpsert, name, data)
+}
+
+// Delete adds a file delete to the transaction
+func (obj *transaction) Delete(name string) error {
+	return obj.add(transactionDelete, name, nil)
+}
+
+// Commit writes the operations of the transaction as one batch
+func (obj *transaction) Commit() error {
+	if obj.isDone {
+		return errors.New(transactionIsDoneErrorOutput)
+	}
+
+	obj.isDone = true
+	if len(obj.operations) <= 0 {
+		return nil
+	}
+
+	return obj.service.commit(obj.operations)
+}
+
+// Rollback discards the operations of the transaction
+func (obj *transaction) Rollback() error {
+	if obj.isDone {
+		return errors.New(transactionIsDoneErrorOutput)
+	}
+
+	obj.isDone = true
+	obj.operations = []transactionOperation{}
+	return nil
+}
+
+func (obj *transaction) add(kind uint8, name string, data interface{}) error {
+	if obj.isDone {
+		return errors.New(transactionIsDoneErrorOutput)
+	}
+
+	op := transactionOperation{
+		kind: kind,
+		name: name,
+	}
+
+	if kind != transactionDelete {
+		encoded, err := obj.service.encode(data)
+		if err != nil {
+			return err
+		}
+
+		op.data = encoded
+	}
+
+	operations := append(obj.operations[:len(obj.operations):len(obj.operations)], op)
+	err := obj.service.validate(operations)
+	if err != nil {
+		return err
+	}
+
+	obj.operations = operations
+	return nil
+}
@@ -1,59 +1,86 @@
 package hash
 
 import (
-	"crypto/sha512"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
 )
 
 type adapter struct {
+	algorithm Algorithm
 }
 
-func createAdapter() Adapter {
-	out := adapter{}
+func createAdapter(
+	algorithm Algorithm,
+) Adapter {
+	out := adapter{
+		algorithm: algorithm,
+	}
+
 	return &out
 }
 
-// FromBytes converts []byte to an Hash
-func (app *adapter) FromBytes(input []byte) (*Hash, error) {
-	if len(input) != 64 {
-		h := sha512.New()
-		_, err := h.Write(input)
-		if err != nil {
-			return nil, err
-		}
-
-		input = h.Sum(nil)
-	}
+// Algorithm returns the algorithm used by the adapter
+func (app *adapter) Algorithm() Algorithm {
+	return app.algorithm
+}
 
-	out := Hash{}
-	for index, oneByte := range input {
-		out[index] = oneByte
+// Hash hashes the input and returns its Hash
+func (app *adapter) Hash(input []byte) (*Hash, error) {
+	digest, err := app.algorithm.sum(input)
+	if err != nil {
+		return nil, err
 	}
 
+	out := createHash(app.algorithm, digest)
 	return &out, nil
 }
 
-// FromMultiBytes converts multiple []byte to an Hash
+// Wrap converts an already computed digest to an Hash, without hashing it
+func (app *adapter) Wrap(digest []byte) (*Hash, error) {
+	return wrap(app.algorithm, digest)
+}
+
+// FromMultiBytes hashes multiple []byte, once concatenated, and returns its Hash
 func (app *adapter) FromMultiBytes(input [][]byte) (*Hash, error) {
 	merged := []byte{}
 	for _, oneRow := range input {
 		merged = append(merged, oneRow...)
 	}
 
-	return app.FromBytes(merged)
+	return app.Hash(merged)
 }
 
-// FromString converts a string to an Hash
+// FromString converts the string of an Hash back to an Hash
 func (app *adapter) FromString(input string) (*Hash, error) {
-	bytes, err := hex.DecodeString(input)
-	if err != nil {
-		return nil, err
+	algorithm := DefaultAlgorithm
+	encoded := input
+	if strings.Contains(input, algorithmDelimiter) {
+		sections := strings.SplitN(input, algorithmDelimiter, 2)
+		retAlgorithm, err := createAlgorithmFromName(sections[0])
+		if err != nil {
+			return nil, err
+		}
+
+		algorithm = retAlgorithm
+		encoded = sections[1]
 	}
 
-	hash, err := app.FromBytes(bytes)
+	digest, err := hex.DecodeString(encoded)
 	if err != nil {
 		return nil, err
 	}
 
-	return hash, nil
+	return wrap(algorithm, digest)
+}
+
+func wrap(algorithm Algorithm, digest []byte) (*Hash, error) {
+	if len(digest) != algorithm.Size() {
+		str := fmt.Sprintf("the digest was expected to contain %d bytes in order to be a valid %s hash, %d provided", algorithm.Size(), algorithm.String(), len(digest))
+		return nil, errors.New(str)
+	}
+
+	out := createHash(algorithm, digest)
+	return &out, nil
 }
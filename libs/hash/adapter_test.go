@@ -0,0 +1,114 @@
+package hash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAdapter_algorithms_Success(t *testing.T) {
+	algorithms := []Algorithm{
+		SHA512,
+		SHA256,
+		BLAKE2b,
+	}
+
+	input := []byte("this is some data to hash")
+	for _, oneAlgorithm := range algorithms {
+		adapter, err := NewAdapterWithAlgorithm(oneAlgorithm)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hsh, err := adapter.Hash(input)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if hsh.Algorithm() != oneAlgorithm {
+			t.Errorf("the hash algorithm was expected to be %s, %s returned", oneAlgorithm.String(), hsh.Algorithm().String())
+			return
+		}
+
+		if len(hsh.Bytes()) != oneAlgorithm.Size() {
+			t.Errorf("the %s hash was expected to contain %d bytes, %d returned", oneAlgorithm.String(), oneAlgorithm.Size(), len(hsh.Bytes()))
+			return
+		}
+
+		wrapped, err := adapter.Wrap(hsh.Bytes())
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !wrapped.Compare(*hsh) {
+			t.Errorf("the wrapped hash was expected to be the same as the hash")
+			return
+		}
+
+		retHash, err := NewAdapter().FromString(hsh.String())
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !retHash.Compare(*hsh) {
+			t.Errorf("the hash (%s) was expected to be the same after a string conversion, %s returned", hsh.String(), retHash.String())
+			return
+		}
+	}
+}
+
+func TestAdapter_hash64Bytes_isHashed_Success(t *testing.T) {
+	input := bytes.Repeat([]byte("a"), 64)
+	hsh, err := NewAdapter().Hash(input)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(hsh.Bytes(), input) == 0 {
+		t.Errorf("the 64 bytes input was expected to be hashed")
+		return
+	}
+}
+
+func TestAdapter_differentAlgorithms_doNotCompare_Success(t *testing.T) {
+	input := []byte("this is some data to hash")
+	sha512Hash, _ := NewAdapter().Hash(input)
+	blake2bAdapter, _ := NewAdapterWithAlgorithm(BLAKE2b)
+	blake2bHash, _ := blake2bAdapter.Hash(input)
+	wrapped, _ := blake2bAdapter.Wrap(sha512Hash.Bytes())
+	if wrapped.Compare(*sha512Hash) {
+		t.Errorf("hashes of different algorithms were expected to be different")
+		return
+	}
+
+	if blake2bHash.Compare(*sha512Hash) {
+		t.Errorf("hashes of different algorithms were expected to be different")
+		return
+	}
+}
+
+func TestAdapter_wrap_invalidLength_returnsError(t *testing.T) {
+	_, err := NewAdapter().Wrap([]byte("too short"))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestAdapter_invalidAlgorithm_returnsError(t *testing.T) {
+	_, err := NewAdapterWithAlgorithm(Algorithm(0))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	_, err = ToAlgorithm("md5")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -0,0 +1,75 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+var algorithmNames = map[Algorithm]string{
+	SHA512:  "sha512",
+	SHA256:  "sha256",
+	BLAKE2b: "blake2b",
+}
+
+func createAlgorithmFromName(name string) (Algorithm, error) {
+	for algorithm, oneName := range algorithmNames {
+		if oneName == name {
+			return algorithm, nil
+		}
+	}
+
+	str := fmt.Sprintf("the hash algorithm (name: %s) is not supported", name)
+	return 0, errors.New(str)
+}
+
+// String returns the name of the algorithm
+func (obj Algorithm) String() string {
+	if name, ok := algorithmNames[obj]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", obj)
+}
+
+// Size returns the size of the digests produced by the algorithm
+func (obj Algorithm) Size() int {
+	switch obj {
+	case SHA512:
+		return sha512.Size
+	case SHA256:
+		return sha256.Size
+	case BLAKE2b:
+		return blake2b.Size
+	}
+
+	return 0
+}
+
+func (obj Algorithm) validate() error {
+	if _, ok := algorithmNames[obj]; !ok {
+		str := fmt.Sprintf("the hash algorithm (%d) is not supported", obj)
+		return errors.New(str)
+	}
+
+	return nil
+}
+
+func (obj Algorithm) sum(input []byte) ([]byte, error) {
+	switch obj {
+	case SHA512:
+		digest := sha512.Sum512(input)
+		return digest[:], nil
+	case SHA256:
+		digest := sha256.Sum256(input)
+		return digest[:], nil
+	case BLAKE2b:
+		digest := blake2b.Sum512(input)
+		return digest[:], nil
+	}
+
+	return nil, obj.validate()
+}
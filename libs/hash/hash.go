@@ -1,37 +1,41 @@
 package hash
 
 import (
-	"bytes"
 	"fmt"
 )
 
-// Compare returns true if the current hash is the same as the passed one
-func (obj Hash) Compare(other Hash) bool {
-	currentBytes := []byte{}
-	for _, oneByte := range obj {
-		currentBytes = append(currentBytes, oneByte)
+func createHash(algorithm Algorithm, digest []byte) Hash {
+	out := Hash{
+		algorithm: algorithm,
 	}
 
-	otherBytes := []byte{}
-	for _, oneByte := range other {
-		otherBytes = append(otherBytes, oneByte)
-	}
+	copy(out.digest[:], digest)
+	return out
+}
 
-	return bytes.Compare(currentBytes, otherBytes) == 0
+// Algorithm returns the algorithm of the hash
+func (obj Hash) Algorithm() Algorithm {
+	return obj.algorithm
+}
+
+// Compare returns true if the current hash is the same as the passed one
+func (obj Hash) Compare(other Hash) bool {
+	return obj == other
 }
 
 // Bytes returns the bytes of an hash
 func (obj Hash) Bytes() []byte {
-	out := []byte{}
-	for _, oneByte := range obj {
-		out = append(out, oneByte)
-	}
-
+	out := make([]byte, obj.algorithm.Size())
+	copy(out, obj.digest[:])
 	return out
 }
 
-// String returns the string of an hash
+// String returns the string of an hash, prefixed by its algorithm when it is not the default one
 func (obj Hash) String() string {
 	bytes := obj.Bytes()
-	return fmt.Sprintf("%x", bytes)
+	if obj.algorithm == DefaultAlgorithm {
+		return fmt.Sprintf("%x", bytes)
+	}
+
+	return fmt.Sprintf("%s%s%x", obj.algorithm.String(), algorithmDelimiter, bytes)
 }
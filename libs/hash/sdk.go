@@ -2,17 +2,65 @@ package hash
 
 const amountLettersPerFolder = 16
 
-// NewAdapter returns a new hash adapter
+const (
+	// SHA512 represents the sha-512 hash algorithm
+	SHA512 Algorithm = iota + 1
+
+	// SHA256 represents the sha-256 hash algorithm
+	SHA256
+
+	// BLAKE2b represents the blake2b-512 hash algorithm
+	BLAKE2b
+)
+
+// DefaultAlgorithm represents the default hash algorithm
+const DefaultAlgorithm = SHA512
+
+// algorithmDelimiter separates the algorithm name from the digest in an hash string
+const algorithmDelimiter = ":"
+
+// maxDigestSize represents the biggest digest size of all the supported algorithms
+const maxDigestSize = 64
+
+// NewAdapter returns a new hash adapter, using the default algorithm
 func NewAdapter() Adapter {
-	return createAdapter()
+	return createAdapter(DefaultAlgorithm)
 }
 
-// Hash represents a hash
-type Hash [64]byte
+// NewAdapterWithAlgorithm returns a new hash adapter, using the given algorithm
+func NewAdapterWithAlgorithm(algorithm Algorithm) (Adapter, error) {
+	err := algorithm.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return createAdapter(algorithm), nil
+}
+
+// NewAdapterFromHash returns a new hash adapter, using the algorithm of the given hash
+func NewAdapterFromHash(hsh Hash) Adapter {
+	return createAdapter(hsh.algorithm)
+}
+
+// ToAlgorithm converts an algorithm name to an Algorithm
+func ToAlgorithm(name string) (Algorithm, error) {
+	return createAlgorithmFromName(name)
+}
+
+// Algorithm represents an hash algorithm
+type Algorithm uint8
+
+// Hash represents an algorithm-tagged hash
+type Hash struct {
+	algorithm Algorithm
+	digest    [maxDigestSize]byte
+}
 
 // Adapter represents an hash adapter
 type Adapter interface {
-	FromBytes(input []byte) (*Hash, error)
+	Algorithm() Algorithm
+	Hash(input []byte) (*Hash, error)
+	Wrap(digest []byte) (*Hash, error)
 	FromMultiBytes(input [][]byte) (*Hash, error)
 	FromString(input string) (*Hash, error)
 }
@@ -0,0 +1,185 @@
+package hashtree
+
+import (
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type appendable struct {
+	hashAdapter hash.Adapter
+	zeros       []hash.Hash
+	frontier    []hash.Hash
+	leaves      []hash.Hash
+}
+
+func createAppendable(
+	hashAdapter hash.Adapter,
+) *appendable {
+	out := appendable{
+		hashAdapter: hashAdapter,
+		zeros:       []hash.Hash{},
+		frontier:    []hash.Hash{},
+		leaves:      []hash.Hash{},
+	}
+
+	return &out
+}
+
+// Length returns the amount of blocks appended to the tree
+func (obj *appendable) Length() int {
+	return len(obj.leaves)
+}
+
+// Leaves returns the block hashes, in the order they were appended
+func (obj *appendable) Leaves() []hash.Hash {
+	return obj.leaves
+}
+
+// Frontier returns the frontier, the head of each complete sub tree indexed by height
+func (obj *appendable) Frontier() []hash.Hash {
+	return obj.frontier
+}
+
+// Append appends blocks to the tree
+func (obj *appendable) Append(blocks [][]byte) error {
+	for _, oneBlock := range blocks {
+		leafHash, err := obj.hashAdapter.Hash(oneBlock)
+		if err != nil {
+			return err
+		}
+
+		err = obj.appendLeaf(*leafHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Head returns the head hash, the same as a HashTree built with the same blocks
+func (obj *appendable) Head() (*hash.Hash, error) {
+	length := len(obj.leaves)
+	if length <= 0 {
+		return nil, errors.New("the head of an empty appendable HashTree cannot be computed")
+	}
+
+	// a complete tree has its head on top of the frontier:
+	height := 0
+	for (1 << uint(height)) < length {
+		height++
+	}
+
+	if height > 0 && (1<<uint(height)) == length {
+		head := obj.frontier[height]
+		return &head, nil
+	}
+
+	if height <= 0 {
+		height = 1
+	}
+
+	// fold the frontier with the filling sub trees:
+	zero, err := obj.zero(0)
+	if err != nil {
+		return nil, err
+	}
+
+	node := *zero
+	size := length
+	for level := 0; level < height; level++ {
+		var parent *hash.Hash
+		if size&1 == 1 {
+			parent, err = obj.join(obj.frontier[level], node)
+		} else {
+			zero, err = obj.zero(level)
+			if err != nil {
+				return nil, err
+			}
+
+			parent, err = obj.join(node, *zero)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		node = *parent
+		size = size >> 1
+	}
+
+	return &node, nil
+}
+
+// HashTree builds the complete HashTree of the appended blocks
+func (obj *appendable) HashTree() (HashTree, error) {
+	if len(obj.leaves) <= 0 {
+		return nil, errors.New("the HashTree of an empty appendable HashTree cannot be built")
+	}
+
+	hashes := make([]hash.Hash, len(obj.leaves))
+	copy(hashes, obj.leaves)
+	blk, err := createBlockFromHashes(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return blk.HashTree()
+}
+
+func (obj *appendable) appendLeaf(leafHash hash.Hash) error {
+	node := leafHash
+	size := len(obj.leaves)
+	level := 0
+	for size&1 == 1 {
+		parent, err := obj.join(obj.frontier[level], node)
+		if err != nil {
+			return err
+		}
+
+		node = *parent
+		size = size >> 1
+		level++
+	}
+
+	if level >= len(obj.frontier) {
+		obj.frontier = append(obj.frontier, node)
+	} else {
+		obj.frontier[level] = node
+	}
+
+	obj.leaves = append(obj.leaves, leafHash)
+	return nil
+}
+
+func (obj *appendable) zero(level int) (*hash.Hash, error) {
+	if len(obj.zeros) <= 0 {
+		filler, err := obj.hashAdapter.Hash(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		obj.zeros = append(obj.zeros, *filler)
+	}
+
+	for len(obj.zeros) <= level {
+		last := obj.zeros[len(obj.zeros)-1]
+		parent, err := obj.join(last, last)
+		if err != nil {
+			return nil, err
+		}
+
+		obj.zeros = append(obj.zeros, *parent)
+	}
+
+	zero := obj.zeros[level]
+	return &zero, nil
+}
+
+func (obj *appendable) join(left hash.Hash, right hash.Hash) (*hash.Hash, error) {
+	return obj.hashAdapter.FromMultiBytes([][]byte{
+		left.Bytes(),
+		right.Bytes(),
+	})
+}
@@ -0,0 +1,45 @@
+package hashtree
+
+import (
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type appendableBuilder struct {
+	hashAdapter hash.Adapter
+	blocks      [][]byte
+}
+
+func createAppendableBuilder(
+	hashAdapter hash.Adapter,
+) AppendableBuilder {
+	out := appendableBuilder{
+		hashAdapter: hashAdapter,
+		blocks:      nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *appendableBuilder) Create() AppendableBuilder {
+	return createAppendableBuilder(app.hashAdapter)
+}
+
+// WithBlocks add blocks to the builder
+func (app *appendableBuilder) WithBlocks(blocks [][]byte) AppendableBuilder {
+	app.blocks = blocks
+	return app
+}
+
+// Now builds a new Appendable instance
+func (app *appendableBuilder) Now() (Appendable, error) {
+	out := createAppendable(app.hashAdapter)
+	if app.blocks != nil {
+		err := out.Append(app.blocks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package hashtree
+
+import (
+	"fmt"
+	"testing"
+)
+
+func createBlocksForTests(amount int) [][]byte {
+	blocks := [][]byte{}
+	for i := 0; i < amount; i++ {
+		blocks = append(blocks, []byte(fmt.Sprintf("this is the block %d", i)))
+	}
+
+	return blocks
+}
+
+func TestAppendable_headMatchesBuilder_Success(t *testing.T) {
+	for amount := 1; amount <= 33; amount++ {
+		blocks := createBlocksForTests(amount)
+		tree, err := NewBuilder().Create().WithBlocks(blocks).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		appendable, err := NewAppendableBuilder().Create().WithBlocks(blocks).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if appendable.Length() != amount {
+			t.Errorf("the appendable HashTree was expected to contain %d blocks, %d returned", amount, appendable.Length())
+			return
+		}
+
+		head, err := appendable.Head()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !head.Compare(tree.Head()) {
+			t.Errorf("the head (%d blocks) was expected to be %s, %s returned", amount, tree.Head().String(), head.String())
+			return
+		}
+
+		retTree, err := appendable.HashTree()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !retTree.Head().Compare(tree.Head()) {
+			t.Errorf("the HashTree head (%d blocks) was expected to be %s, %s returned", amount, tree.Head().String(), retTree.Head().String())
+			return
+		}
+	}
+}
+
+func TestAppendable_appendIncrementally_Success(t *testing.T) {
+	blocks := createBlocksForTests(20)
+	appendable, err := NewAppendableBuilder().Create().Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = appendable.Head()
+	if err == nil {
+		t.Errorf("the error was expected to be valid when computing the head of an empty appendable HashTree, nil returned")
+		return
+	}
+
+	for index, oneBlock := range blocks {
+		err = appendable.Append([][]byte{oneBlock})
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		tree, err := NewBuilder().Create().WithBlocks(blocks[:index+1]).Now()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		head, err := appendable.Head()
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !head.Compare(tree.Head()) {
+			t.Errorf("the head (%d blocks) was expected to be %s, %s returned", index+1, tree.Head().String(), head.String())
+			return
+		}
+	}
+}
@@ -2,6 +2,8 @@ package hashtree
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
 
 	"github.com/deepvalue-network/software/libs/hash"
@@ -19,7 +21,7 @@ func createBlockFromData(data [][]byte) (Block, error) {
 
 	hashes := []hash.Hash{}
 	for _, oneData := range data {
-		oneHash, err := hash.NewAdapter().FromBytes(oneData)
+		oneHash, err := hash.NewAdapter().Hash(oneData)
 		if err != nil {
 			return nil, err
 		}
@@ -27,18 +29,44 @@ func createBlockFromData(data [][]byte) (Block, error) {
 		hashes = append(hashes, *oneHash)
 	}
 
+	return createBlockFromHashes(hashes)
+}
+
+func createBlockFromHashes(hashes []hash.Hash) (Block, error) {
+	// the leaves must share an algorithm, which hashes the fillers and the parents of the tree:
+	hashAdapter := hash.NewAdapter()
+	if len(hashes) > 0 {
+		hashAdapter = hash.NewAdapterFromHash(hashes[0])
+	}
+
+	for _, oneHash := range hashes {
+		if oneHash.Algorithm() != hashAdapter.Algorithm() {
+			str := fmt.Sprintf("the hash (%s) must use the algorithm (%s) of the other hashes of the block", oneHash.String(), hashAdapter.Algorithm().String())
+			return nil, errors.New(str)
+		}
+	}
+
+	if len(hashes) <= 1 {
+		filler, err := hashAdapter.Hash([]byte(""))
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, *filler)
+	}
+
 	blk := block{
 		List: hashes,
 	}
 
-	return blk.resize(), nil
+	return blk.resize(hashAdapter), nil
 }
 
-func (obj *block) resize() Block {
+func (obj *block) resize(hashAdapter hash.Adapter) Block {
 	//need to make sure the elements are always a power of 2:
 	isPowerOfTwo := obj.isLengthPowerForTwo()
 	if !isPowerOfTwo {
-		obj.resizeToNextPowerOfTwo()
+		obj.resizeToNextPowerOfTwo(hashAdapter)
 	}
 
 	return obj
@@ -49,12 +77,12 @@ func (obj *block) isLengthPowerForTwo() bool {
 	return (length != 0) && ((length & (length - 1)) == 0)
 }
 
-func (obj *block) resizeToNextPowerOfTwo() (Block, error) {
+func (obj *block) resizeToNextPowerOfTwo(hashAdapter hash.Adapter) (Block, error) {
 	lengthAsFloat := float64(len(obj.List))
 	next := uint(math.Pow(2, math.Ceil(math.Log(lengthAsFloat)/math.Log(2))))
 	remaining := int(next) - int(lengthAsFloat)
 	for i := 0; i < remaining; i++ {
-		single, err := hash.NewAdapter().FromBytes(nil)
+		single, err := hashAdapter.Hash(nil)
 		if err != nil {
 			return nil, err
 		}
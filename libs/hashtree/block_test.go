@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
 )
 
 func TestBlock_Success(t *testing.T) {
@@ -36,3 +38,46 @@ func TestBlock_Success(t *testing.T) {
 		return
 	}
 }
+
+func TestBlock_withHashes_usesTheirAlgorithm_Success(t *testing.T) {
+	hashAdapter, err := hash.NewAdapterWithAlgorithm(hash.SHA256)
+	if err != nil {
+		t.Errorf("the error was expected to be nil: %s", err.Error())
+		return
+	}
+
+	hashes := []hash.Hash{}
+	for _, oneData := range []string{"first", "second", "third"} {
+		hsh, err := hashAdapter.Hash([]byte(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil: %s", err.Error())
+			return
+		}
+
+		hashes = append(hashes, *hsh)
+	}
+
+	tree, err := createHashTreeFromHashes(hashes)
+	if err != nil {
+		t.Errorf("the error was expected to be nil: %s", err.Error())
+		return
+	}
+
+	if tree.Head().Algorithm() != hash.SHA256 {
+		t.Errorf("the head was expected to be hashed with %s, %s returned", hash.SHA256.String(), tree.Head().Algorithm().String())
+		return
+	}
+
+	// the hashes of a block must share their algorithm:
+	other, err := hash.NewAdapter().Hash([]byte("other"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil: %s", err.Error())
+		return
+	}
+
+	_, err = createHashTreeFromHashes(append(hashes, *other))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -2,16 +2,20 @@ package hashtree
 
 import (
 	"errors"
+
+	"github.com/deepvalue-network/software/libs/hash"
 )
 
 type builder struct {
 	blocks [][]byte
+	hashes []hash.Hash
 	js     []byte
 }
 
 func createBuilder() Builder {
 	out := builder{
 		blocks: nil,
+		hashes: nil,
 		js:     nil,
 	}
 
@@ -29,11 +33,21 @@ func (app *builder) WithBlocks(blocks [][]byte) Builder {
 	return app
 }
 
+// WithHashes add already hashed blocks to the builder, used as leaves without being hashed again
+func (app *builder) WithHashes(hashes []hash.Hash) Builder {
+	app.hashes = hashes
+	return app
+}
+
 // Now builds a new HashTree instance
 func (app *builder) Now() (HashTree, error) {
 	if app.blocks != nil {
 		return createHashTreeFromBlocks(app.blocks)
 	}
 
+	if app.hashes != nil {
+		return createHashTreeFromHashes(app.hashes)
+	}
+
 	return nil, errors.New("the HashTree is invalid")
 }
@@ -57,6 +57,20 @@ func createHashTreeFromBlocks(blocks [][]byte) (*hashtree, error) {
 	return tree.(*hashtree), err
 }
 
+func createHashTreeFromHashes(hashes []hash.Hash) (*hashtree, error) {
+	blockHashes, err := createBlockFromHashes(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := blockHashes.HashTree()
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.(*hashtree), nil
+}
+
 // Height returns the hashtree height
 func (obj *hashtree) Height() int {
 	left := obj.Pt.Left()
@@ -90,7 +104,7 @@ func (obj *hashtree) Order(data [][]byte) ([][]byte, error) {
 	hashAdapter := hash.NewAdapter()
 	hashed := map[string][]byte{}
 	for _, oneData := range data {
-		hsh, err := hashAdapter.FromBytes(oneData)
+		hsh, err := hashAdapter.Hash(oneData)
 		if err != nil {
 			return nil, err
 		}
@@ -120,6 +134,81 @@ func (obj *hashtree) Order(data [][]byte) ([][]byte, error) {
 	return out, nil
 }
 
+// Proof returns the inclusion proof of the block that matches the given hash
+func (obj *hashtree) Proof(blockHash hash.Hash) (Proof, error) {
+	leaves := obj.Pt.BlockLeaves().Leaves()
+	index := -1
+	for leafIndex, oneLeaf := range leaves {
+		if oneLeaf.Head().Compare(blockHash) {
+			index = leafIndex
+			break
+		}
+	}
+
+	if index < 0 {
+		str := fmt.Sprintf("the block (hash: %s) is not a leaf of the HashTree (head: %s)", blockHash.String(), obj.Hd.String())
+		return nil, errors.New(str)
+	}
+
+	// walk down from the head, keeping the sibling of each node on the path:
+	siblings := []hash.Hash{}
+	var current ParentLeaf = obj.Pt
+	offset := index
+	length := len(leaves)
+	for {
+		half := length / 2
+		next := current.Left()
+		sibling := current.Right()
+		if offset >= half {
+			next = current.Right()
+			sibling = current.Left()
+			offset -= half
+		}
+
+		siblings = append([]hash.Hash{sibling.Head()}, siblings...)
+		if !next.HasParent() {
+			break
+		}
+
+		current = next.Parent()
+		length = half
+	}
+
+	return createProof(uint(index), siblings), nil
+}
+
+// MultiProof returns the inclusion proof of the blocks that match the given hashes
+func (obj *hashtree) MultiProof(blockHashes []hash.Hash) (MultiProof, error) {
+	if len(blockHashes) <= 0 {
+		return nil, errors.New("at least one block hash is mandatory in order to build a MultiProof instance")
+	}
+
+	return createMultiProofFromLevels(obj.levels(), blockHashes)
+}
+
+func (obj *hashtree) levels() [][]hash.Hash {
+	// walk the tree breadth first, from the head to the block leaves:
+	levels := [][]hash.Hash{}
+	parents := []ParentLeaf{obj.Pt}
+	for len(parents) > 0 {
+		level := []hash.Hash{}
+		next := []ParentLeaf{}
+		for _, oneParent := range parents {
+			for _, oneLeaf := range []Leaf{oneParent.Left(), oneParent.Right()} {
+				level = append(level, oneLeaf.Head())
+				if oneLeaf.HasParent() {
+					next = append(next, oneLeaf.Parent())
+				}
+			}
+		}
+
+		levels = append([][]hash.Hash{level}, levels...)
+		parents = next
+	}
+
+	return levels
+}
+
 // MarshalJSON converts the instance to JSON
 func (obj *hashtree) MarshalJSON() ([]byte, error) {
 	ins := createJSONHashTreeFromHashTree(obj)
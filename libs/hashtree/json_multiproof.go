@@ -0,0 +1,27 @@
+package hashtree
+
+// JSONMultiProof represents a json multi proof
+type JSONMultiProof struct {
+	Indexes []uint   `json:"indexes"`
+	Length  uint     `json:"length"`
+	Hashes  []string `json:"hashes"`
+}
+
+func createJSONMultiProofFromMultiProof(proof MultiProof) *JSONMultiProof {
+	hashes := []string{}
+	for _, oneHash := range proof.Hashes() {
+		hashes = append(hashes, oneHash.String())
+	}
+
+	return createJSONMultiProof(proof.Indexes(), proof.Length(), hashes)
+}
+
+func createJSONMultiProof(indexes []uint, length uint, hashes []string) *JSONMultiProof {
+	out := JSONMultiProof{
+		Indexes: indexes,
+		Length:  length,
+		Hashes:  hashes,
+	}
+
+	return &out
+}
@@ -0,0 +1,25 @@
+package hashtree
+
+// JSONProof represents a json proof
+type JSONProof struct {
+	Index    uint     `json:"index"`
+	Siblings []string `json:"siblings"`
+}
+
+func createJSONProofFromProof(proof Proof) *JSONProof {
+	siblings := []string{}
+	for _, oneSibling := range proof.Siblings() {
+		siblings = append(siblings, oneSibling.String())
+	}
+
+	return createJSONProof(proof.Index(), siblings)
+}
+
+func createJSONProof(index uint, siblings []string) *JSONProof {
+	out := JSONProof{
+		Index:    index,
+		Siblings: siblings,
+	}
+
+	return &out
+}
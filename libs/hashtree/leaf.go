@@ -53,7 +53,7 @@ func createChildLeaf(left *leaf, right *leaf) (*leaf, error) {
 		right.Head().Bytes(),
 	}, []byte{})
 
-	h, err := hash.NewAdapter().FromBytes(data)
+	h, err := hash.NewAdapterFromHash(left.Head()).Hash(data)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,246 @@
+package hashtree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type multiProof struct {
+	indexes []uint
+	length  uint
+	hashes  []hash.Hash
+}
+
+func createMultiProofFromJSON(ins *JSONMultiProof) (MultiProof, error) {
+	hashAdapter := hash.NewAdapter()
+	hashes := []hash.Hash{}
+	for _, oneHashStr := range ins.Hashes {
+		hsh, err := hashAdapter.FromString(oneHashStr)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, *hsh)
+	}
+
+	return createMultiProof(ins.Indexes, ins.Length, hashes), nil
+}
+
+func createMultiProof(
+	indexes []uint,
+	length uint,
+	hashes []hash.Hash,
+) MultiProof {
+	out := multiProof{
+		indexes: indexes,
+		length:  length,
+		hashes:  hashes,
+	}
+
+	return &out
+}
+
+// Indexes returns the sorted indexes of the proven blocks in the tree
+func (obj *multiProof) Indexes() []uint {
+	return obj.indexes
+}
+
+// Length returns the amount of leaves in the tree
+func (obj *multiProof) Length() uint {
+	return obj.length
+}
+
+// Hashes returns the hashes that cannot be computed from the proven blocks
+func (obj *multiProof) Hashes() []hash.Hash {
+	return obj.hashes
+}
+
+// Head computes the head hash using the given blocks data, in the order of the indexes
+func (obj *multiProof) Head(blocksData [][]byte) (*hash.Hash, error) {
+	if len(blocksData) != len(obj.indexes) {
+		str := fmt.Sprintf("the multi proof contains %d indexes, %d blocks data given", len(obj.indexes), len(blocksData))
+		return nil, errors.New(str)
+	}
+
+	height := 0
+	for (uint(1) << uint(height)) < obj.length {
+		height++
+	}
+
+	if height <= 0 || (uint(1)<<uint(height)) != obj.length {
+		str := fmt.Sprintf("the length (%d) of the multi proof was expected to be a power of two greater than one", obj.length)
+		return nil, errors.New(str)
+	}
+
+	hashAdapter := hash.NewAdapter()
+	current := []uint{}
+	nodes := map[uint]hash.Hash{}
+	for index, oneIndex := range obj.indexes {
+		if oneIndex >= obj.length {
+			str := fmt.Sprintf("the index (%d) is out of the tree's length (%d)", oneIndex, obj.length)
+			return nil, errors.New(str)
+		}
+
+		if index > 0 && obj.indexes[index-1] >= oneIndex {
+			return nil, errors.New("the indexes of the multi proof were expected to be sorted and unique")
+		}
+
+		hsh, err := hashAdapter.Hash(blocksData[index])
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[oneIndex] = *hsh
+		current = append(current, oneIndex)
+	}
+
+	remaining := obj.hashes
+	for level := 0; level < height; level++ {
+		next := []uint{}
+		nextNodes := map[uint]hash.Hash{}
+		for _, oneIndex := range current {
+			parentIndex := oneIndex / 2
+			if _, ok := nextNodes[parentIndex]; ok {
+				continue
+			}
+
+			sibling, ok := nodes[oneIndex^1]
+			if !ok {
+				if len(remaining) <= 0 {
+					return nil, errors.New("the multi proof does not contain enough hashes to compute the head")
+				}
+
+				sibling = remaining[0]
+				remaining = remaining[1:]
+			}
+
+			left, right := nodes[oneIndex], sibling
+			if oneIndex%2 != 0 {
+				left, right = sibling, nodes[oneIndex]
+			}
+
+			parent, err := hashAdapter.FromMultiBytes([][]byte{
+				left.Bytes(),
+				right.Bytes(),
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			nextNodes[parentIndex] = *parent
+			next = append(next, parentIndex)
+		}
+
+		current = next
+		nodes = nextNodes
+	}
+
+	if len(remaining) > 0 {
+		str := fmt.Sprintf("the multi proof contains %d unused hashes", len(remaining))
+		return nil, errors.New(str)
+	}
+
+	head := nodes[0]
+	return &head, nil
+}
+
+// MarshalJSON converts the instance to JSON
+func (obj *multiProof) MarshalJSON() ([]byte, error) {
+	ins := createJSONMultiProofFromMultiProof(obj)
+	return json.Marshal(ins)
+}
+
+// UnmarshalJSON converts the JSON to an instance
+func (obj *multiProof) UnmarshalJSON(data []byte) error {
+	ins := new(JSONMultiProof)
+	err := json.Unmarshal(data, ins)
+	if err != nil {
+		return err
+	}
+
+	pr, err := createMultiProofFromJSON(ins)
+	if err != nil {
+		return err
+	}
+
+	obj.indexes = pr.Indexes()
+	obj.length = pr.Length()
+	obj.hashes = pr.Hashes()
+	return nil
+}
+
+func createMultiProofFromLevels(levels [][]hash.Hash, blockHashes []hash.Hash) (MultiProof, error) {
+	leaves := levels[0]
+	positions := map[string]uint{}
+	for index, oneLeaf := range leaves {
+		keyname := oneLeaf.String()
+		if _, ok := positions[keyname]; ok {
+			continue
+		}
+
+		positions[keyname] = uint(index)
+	}
+
+	indexes := []uint{}
+	added := map[uint]bool{}
+	for _, oneBlockHash := range blockHashes {
+		index, ok := positions[oneBlockHash.String()]
+		if !ok {
+			str := fmt.Sprintf("the block (hash: %s) is not a leaf of the HashTree", oneBlockHash.String())
+			return nil, errors.New(str)
+		}
+
+		if _, ok := added[index]; ok {
+			continue
+		}
+
+		added[index] = true
+		indexes = append(indexes, index)
+	}
+
+	sort.Slice(indexes, func(i int, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	hashes := []hash.Hash{}
+	current := indexes
+	for _, oneLevel := range levels {
+		known := map[uint]bool{}
+		for _, oneIndex := range current {
+			known[oneIndex] = true
+		}
+
+		next := []uint{}
+		for _, oneIndex := range current {
+			parentIndex := oneIndex / 2
+			if len(next) > 0 && next[len(next)-1] == parentIndex {
+				continue
+			}
+
+			siblingIndex := oneIndex ^ 1
+			if _, ok := known[siblingIndex]; !ok {
+				hashes = append(hashes, oneLevel[siblingIndex])
+			}
+
+			next = append(next, parentIndex)
+		}
+
+		current = next
+	}
+
+	return createMultiProof(indexes, uint(len(leaves)), hashes), nil
+}
+
+func verifyMultiProof(head hash.Hash, blocksData [][]byte, proof MultiProof) bool {
+	computed, err := proof.Head(blocksData)
+	if err != nil {
+		return false
+	}
+
+	return computed.Compare(head)
+}
@@ -0,0 +1,110 @@
+package hashtree
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func createMultiProofAndTest(t *testing.T, amount int, selected []int) {
+	blocks := createBlocksForTests(amount)
+	tree, err := NewBuilder().Create().WithBlocks(blocks).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	blockHashes := []hash.Hash{}
+	for _, oneIndex := range selected {
+		blockHash, err := hash.NewAdapter().Hash(blocks[oneIndex])
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		blockHashes = append(blockHashes, *blockHash)
+	}
+
+	pr, err := tree.MultiProof(blockHashes)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(pr.Indexes()) != len(selected) {
+		t.Errorf("the multi proof was expected to contain %d indexes, %d returned", len(selected), len(pr.Indexes()))
+		return
+	}
+
+	// the blocks data must be in the order of the proof indexes:
+	blocksData := [][]byte{}
+	for _, oneIndex := range pr.Indexes() {
+		blocksData = append(blocksData, blocks[oneIndex])
+	}
+
+	if !VerifyMultiProof(tree.Head(), blocksData, pr) {
+		t.Errorf("the multi proof was expected to be valid")
+		return
+	}
+
+	invalidData := append([][]byte{[]byte("this is not in the tree")}, blocksData[1:]...)
+	if VerifyMultiProof(tree.Head(), invalidData, pr) {
+		t.Errorf("the multi proof was expected to be invalid when used with invalid blocks data")
+		return
+	}
+
+	js, err := json.Marshal(pr)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	reProof := new(multiProof)
+	err = json.Unmarshal(js, reProof)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !VerifyMultiProof(tree.Head(), blocksData, reProof) {
+		t.Errorf("the multi proof was expected to be valid after a JSON round-trip")
+		return
+	}
+}
+
+func TestMultiProof_oneBlock_Success(t *testing.T) {
+	createMultiProofAndTest(t, 1, []int{0})
+}
+
+func TestMultiProof_allBlocks_Success(t *testing.T) {
+	createMultiProofAndTest(t, 8, []int{0, 1, 2, 3, 4, 5, 6, 7})
+}
+
+func TestMultiProof_siblingBlocks_Success(t *testing.T) {
+	createMultiProofAndTest(t, 9, []int{2, 3, 8})
+}
+
+func TestMultiProof_unorderedBlocks_Success(t *testing.T) {
+	createMultiProofAndTest(t, 13, []int{12, 0, 5})
+}
+
+func TestMultiProof_blockNotInTree_returnsError(t *testing.T) {
+	tree, err := NewBuilder().Create().WithBlocks(createBlocksForTests(4)).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	blockHash, err := hash.NewAdapter().Hash([]byte("this is not in the tree"))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = tree.MultiProof([]hash.Hash{*blockHash})
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
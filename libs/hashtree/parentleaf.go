@@ -41,7 +41,7 @@ func (obj *parentLeaf) HashTree() (HashTree, error) {
 		obj.Right().Head().Bytes(),
 	}, []byte{})
 
-	hsh, err := hash.NewAdapter().FromBytes(data)
+	hsh, err := hash.NewAdapterFromHash(obj.Left().Head()).Hash(data)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,133 @@
+package hashtree
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type proof struct {
+	index    uint
+	siblings []hash.Hash
+}
+
+func createProofFromJSON(ins *JSONProof) (Proof, error) {
+	hashAdapter := hash.NewAdapter()
+	siblings := []hash.Hash{}
+	for _, oneSiblingStr := range ins.Siblings {
+		sibling, err := hashAdapter.FromString(oneSiblingStr)
+		if err != nil {
+			return nil, err
+		}
+
+		siblings = append(siblings, *sibling)
+	}
+
+	return createProof(ins.Index, siblings), nil
+}
+
+func createProof(
+	index uint,
+	siblings []hash.Hash,
+) Proof {
+	out := proof{
+		index:    index,
+		siblings: siblings,
+	}
+
+	return &out
+}
+
+// Index returns the index of the proven block in the tree
+func (obj *proof) Index() uint {
+	return obj.index
+}
+
+// Siblings returns the sibling hashes, from the block leaf up to the head
+func (obj *proof) Siblings() []hash.Hash {
+	return obj.siblings
+}
+
+// Head computes the head hash using the given block data
+func (obj *proof) Head(blockData []byte) (*hash.Hash, error) {
+	leaf, err := hash.NewAdapter().Hash(blockData)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.HeadFromHash(*leaf)
+}
+
+// HeadFromHash computes the head hash using the given leaf hash, hashed with its algorithm
+func (obj *proof) HeadFromHash(leaf hash.Hash) (*hash.Hash, error) {
+	hashAdapter := hash.NewAdapterFromHash(leaf)
+	current := &leaf
+	index := obj.index
+	for _, oneSibling := range obj.siblings {
+		data := [][]byte{
+			current.Bytes(),
+			oneSibling.Bytes(),
+		}
+
+		if index%2 != 0 {
+			data = [][]byte{
+				oneSibling.Bytes(),
+				current.Bytes(),
+			}
+		}
+
+		next, err := hashAdapter.Hash(bytes.Join(data, []byte{}))
+		if err != nil {
+			return nil, err
+		}
+
+		current = next
+
+		index = index / 2
+	}
+
+	return current, nil
+}
+
+// MarshalJSON converts the instance to JSON
+func (obj *proof) MarshalJSON() ([]byte, error) {
+	ins := createJSONProofFromProof(obj)
+	return json.Marshal(ins)
+}
+
+// UnmarshalJSON converts the JSON to an instance
+func (obj *proof) UnmarshalJSON(data []byte) error {
+	ins := new(JSONProof)
+	err := json.Unmarshal(data, ins)
+	if err != nil {
+		return err
+	}
+
+	pr, err := createProofFromJSON(ins)
+	if err != nil {
+		return err
+	}
+
+	obj.index = pr.Index()
+	obj.siblings = pr.Siblings()
+	return nil
+}
+
+func verifyProof(head hash.Hash, blockData []byte, proof Proof) bool {
+	computed, err := proof.Head(blockData)
+	if err != nil {
+		return false
+	}
+
+	return computed.Compare(head)
+}
+
+func verifyProofFromHash(head hash.Hash, leaf hash.Hash, proof Proof) bool {
+	computed, err := proof.HeadFromHash(leaf)
+	if err != nil {
+		return false
+	}
+
+	return computed.Compare(head)
+}
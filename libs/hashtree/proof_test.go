@@ -0,0 +1,99 @@
+package hashtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+func createProofsAndTest(t *testing.T, text string, delimiter string) {
+	splittedData := bytes.Split([]byte(text), []byte(delimiter))
+	tree, err := NewBuilder().Create().WithBlocks(splittedData).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	head := tree.Head()
+	for _, oneData := range splittedData {
+		blockHash, err := hash.NewAdapter().Hash(oneData)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		pr, err := tree.Proof(*blockHash)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if len(pr.Siblings()) != tree.Height()-1 {
+			t.Errorf("the proof was expected to contain %d siblings, %d returned", tree.Height()-1, len(pr.Siblings()))
+			return
+		}
+
+		if !VerifyProof(head, oneData, pr) {
+			t.Errorf("the proof of the block (%s) was expected to be valid", oneData)
+			return
+		}
+
+		if VerifyProof(head, []byte("this is not in the tree"), pr) {
+			t.Errorf("the proof was expected to be invalid when used with invalid block data")
+			return
+		}
+
+		js, err := json.Marshal(pr)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		reProof := new(proof)
+		err = json.Unmarshal(js, reProof)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !reflect.DeepEqual(pr, reProof) {
+			t.Errorf("the json conversion (back and forth) did not succeed")
+			return
+		}
+
+		if !VerifyProof(head, oneData, reProof) {
+			t.Errorf("the proof converted from JSON was expected to be valid")
+			return
+		}
+	}
+}
+
+func TestProof_Success(t *testing.T) {
+	createProofsAndTest(t, "this", "|")                                                    //1 block, rounded up to 2
+	createProofsAndTest(t, "this|is", "|")                                                 //2 blocks
+	createProofsAndTest(t, "this|is|some|data|separated|by|delimiters|asfsf", "|")         //8 blocks
+	createProofsAndTest(t, "this|is|some|data|separated|by|delimiters|asfsf|another", "|") //9 blocks, rounded up to 16
+}
+
+func TestProof_blockNotInTree_returnsError(t *testing.T) {
+	tree, err := NewBuilder().Create().WithBlocks([][]byte{
+		[]byte("this"),
+		[]byte("is"),
+		[]byte("some"),
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	invalidHash, _ := hash.NewAdapter().Hash([]byte("not in the tree"))
+	_, err = tree.Proof(*invalidHash)
+	if err == nil {
+		t.Errorf("the returned error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -14,6 +14,48 @@ func ToCompact(js *JSONCompact) (Compact, error) {
 	return createCompactFromJSON(js)
 }
 
+// ToProofJSON converts a proof to JSONProof
+func ToProofJSON(proof Proof) *JSONProof {
+	return createJSONProofFromProof(proof)
+}
+
+// ToProof creates a proof from JSONProof
+func ToProof(js *JSONProof) (Proof, error) {
+	return createProofFromJSON(js)
+}
+
+// VerifyProof returns true if the given block data is part of the HashTree of the given head, false otherwise
+func VerifyProof(head hash.Hash, blockData []byte, proof Proof) bool {
+	return verifyProof(head, blockData, proof)
+}
+
+// VerifyProofFromHash returns true if the given leaf hash is part of the HashTree of the given head, false otherwise; it
+// verifies the trees built from already hashed blocks, whose leaves are the hashes themselves
+func VerifyProofFromHash(head hash.Hash, leaf hash.Hash, proof Proof) bool {
+	return verifyProofFromHash(head, leaf, proof)
+}
+
+// ToMultiProofJSON converts a multi proof to JSONMultiProof
+func ToMultiProofJSON(proof MultiProof) *JSONMultiProof {
+	return createJSONMultiProofFromMultiProof(proof)
+}
+
+// ToMultiProof creates a multi proof from JSONMultiProof
+func ToMultiProof(js *JSONMultiProof) (MultiProof, error) {
+	return createMultiProofFromJSON(js)
+}
+
+// VerifyMultiProof returns true if the given blocks data are part of the HashTree of the given head, false otherwise
+func VerifyMultiProof(head hash.Hash, blocksData [][]byte, proof MultiProof) bool {
+	return verifyMultiProof(head, blocksData, proof)
+}
+
+// NewAppendableBuilder creates a new appendable hashtree builder
+func NewAppendableBuilder() AppendableBuilder {
+	hashAdapter := hash.NewAdapter()
+	return createAppendableBuilder(hashAdapter)
+}
+
 // NewBuilder creates a new hashtree builder
 func NewBuilder() Builder {
 	return createBuilder()
@@ -23,9 +65,27 @@ func NewBuilder() Builder {
 type Builder interface {
 	Create() Builder
 	WithBlocks(blocks [][]byte) Builder
+	WithHashes(hashes []hash.Hash) Builder
 	Now() (HashTree, error)
 }
 
+// AppendableBuilder represents an appendable hashtree builder
+type AppendableBuilder interface {
+	Create() AppendableBuilder
+	WithBlocks(blocks [][]byte) AppendableBuilder
+	Now() (Appendable, error)
+}
+
+// Appendable represents an hashtree that blocks can be appended to
+type Appendable interface {
+	Length() int
+	Leaves() []hash.Hash
+	Frontier() []hash.Hash
+	Append(blocks [][]byte) error
+	Head() (*hash.Hash, error)
+	HashTree() (HashTree, error)
+}
+
 // HashTree represents an hashtree
 type HashTree interface {
 	Height() int
@@ -34,6 +94,8 @@ type HashTree interface {
 	Parent() ParentLeaf
 	Compact() Compact
 	Order(data [][]byte) ([][]byte, error)
+	Proof(blockHash hash.Hash) (Proof, error)
+	MultiProof(blockHashes []hash.Hash) (MultiProof, error)
 }
 
 // Block represents a block of hashes
@@ -72,3 +134,19 @@ type Compact interface {
 	Leaves() Leaves
 	Length() int
 }
+
+// Proof represents an inclusion proof of a block in an hashtree
+type Proof interface {
+	Index() uint
+	Siblings() []hash.Hash
+	Head(blockData []byte) (*hash.Hash, error)
+	HeadFromHash(leaf hash.Hash) (*hash.Hash, error)
+}
+
+// MultiProof represents an inclusion proof of multiple blocks in an hashtree
+type MultiProof interface {
+	Indexes() []uint
+	Length() uint
+	Hashes() []hash.Hash
+	Head(blocksData [][]byte) (*hash.Hash, error)
+}
@@ -1,6 +1,8 @@
 package hydro
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -13,6 +15,16 @@ type adapter struct {
 	manager Manager
 }
 
+// nestedField represents a field of an hydrated struct that contains hydrated structs, by its JSON key
+type nestedField struct {
+	key    string
+	typ    reflect.Type
+	bridge Bridge
+}
+
+// nestedFn updates a nested hydrated object of a payload, using its bridge
+type nestedFn func(obj map[string]interface{}, bridge Bridge) (map[string]interface{}, error)
+
 func createAdapter(
 	manager Manager,
 ) Adapter {
@@ -42,10 +54,17 @@ func (app *adapter) Hydrate(dehydrate interface{}) (interface{}, error) {
 		return nil, err
 	}
 
+	// generated bridges do not need reflection:
+	if bridge.HasCodec() {
+		return bridge.Codec().Hydrate(app, dehydrate)
+	}
+
+	// every hydration fills a new instance of the registered pointer type:
 	hydratedBridge := bridge.Hydrated()
-	ptr := hydratedBridge.Pointer()
-	ptrVal := reflect.ValueOf(ptr)
+	ptrType := reflect.Indirect(reflect.ValueOf(hydratedBridge.Pointer())).Type()
+	ptrVal := reflect.New(ptrType)
 	indPtrVal := reflect.Indirect(ptrVal)
+	ptr := ptrVal.Interface()
 
 	amount := dehydrateType.NumField()
 	for i := 0; i < amount; i++ {
@@ -81,9 +100,10 @@ func (app *adapter) Hydrate(dehydrate interface{}) (interface{}, error) {
 			return nil, errors.New(str)
 		}
 
+		// the optional fields that are not set are left empty:
 		fieldVal := indVal.Field(i)
 		if fieldVal.IsZero() {
-			return nil, nil
+			continue
 		}
 
 		indFieldVal := reflect.Indirect(fieldVal)
@@ -145,6 +165,11 @@ func (app *adapter) Dehydrate(hydrate interface{}) (interface{}, error) {
 		return nil, err
 	}
 
+	// generated bridges do not need reflection:
+	if bridge.HasCodec() {
+		return bridge.Codec().Dehydrate(app, hydrate)
+	}
+
 	dehydratedBridge := bridge.Dehydrated()
 	paramsIns := []interface{}{}
 	amount := hydrateType.NumField()
@@ -172,17 +197,15 @@ func (app *adapter) Dehydrate(hydrate interface{}) (interface{}, error) {
 			return nil, err
 		}
 
+		// the empty fields are passed as the zero value of their constructor parameter:
 		fieldVal := indVal.Field(i)
-		indFieldVal := reflect.Indirect(fieldVal)
-		indFieldKind := indFieldVal.Kind()
 		if fieldVal.IsZero() {
-			if indFieldKind == reflect.Ptr {
-				paramsIns[index] = fieldVal
-				continue
-			}
-
+			continue
 		}
 
+		indFieldVal := reflect.Indirect(fieldVal)
+		indFieldKind := indFieldVal.Kind()
+
 		fieldValIns := fieldVal.Interface()
 		switch indFieldKind {
 		case reflect.Struct:
@@ -270,6 +293,22 @@ func (app *adapter) Dehydrate(hydrate interface{}) (interface{}, error) {
 				results.Index(i).Set(elem)
 			}
 
+			// an empty slice is dehydrated to an empty slice of the registered element interface:
+			if sliceLength <= 0 {
+				elType := fieldVal.Type().Elem()
+				if elType.Kind() == reflect.Ptr {
+					elType = elType.Elem()
+				}
+
+				elBridge, err := app.manager.Fetch(elType.PkgPath(), elType.Name())
+				if err != nil {
+					return nil, err
+				}
+
+				ourSliceValue := reflect.TypeOf(elBridge.Dehydrated().Interface()).Elem()
+				results = reflect.MakeSlice(reflect.SliceOf(ourSliceValue), 0, 0)
+			}
+
 			ptrVal, err := app.executeOnDehydrateEvent(dehydratedBridge, results.Interface(), field.Name, hydrateType.Name())
 			if err != nil {
 				return nil, err
@@ -288,13 +327,18 @@ func (app *adapter) Dehydrate(hydrate interface{}) (interface{}, error) {
 		}
 	}
 
+	constructorFnIns := dehydratedBridge.ConstructorFn()
+	constructorFn := reflect.Indirect(reflect.ValueOf(constructorFnIns))
 	params := []reflect.Value{}
-	for _, oneParamIns := range paramsIns {
+	for index, oneParamIns := range paramsIns {
+		if oneParamIns == nil {
+			params = append(params, reflect.Zero(constructorFn.Type().In(index)))
+			continue
+		}
+
 		params = append(params, reflect.ValueOf(oneParamIns))
 	}
 
-	constructorFnIns := dehydratedBridge.ConstructorFn()
-	constructorFn := reflect.Indirect(reflect.ValueOf(constructorFnIns))
 	results := constructorFn.Call(params)
 	if len(results) != 2 {
 		dehydratedBridgePointerType := reflect.TypeOf(dehydratedBridge.Pointer())
@@ -314,6 +358,421 @@ func (app *adapter) Dehydrate(hydrate interface{}) (interface{}, error) {
 	return results[0].Interface(), nil
 }
 
+// Encode hydrates a dehydrated instance and encodes it to JSON, tagged with the version of its bridge
+func (app *adapter) Encode(dehydrated interface{}) ([]byte, error) {
+	return app.EncodeWithFormat(dehydrated, JSON)
+}
+
+// EncodeWithFormat hydrates a dehydrated instance and encodes it in the given format, tagged with the version of its bridge
+func (app *adapter) EncodeWithFormat(dehydrated interface{}, format Format) ([]byte, error) {
+	hydrated, err := app.Hydrate(dehydrated)
+	if err != nil {
+		return nil, err
+	}
+
+	bridge, err := app.fetchByPointer(hydrated)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case JSON:
+		js, err := json.Marshal(hydrated)
+		if err != nil {
+			return nil, err
+		}
+
+		tagged, err := app.tagNested(js, reflect.Indirect(reflect.ValueOf(hydrated)).Type())
+		if err != nil {
+			return nil, err
+		}
+
+		return tagVersion(tagged, bridge.Version()), nil
+	case Binary:
+		body, err := marshalBinary(hydrated)
+		if err != nil {
+			return nil, err
+		}
+
+		return tagBinary(body, bridge.Version()), nil
+	}
+
+	str := fmt.Sprintf("the format (%d) is invalid", format)
+	return nil, errors.New(str)
+}
+
+// Decode decodes a JSON or binary payload to the hydrated pointer, upgrading it first if it is an old version, then dehydrates it
+func (app *adapter) Decode(data []byte, ptr interface{}) (interface{}, error) {
+	err := app.Unmarshal(data, ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.Dehydrate(ptr)
+}
+
+// Unmarshal upgrades an encoded payload and decodes it to the given hydrated pointer, without dehydrating it
+func (app *adapter) Unmarshal(data []byte, ptr interface{}) error {
+	upgraded, err := app.Upgrade(data, ptr)
+	if err != nil {
+		return err
+	}
+
+	if formatOf(upgraded) == Binary {
+		_, body, err := untagBinary(upgraded)
+		if err != nil {
+			return err
+		}
+
+		return unmarshalBinary(body, ptr)
+	}
+
+	return json.Unmarshal(upgraded, ptr)
+}
+
+// Upgrade upgrades a payload to the version of the bridge of the hydrated pointer.  Binary payloads carry no field
+// names, so they cannot be migrated: an old binary payload must be re-encoded from a version that can still decode it.
+// The payload is migrated first, then every nested hydrated object is migrated by its own bridge, from its own version
+func (app *adapter) Upgrade(data []byte, ptr interface{}) ([]byte, error) {
+	bridge, err := app.fetchByPointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	if formatOf(data) == Binary {
+		version, _, err := untagBinary(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if version != bridge.Version() {
+			str := fmt.Sprintf("the binary payload (version: %d) cannot be migrated to the bridge version (%d)", version, bridge.Version())
+			return nil, errors.New(str)
+		}
+
+		return data, nil
+	}
+
+	payload, err := decodePayload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the payloads encoded before the versions were introduced do not contain one:
+	version := uint(FirstVersion)
+	_, isTagged := payload[VersionKey]
+	if isTagged {
+		number, err := parseVersion(payload[VersionKey])
+		if err != nil {
+			return nil, err
+		}
+
+		version = number
+		delete(payload, VersionKey)
+	}
+
+	isUpgraded := version != bridge.Version()
+	if isUpgraded {
+		migrated, err := bridge.Migrate(payload, version)
+		if err != nil {
+			return nil, err
+		}
+
+		payload = migrated
+	}
+
+	ptrType := reflect.Indirect(reflect.ValueOf(ptr)).Type()
+	err = app.walkNested(payload, ptrType, func(obj map[string]interface{}, nestedBridge Bridge) (map[string]interface{}, error) {
+		upgraded, isNestedUpgraded, err := upgradeNested(obj, nestedBridge)
+		if isNestedUpgraded {
+			isUpgraded = true
+		}
+
+		return upgraded, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isUpgraded {
+		if isTagged {
+			return data, nil
+		}
+
+		return tagVersion(data, version), nil
+	}
+
+	js, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagVersion(js, bridge.Version()), nil
+}
+
+// upgradeNested migrates a nested hydrated object to the version of its bridge, and returns true if it was not tagged
+// with it
+func upgradeNested(obj map[string]interface{}, bridge Bridge) (map[string]interface{}, bool, error) {
+	// the nested objects encoded before they were tagged do not contain a version:
+	version := uint(FirstVersion)
+	isUpgraded := true
+	if value, ok := obj[VersionKey]; ok {
+		number, err := parseVersion(value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		version = number
+		isUpgraded = version != bridge.Version()
+		delete(obj, VersionKey)
+	}
+
+	if version != bridge.Version() {
+		migrated, err := bridge.Migrate(obj, version)
+		if err != nil {
+			return nil, false, err
+		}
+
+		obj = migrated
+	}
+
+	obj[VersionKey] = bridge.Version()
+	return obj, isUpgraded, nil
+}
+
+// tagNested tags the nested hydrated objects of a JSON payload with the version of their bridge
+func (app *adapter) tagNested(js []byte, typ reflect.Type) ([]byte, error) {
+	if len(app.nestedFields(typ)) <= 0 {
+		return js, nil
+	}
+
+	payload, err := decodePayload(js)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.walkNested(payload, typ, func(obj map[string]interface{}, bridge Bridge) (map[string]interface{}, error) {
+		obj[VersionKey] = bridge.Version()
+		return obj, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(payload)
+}
+
+// walkNested applies the func to the nested hydrated objects of the payload of the hydrated type, then walks the
+// objects it returns
+func (app *adapter) walkNested(payload map[string]interface{}, typ reflect.Type, fn nestedFn) error {
+	for _, oneField := range app.nestedFields(typ) {
+		value, ok := payload[oneField.key]
+		if !ok {
+			continue
+		}
+
+		walked, err := app.walkNestedValue(value, oneField.typ, oneField.bridge, fn)
+		if err != nil {
+			return err
+		}
+
+		payload[oneField.key] = walked
+	}
+
+	return nil
+}
+
+func (app *adapter) walkNestedValue(value interface{}, typ reflect.Type, bridge Bridge, fn nestedFn) (interface{}, error) {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return app.walkNestedValue(value, typ.Elem(), bridge, fn)
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			return value, nil
+		}
+
+		for index, oneValue := range list {
+			walked, err := app.walkNestedValue(oneValue, typ.Elem(), bridge, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			list[index] = walked
+		}
+
+		return list, nil
+	case reflect.Map:
+		mp, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
+		}
+
+		for keyname, oneValue := range mp {
+			walked, err := app.walkNestedValue(oneValue, typ.Elem(), bridge, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			mp[keyname] = walked
+		}
+
+		return mp, nil
+	case reflect.Struct:
+		// a nil pointer is encoded as null:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
+		}
+
+		updated, err := fn(obj, bridge)
+		if err != nil {
+			return nil, err
+		}
+
+		err = app.walkNested(updated, typ, fn)
+		if err != nil {
+			return nil, err
+		}
+
+		return updated, nil
+	}
+
+	return value, nil
+}
+
+// nestedFields returns the fields of an hydrated type that contain hydrated structs, directly or in a slice, an array
+// or a map
+func (app *adapter) nestedFields(typ reflect.Type) []nestedField {
+	out := []nestedField{}
+	if typ.Kind() != reflect.Struct {
+		return out
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" || field.Anonymous {
+			continue
+		}
+
+		key := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			name := strings.Split(tag, ",")[0]
+			if name == "-" {
+				continue
+			}
+
+			if name != "" {
+				key = name
+			}
+		}
+
+		elemType := field.Type
+		for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Slice || elemType.Kind() == reflect.Array || elemType.Kind() == reflect.Map {
+			elemType = elemType.Elem()
+		}
+
+		if elemType.Kind() != reflect.Struct {
+			continue
+		}
+
+		bridge, err := app.manager.Fetch(elemType.PkgPath(), elemType.Name())
+		if err != nil {
+			continue
+		}
+
+		// the dehydrated structs are registered too:
+		hydratedType := reflect.Indirect(reflect.ValueOf(bridge.Hydrated().Pointer())).Type()
+		if hydratedType != elemType {
+			continue
+		}
+
+		out = append(out, nestedField{
+			key:    key,
+			typ:    field.Type,
+			bridge: bridge,
+		})
+	}
+
+	return out
+}
+
+// decodePayload decodes a JSON object; the numbers are kept as json.Number, so that the integers above 2^53 keep their
+// precision
+func decodePayload(data []byte) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// parseVersion parses the version of a JSON payload
+func parseVersion(value interface{}) (uint, error) {
+	if number, ok := value.(json.Number); ok {
+		version, err := strconv.ParseUint(number.String(), 10, 64)
+		if err == nil && version >= FirstVersion {
+			return uint(version), nil
+		}
+	}
+
+	str := fmt.Sprintf("the payload version (%v) is invalid", value)
+	return 0, errors.New(str)
+}
+
+func (app *adapter) fetchByPointer(ptr interface{}) (Bridge, error) {
+	if ptr == nil {
+		return nil, errors.New("the hydrated pointer is mandatory in order to fetch its bridge")
+	}
+
+	ptrType := reflect.Indirect(reflect.ValueOf(ptr)).Type()
+	return app.manager.Fetch(ptrType.PkgPath(), ptrType.Name())
+}
+
+func formatOf(data []byte) Format {
+	if bytes.HasPrefix(data, binaryMagic) {
+		return Binary
+	}
+
+	return JSON
+}
+
+// tagVersion adds the version as the first key of a JSON object, replacing the existing one if any
+func tagVersion(js []byte, version uint) []byte {
+	trimmed := bytes.TrimSpace(js)
+	if len(trimmed) < 2 || trimmed[0] != '{' {
+		return js
+	}
+
+	payload := map[string]json.RawMessage{}
+	err := json.Unmarshal(trimmed, &payload)
+	if err != nil {
+		return js
+	}
+
+	if _, ok := payload[VersionKey]; ok {
+		delete(payload, VersionKey)
+		trimmed, err = json.Marshal(payload)
+		if err != nil {
+			return js
+		}
+	}
+
+	tag := fmt.Sprintf("{\"%s\":%d", VersionKey, version)
+	body := bytes.TrimSpace(trimmed[1:])
+	if len(body) > 0 && body[0] != '}' {
+		tag = fmt.Sprintf("%s,", tag)
+	}
+
+	return append([]byte(tag), body...)
+}
+
 func (app *adapter) setHydratedMapField(strctType reflect.Type, ptr reflect.Value, fieldName string, ins interface{}, bridge Hydrated) error {
 	val := reflect.ValueOf(ins)
 	indVal := reflect.Indirect(val)
@@ -346,30 +805,14 @@ func (app *adapter) setHydratedSliceField(strctType reflect.Type, ptr reflect.Va
 	indVal := reflect.Indirect(val)
 	sliceLength := indVal.Len()
 
-	var results reflect.Value
-	for i := 0; i < sliceLength; i++ {
-		if i <= 0 {
-			if ptr.IsZero() {
-				str := fmt.Sprintf("the pointer (name: %s) contains a field (name: %s) that is nil", ptr.Type().Name(), fieldName)
-				return errors.New(str)
-			}
-
-			ptrField := ptr.FieldByName(fieldName)
-			if !ptrField.IsValid() {
-				str := fmt.Sprintf("the field (type: %s, field: %s) is invalid", ptr.Type().Name(), fieldName)
-				return errors.New(str)
-			}
-
-			if ptrField.IsZero() {
-				str := fmt.Sprintf("the slice (type: %s, field: %s) is empty", ptr.Type().Name(), fieldName)
-				return errors.New(str)
-			}
-
-			outSliceValue := ptr.FieldByName(fieldName).Index(i)
-			sliceType := reflect.SliceOf(outSliceValue.Type())
-			results = reflect.MakeSlice(sliceType, sliceLength, indVal.Cap())
-		}
+	ptrField := ptr.FieldByName(fieldName)
+	if !ptrField.IsValid() {
+		str := fmt.Sprintf("the field (type: %s, field: %s) is invalid", ptr.Type().Name(), fieldName)
+		return errors.New(str)
+	}
 
+	results := reflect.MakeSlice(ptrField.Type(), sliceLength, sliceLength)
+	for i := 0; i < sliceLength; i++ {
 		el := indVal.Index(i)
 		hydrated, err := app.Hydrate(el.Interface())
 		if hydrated == nil && err != nil {
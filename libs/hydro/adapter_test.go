@@ -1,6 +1,9 @@
 package hydro
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/deepvalue-network/software/libs/hash"
@@ -13,7 +16,7 @@ func TestHydrate_Success(t *testing.T) {
 	another := uint(567)
 	simple, _ := internals.NewSimple(first, second)
 
-	hsh, _ := hash.NewAdapter().FromBytes([]byte("this is an hash"))
+	hsh, _ := hash.NewAdapter().Hash([]byte("this is an hash"))
 	complex, _ := internals.NewComplex(simple, another, *hsh)
 
 	// create simple bridge:
@@ -61,3 +64,251 @@ func TestHydrate_Success(t *testing.T) {
 	// execute:
 	VerifyAdapterUsingJSForTests(adapter, complex, t)
 }
+
+func TestDecode_upgradesOldPayload_Success(t *testing.T) {
+	// the version 1 of the payload was naming the first field "one":
+	simpleBridge, err := NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.SimpleInterface)(nil)).
+		WithDehydratedConstructor(internals.NewSimple).
+		WithDehydratedPointer(new(internals.DehydrateSimpleStruct)).
+		WithHydratedPointer(new(internals.HydrateSimpleStruct)).
+		WithVersion(2).
+		WithMigration(1, func(payload map[string]interface{}) (map[string]interface{}, error) {
+			payload["first"] = payload["one"]
+			delete(payload, "one")
+			return payload, nil
+		}).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	manager := NewManagerFactory().Create()
+	manager.Register(simpleBridge)
+	adapter, err := NewAdapterBuilder().Create().WithManager(manager).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	old := []byte(`{"one":"firstValue","second":"secondValue"}`)
+	ins, err := adapter.Decode(old, new(internals.HydrateSimpleStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	simple := ins.(internals.SimpleInterface)
+	if simple.First() != "firstValue" || simple.Second() != "secondValue" {
+		t.Errorf("the old payload was not upgraded: %s, %s", simple.First(), simple.Second())
+		return
+	}
+
+	encoded, err := adapter.Encode(simple)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	expected := `{"hydro_version":2,"first":"firstValue","second":"secondValue"}`
+	if string(encoded) != expected {
+		t.Errorf("the encoded payload was expected to be %s, %s returned", expected, encoded)
+		return
+	}
+
+	upgraded, err := adapter.Upgrade(old, new(internals.HydrateSimpleStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if string(upgraded) != expected {
+		t.Errorf("the upgraded payload was expected to be %s, %s returned", expected, upgraded)
+		return
+	}
+
+	// a payload written by a newer version cannot be decoded:
+	_, err = adapter.Decode([]byte(`{"hydro_version":3,"first":"firstValue"}`), new(internals.HydrateSimpleStruct))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestBridgeBuilder_missingMigration_returnsError(t *testing.T) {
+	_, err := NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.SimpleInterface)(nil)).
+		WithDehydratedConstructor(internals.NewSimple).
+		WithDehydratedPointer(new(internals.DehydrateSimpleStruct)).
+		WithHydratedPointer(new(internals.HydrateSimpleStruct)).
+		WithVersion(3).
+		WithMigration(1, func(payload map[string]interface{}) (map[string]interface{}, error) {
+			return payload, nil
+		}).
+		Now()
+
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestDecode_upgradesOldPayload_keepsLargeIntegers_Success(t *testing.T) {
+	adapter := createComplexAdapterForTests(t, func(payload map[string]interface{}) (map[string]interface{}, error) {
+		return payload, nil
+	})
+
+	if adapter == nil {
+		return
+	}
+
+	// above 2^53, a float64 cannot represent the integer:
+	another := uint(1<<60 + 1)
+	simple, _ := internals.NewSimple("firstValue", "secondValue")
+	hsh, _ := hash.NewAdapter().Hash([]byte("this is an hash"))
+	complex, _ := internals.NewComplex(simple, another, *hsh)
+	encoded, err := adapter.Encode(complex)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	old := bytes.Replace(encoded, []byte(`"hydro_version":2`), []byte(`"hydro_version":1`), 1)
+	upgraded, err := adapter.Upgrade(old, new(internals.HydrateComplexStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Contains(upgraded, []byte(`"another":1152921504606846977`)) {
+		t.Errorf("the upgraded payload was expected to keep the integer: %s", upgraded)
+		return
+	}
+
+	ins, err := adapter.Decode(old, new(internals.HydrateComplexStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if ins.(internals.ComplexInterface).Another() != another {
+		t.Errorf("the integer was expected to be %d, %d returned", another, ins.(internals.ComplexInterface).Another())
+		return
+	}
+}
+
+func TestDecode_upgradesNestedStructByItsBridge_Success(t *testing.T) {
+	// the version 1 of the nested simple struct was naming its first field "one"; the nested objects written before
+	// they were tagged are at the first version, and are upgraded by the migration of their own bridge:
+	adapter := createComplexAdapterForTests(t, func(payload map[string]interface{}) (map[string]interface{}, error) {
+		return payload, nil
+	})
+
+	if adapter == nil {
+		return
+	}
+
+	hsh, _ := hash.NewAdapter().Hash([]byte("this is an hash"))
+	old := []byte(fmt.Sprintf(`{"simple":{"one":"firstValue","second":"secondValue"},"another":5,"hash":"%s"}`, hsh.String()))
+	ins, err := adapter.Decode(old, new(internals.HydrateComplexStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	simple := ins.(internals.ComplexInterface).Simple()
+	if simple.First() != "firstValue" || simple.Second() != "secondValue" {
+		t.Errorf("the nested struct was not upgraded: %s, %s", simple.First(), simple.Second())
+		return
+	}
+
+	// the nested objects are tagged with the version of their bridge:
+	encoded, err := adapter.Encode(ins)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Contains(encoded, []byte(`"simple":{"first":"firstValue","hydro_version":2,"second":"secondValue"}`)) {
+		t.Errorf("the nested struct was expected to be tagged with its version: %s", encoded)
+		return
+	}
+
+	upgraded, err := adapter.Upgrade(old, new(internals.HydrateComplexStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Equal(upgraded, encoded) {
+		t.Errorf("the upgraded payload was expected to be %s, %s returned", encoded, upgraded)
+		return
+	}
+
+	// a payload whose nested objects are tagged with the latest version is kept as is:
+	current, err := adapter.Upgrade(encoded, new(internals.HydrateComplexStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Equal(current, encoded) {
+		t.Errorf("the current payload was expected to be kept as is, %s returned", current)
+		return
+	}
+}
+
+// createComplexAdapterForTests creates an adapter whose complex bridge is at version 2, with the given migration, and
+// whose simple bridge is at version 2, its version 1 naming its first field "one"
+func createComplexAdapterForTests(t *testing.T, migrationFn MigrationFn) Adapter {
+	simpleBridge, err := NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.SimpleInterface)(nil)).
+		WithDehydratedConstructor(internals.NewSimple).
+		WithDehydratedPointer(new(internals.DehydrateSimpleStruct)).
+		WithHydratedPointer(new(internals.HydrateSimpleStruct)).
+		WithVersion(2).
+		WithMigration(1, func(payload map[string]interface{}) (map[string]interface{}, error) {
+			if _, ok := payload["one"]; !ok {
+				return nil, errors.New("the nested simple struct was expected to be at its version 1")
+			}
+
+			payload["first"] = payload["one"]
+			delete(payload, "one")
+			return payload, nil
+		}).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	complexBridge, err := NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.ComplexInterface)(nil)).
+		WithDehydratedConstructor(internals.NewComplex).
+		WithDehydratedPointer(new(internals.DehydrateComplexStruct)).
+		WithHydratedPointer(new(internals.HydrateComplexStruct)).
+		OnHydrate(internals.ComplexStructOnHydrateEventFn).
+		OnDehydrate(internals.ComplexStructOnDehydrateEventFn).
+		WithVersion(2).
+		WithMigration(1, migrationFn).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	manager := NewManagerFactory().Create()
+	manager.Register(simpleBridge)
+	manager.Register(complexBridge)
+	adapter, err := NewAdapterBuilder().Create().WithManager(manager).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return adapter
+}
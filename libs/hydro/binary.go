@@ -0,0 +1,485 @@
+package hydro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// maxBinaryZeroSizeAmount represents the max amount of elements that are encoded to no byte, in a slice or a map
+const maxBinaryZeroSizeAmount = 1024 * 64
+
+// binaryMagic prefixes every binary payload; a JSON payload can never start with a zero byte
+var binaryMagic = []byte{0x00, 'h', 'b'}
+
+// marshalBinary encodes an hydrated instance to its canonical binary form:
+//   - bools are a single 0 or 1 byte,
+//   - unsigned integers are uvarints, signed integers are zig-zag varints,
+//   - floats are their IEEE 754 bits, big-endian, on 8 bytes,
+//   - strings and byte slices are prefixed by their uvarint length,
+//   - slices and arrays are prefixed by their uvarint amount of elements,
+//   - maps are prefixed by their uvarint amount of entries, sorted by their encoded keys,
+//   - pointers are prefixed by a 0 (nil) or 1 presence byte,
+//   - structs are their exported fields, in declaration order, without names.
+//
+// A pointer to the root instance is encoded as the instance it points to.
+func marshalBinary(ins interface{}) ([]byte, error) {
+	val := reflect.ValueOf(ins)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, errors.New("a nil pointer cannot be encoded to binary")
+		}
+
+		val = val.Elem()
+	}
+
+	buffer := new(bytes.Buffer)
+	err := writeBinaryValue(buffer, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// unmarshalBinary decodes a canonical binary payload in the given pointer
+func unmarshalBinary(data []byte, ptr interface{}) error {
+	val := reflect.ValueOf(ptr)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.New("the binary payload can only be decoded in a non-nil pointer")
+	}
+
+	reader := bytes.NewReader(data)
+	err := readBinaryValue(reader, val.Elem())
+	if err != nil {
+		return err
+	}
+
+	if reader.Len() > 0 {
+		str := fmt.Sprintf("the binary payload contains %d trailing bytes", reader.Len())
+		return errors.New(str)
+	}
+
+	return nil
+}
+
+// tagBinary prefixes a binary body with the magic bytes and the version
+func tagBinary(body []byte, version uint) []byte {
+	header := make([]byte, len(binaryMagic)+binary.MaxVarintLen64)
+	copy(header, binaryMagic)
+	amount := binary.PutUvarint(header[len(binaryMagic):], uint64(version))
+	return append(header[:len(binaryMagic)+amount], body...)
+}
+
+// untagBinary returns the version and the body of a binary payload
+func untagBinary(data []byte) (uint, []byte, error) {
+	if !bytes.HasPrefix(data, binaryMagic) {
+		return 0, nil, errors.New("the payload is not a binary payload")
+	}
+
+	version, amount := binary.Uvarint(data[len(binaryMagic):])
+	if amount <= 0 || version < FirstVersion {
+		return 0, nil, errors.New("the binary payload version is invalid")
+	}
+
+	return uint(version), data[len(binaryMagic)+amount:], nil
+}
+
+func writeBinaryValue(buffer *bytes.Buffer, val reflect.Value) error {
+	switch val.Kind() {
+	case reflect.Bool:
+		if val.Bool() {
+			buffer.WriteByte(1)
+			return nil
+		}
+
+		buffer.WriteByte(0)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUvarint(buffer, val.Uint())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bytes := make([]byte, binary.MaxVarintLen64)
+		amount := binary.PutVarint(bytes, val.Int())
+		buffer.Write(bytes[:amount])
+		return nil
+	case reflect.Float32, reflect.Float64:
+		bytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(bytes, math.Float64bits(val.Float()))
+		buffer.Write(bytes)
+		return nil
+	case reflect.String:
+		writeUvarint(buffer, uint64(val.Len()))
+		buffer.WriteString(val.String())
+		return nil
+	case reflect.Slice:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			writeUvarint(buffer, uint64(val.Len()))
+			buffer.Write(val.Bytes())
+			return nil
+		}
+
+		return writeBinaryElements(buffer, val)
+	case reflect.Array:
+		return writeBinaryElements(buffer, val)
+	case reflect.Map:
+		return writeBinaryMap(buffer, val)
+	case reflect.Ptr:
+		if val.IsNil() {
+			buffer.WriteByte(0)
+			return nil
+		}
+
+		buffer.WriteByte(1)
+		return writeBinaryValue(buffer, val.Elem())
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if !isBinaryField(val.Type().Field(i)) {
+				continue
+			}
+
+			err := writeBinaryValue(buffer, val.Field(i))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	str := fmt.Sprintf("the kind (%s) cannot be encoded to binary", val.Kind().String())
+	return errors.New(str)
+}
+
+func writeBinaryElements(buffer *bytes.Buffer, val reflect.Value) error {
+	writeUvarint(buffer, uint64(val.Len()))
+	for i := 0; i < val.Len(); i++ {
+		err := writeBinaryValue(buffer, val.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeBinaryMap(buffer *bytes.Buffer, val reflect.Value) error {
+	type entry struct {
+		key   []byte
+		value []byte
+	}
+
+	entries := []entry{}
+	iter := val.MapRange()
+	for iter.Next() {
+		keyBuffer := new(bytes.Buffer)
+		err := writeBinaryValue(keyBuffer, iter.Key())
+		if err != nil {
+			return err
+		}
+
+		valueBuffer := new(bytes.Buffer)
+		err = writeBinaryValue(valueBuffer, iter.Value())
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry{
+			key:   keyBuffer.Bytes(),
+			value: valueBuffer.Bytes(),
+		})
+	}
+
+	sort.Slice(entries, func(i int, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	writeUvarint(buffer, uint64(len(entries)))
+	for _, oneEntry := range entries {
+		buffer.Write(oneEntry.key)
+		buffer.Write(oneEntry.value)
+	}
+
+	return nil
+}
+
+func readBinaryValue(reader *bytes.Reader, val reflect.Value) error {
+	switch val.Kind() {
+	case reflect.Bool:
+		flag, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if flag > 1 {
+			str := fmt.Sprintf("the bool byte (%d) is invalid", flag)
+			return errors.New(str)
+		}
+
+		val.SetBool(flag == 1)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return err
+		}
+
+		if val.OverflowUint(number) {
+			str := fmt.Sprintf("the number (%d) overflows the kind (%s)", number, val.Kind().String())
+			return errors.New(str)
+		}
+
+		val.SetUint(number)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := binary.ReadVarint(reader)
+		if err != nil {
+			return err
+		}
+
+		if val.OverflowInt(number) {
+			str := fmt.Sprintf("the number (%d) overflows the kind (%s)", number, val.Kind().String())
+			return errors.New(str)
+		}
+
+		val.SetInt(number)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		bytes, err := readBinaryBytes(reader, 8)
+		if err != nil {
+			return err
+		}
+
+		val.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(bytes)))
+		return nil
+	case reflect.String:
+		bytes, err := readBinaryLengthPrefixed(reader)
+		if err != nil {
+			return err
+		}
+
+		val.SetString(string(bytes))
+		return nil
+	case reflect.Slice:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			bytes, err := readBinaryLengthPrefixed(reader)
+			if err != nil {
+				return err
+			}
+
+			val.SetBytes(bytes)
+			return nil
+		}
+
+		amount, err := readBinaryAmount(reader, minBinarySize(val.Type().Elem()))
+		if err != nil {
+			return err
+		}
+
+		slice := reflect.MakeSlice(val.Type(), amount, amount)
+		for i := 0; i < amount; i++ {
+			err := readBinaryValue(reader, slice.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+
+		val.Set(slice)
+		return nil
+	case reflect.Array:
+		amount, err := readBinaryAmount(reader, minBinarySize(val.Type().Elem()))
+		if err != nil {
+			return err
+		}
+
+		if amount != val.Len() {
+			str := fmt.Sprintf("the array was expected to contain %d elements, %d declared", val.Len(), amount)
+			return errors.New(str)
+		}
+
+		for i := 0; i < amount; i++ {
+			err := readBinaryValue(reader, val.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		amount, err := readBinaryAmount(reader, minBinarySize(val.Type().Key())+minBinarySize(val.Type().Elem()))
+		if err != nil {
+			return err
+		}
+
+		// the entries are sorted by their encoded keys, so a key that does not follow the previous one is either a
+		// duplicate or out of order:
+		var previousKey []byte
+		mp := reflect.MakeMapWithSize(val.Type(), amount)
+		for i := 0; i < amount; i++ {
+			key := reflect.New(val.Type().Key()).Elem()
+			err := readBinaryValue(reader, key)
+			if err != nil {
+				return err
+			}
+
+			keyBuffer := new(bytes.Buffer)
+			err = writeBinaryValue(keyBuffer, key)
+			if err != nil {
+				return err
+			}
+
+			if i > 0 && bytes.Compare(previousKey, keyBuffer.Bytes()) >= 0 {
+				str := fmt.Sprintf("the map key (index: %d) was expected to be greater than the previous one", i)
+				return errors.New(str)
+			}
+
+			previousKey = keyBuffer.Bytes()
+			value := reflect.New(val.Type().Elem()).Elem()
+			err = readBinaryValue(reader, value)
+			if err != nil {
+				return err
+			}
+
+			mp.SetMapIndex(key, value)
+		}
+
+		val.Set(mp)
+		return nil
+	case reflect.Ptr:
+		presence, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if presence == 0 {
+			val.Set(reflect.Zero(val.Type()))
+			return nil
+		}
+
+		if presence != 1 {
+			str := fmt.Sprintf("the pointer presence byte (%d) is invalid", presence)
+			return errors.New(str)
+		}
+
+		elem := reflect.New(val.Type().Elem())
+		err = readBinaryValue(reader, elem.Elem())
+		if err != nil {
+			return err
+		}
+
+		val.Set(elem)
+		return nil
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if !isBinaryField(val.Type().Field(i)) {
+				continue
+			}
+
+			err := readBinaryValue(reader, val.Field(i))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	str := fmt.Sprintf("the kind (%s) cannot be decoded from binary", val.Kind().String())
+	return errors.New(str)
+}
+
+func readBinaryAmount(reader *bytes.Reader, elementSize int) (int, error) {
+	amount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	// the elements that take no byte cannot be checked against the remaining bytes, so their amount is bounded:
+	if elementSize <= 0 {
+		if amount > maxBinaryZeroSizeAmount {
+			str := fmt.Sprintf("the declared amount (%d) of elements without bytes exceeds the max (%d)", amount, maxBinaryZeroSizeAmount)
+			return 0, errors.New(str)
+		}
+
+		return int(amount), nil
+	}
+
+	// every element takes at least its min size, which protects against huge allocations:
+	if amount > uint64(reader.Len()/elementSize) {
+		str := fmt.Sprintf("the declared amount (%d) of elements of at least %d bytes exceeds the remaining bytes (%d)", amount, elementSize, reader.Len())
+		return 0, errors.New(str)
+	}
+
+	return int(amount), nil
+}
+
+func readBinaryLengthPrefixed(reader *bytes.Reader) ([]byte, error) {
+	length, err := readBinaryAmount(reader, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return readBinaryBytes(reader, length)
+}
+
+func readBinaryBytes(reader *bytes.Reader, length int) ([]byte, error) {
+	if length > reader.Len() {
+		str := fmt.Sprintf("%d bytes were expected, %d remaining", length, reader.Len())
+		return nil, errors.New(str)
+	}
+
+	out := make([]byte, length)
+	if length == 0 {
+		return out, nil
+	}
+
+	_, err := reader.Read(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func writeUvarint(buffer *bytes.Buffer, number uint64) {
+	bytes := make([]byte, binary.MaxVarintLen64)
+	amount := binary.PutUvarint(bytes, number)
+	buffer.Write(bytes[:amount])
+}
+
+// minBinarySize returns the min amount of bytes a value of the type is encoded to
+func minBinarySize(typ reflect.Type) int {
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return 8
+	case reflect.Array:
+		return typ.Len() * minBinarySize(typ.Elem())
+	case reflect.Struct:
+		out := 0
+		for i := 0; i < typ.NumField(); i++ {
+			if !isBinaryField(typ.Field(i)) {
+				continue
+			}
+
+			out += minBinarySize(typ.Field(i).Type)
+		}
+
+		return out
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return 0
+	}
+
+	return 1
+}
+
+func isBinaryField(field reflect.StructField) bool {
+	if field.PkgPath != "" {
+		return false
+	}
+
+	return field.Tag.Get("json") != "-"
+}
@@ -0,0 +1,253 @@
+package hydro
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro/internals"
+)
+
+type binaryNested struct {
+	Name string `json:"name"`
+}
+
+type binaryAll struct {
+	Flag     bool            `json:"flag"`
+	Amount   uint            `json:"amount"`
+	Delta    int64           `json:"delta"`
+	Ratio    float64         `json:"ratio"`
+	Text     string          `json:"text"`
+	Data     []byte          `json:"data"`
+	Hashes   []string        `json:"hashes"`
+	Labels   map[string]uint `json:"labels"`
+	Nested   *binaryNested   `json:"nested"`
+	Missing  *binaryNested   `json:"missing"`
+	Children []*binaryNested `json:"children"`
+	Ignored  string          `json:"-"`
+	byName   map[string]string
+}
+
+func TestBinary_isCanonical_Success(t *testing.T) {
+	ins := binaryAll{
+		Flag:   true,
+		Amount: 300,
+		Delta:  -2,
+		Ratio:  1.5,
+		Text:   "hi",
+		Data:   []byte{1, 2},
+		Hashes: []string{"a", "bc"},
+		Labels: map[string]uint{
+			"z": 1,
+			"a": 2,
+			"m": 3,
+		},
+		Nested: &binaryNested{
+			Name: "n",
+		},
+		Children: []*binaryNested{},
+		Ignored:  "ignored",
+	}
+
+	expected := []byte{
+		1,          // flag
+		0xac, 0x02, // amount
+		0x03,                                           // delta
+		0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // ratio
+		2, 'h', 'i', // text
+		2, 1, 2, // data
+		2, 1, 'a', 2, 'b', 'c', // hashes
+		3, 1, 'a', 2, 1, 'm', 3, 1, 'z', 1, // labels, sorted
+		1, 1, 'n', // nested
+		0, // missing
+		0, // children
+	}
+
+	for i := 0; i < 10; i++ {
+		encoded, err := marshalBinary(&ins)
+		if err != nil {
+			t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !bytes.Equal(encoded, expected) {
+			t.Errorf("the encoded payload was expected to be %v, %v returned", expected, encoded)
+			return
+		}
+	}
+
+	decoded := new(binaryAll)
+	err := unmarshalBinary(expected, decoded)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	ins.Ignored = ""
+	if !reflect.DeepEqual(&ins, decoded) {
+		t.Errorf("the decoded instance was expected to be %v, %v returned", ins, decoded)
+		return
+	}
+}
+
+func TestBinary_withTrailingBytes_returnsError(t *testing.T) {
+	err := unmarshalBinary([]byte{1, 'n', 0}, new(binaryNested))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestBinary_withTruncatedPayload_returnsError(t *testing.T) {
+	err := unmarshalBinary([]byte{5, 'n'}, new(binaryNested))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestBinary_withUnsortedMapKeys_returnsError(t *testing.T) {
+	payloads := [][]byte{
+		{2, 1, 'z', 1, 1, 'a', 2}, // out of order
+		{2, 1, 'a', 1, 1, 'a', 2}, // duplicate
+	}
+
+	for _, onePayload := range payloads {
+		decoded := map[string]uint{}
+		err := unmarshalBinary(onePayload, &decoded)
+		if err == nil {
+			t.Errorf("the error was expected to be valid, nil returned")
+			return
+		}
+	}
+}
+
+func TestBinary_withZeroSizeElements_Success(t *testing.T) {
+	type zeroSize struct {
+		Elements []struct{}  `json:"elements"`
+		Array    [3]struct{} `json:"array"`
+	}
+
+	ins := zeroSize{
+		Elements: []struct{}{{}, {}},
+	}
+
+	encoded, err := marshalBinary(&ins)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	decoded := new(zeroSize)
+	err = unmarshalBinary(encoded, decoded)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(&ins, decoded) {
+		t.Errorf("the decoded instance was expected to be %v, %v returned", ins, decoded)
+		return
+	}
+
+	// a huge amount of elements without bytes is rejected:
+	err = unmarshalBinary([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 3}, decoded)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestEncodeWithFormat_binary_Success(t *testing.T) {
+	simpleBridge, err := NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.SimpleInterface)(nil)).
+		WithDehydratedConstructor(internals.NewSimple).
+		WithDehydratedPointer(new(internals.DehydrateSimpleStruct)).
+		WithHydratedPointer(new(internals.HydrateSimpleStruct)).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	complexBridge, err := NewBridgeBuilder().Create().
+		WithDehydratedInterface((*internals.ComplexInterface)(nil)).
+		WithDehydratedConstructor(internals.NewComplex).
+		WithDehydratedPointer(new(internals.DehydrateComplexStruct)).
+		WithHydratedPointer(new(internals.HydrateComplexStruct)).
+		OnHydrate(internals.ComplexStructOnHydrateEventFn).
+		OnDehydrate(internals.ComplexStructOnDehydrateEventFn).
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	manager := NewManagerFactory().Create()
+	manager.Register(simpleBridge)
+	manager.Register(complexBridge)
+	adapter, err := NewAdapterBuilder().Create().WithManager(manager).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	simple, _ := internals.NewSimple("firstValue", "secondValue")
+	hsh, _ := hash.NewAdapter().Hash([]byte("this is an hash"))
+	complex, _ := internals.NewComplex(simple, uint(567), *hsh)
+
+	encoded, err := adapter.EncodeWithFormat(complex, Binary)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if FormatOf(encoded) != Binary {
+		t.Errorf("the encoded payload was expected to be binary")
+		return
+	}
+
+	js, err := adapter.Encode(complex)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if FormatOf(js) != JSON {
+		t.Errorf("the encoded payload was expected to be JSON")
+		return
+	}
+
+	if len(encoded) >= len(js) {
+		t.Errorf("the binary payload (%d bytes) was expected to be smaller than the JSON payload (%d bytes)", len(encoded), len(js))
+		return
+	}
+
+	ins, err := adapter.Decode(encoded, new(internals.HydrateComplexStruct))
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	reEncoded, err := adapter.EncodeWithFormat(ins, Binary)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Equal(encoded, reEncoded) {
+		t.Errorf("the binary payloads were expected to be the same")
+		return
+	}
+
+	// a binary payload of another version cannot be migrated:
+	old := tagBinary(encoded[len(binaryMagic)+1:], 2)
+	_, err = adapter.Decode(old, new(internals.HydrateComplexStruct))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
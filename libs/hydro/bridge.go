@@ -1,17 +1,50 @@
 package hydro
 
+import (
+	"errors"
+	"fmt"
+)
+
 type bridge struct {
 	hydrated   Hydrated
 	dehydrated Dehydrated
+	codec      Codec
+	version    uint
+	migrations map[uint]MigrationFn
 }
 
 func createBridge(
 	hydrated Hydrated,
 	dehydrated Dehydrated,
+	version uint,
+	migrations map[uint]MigrationFn,
+) Bridge {
+	return createBridgeInternally(hydrated, dehydrated, nil, version, migrations)
+}
+
+func createBridgeWithCodec(
+	hydrated Hydrated,
+	dehydrated Dehydrated,
+	codec Codec,
+	version uint,
+	migrations map[uint]MigrationFn,
+) Bridge {
+	return createBridgeInternally(hydrated, dehydrated, codec, version, migrations)
+}
+
+func createBridgeInternally(
+	hydrated Hydrated,
+	dehydrated Dehydrated,
+	codec Codec,
+	version uint,
+	migrations map[uint]MigrationFn,
 ) Bridge {
 	out := bridge{
 		hydrated:   hydrated,
 		dehydrated: dehydrated,
+		codec:      codec,
+		version:    version,
+		migrations: migrations,
 	}
 
 	return &out
@@ -26,3 +59,44 @@ func (obj *bridge) Hydrated() Hydrated {
 func (obj *bridge) Dehydrated() Dehydrated {
 	return obj.dehydrated
 }
+
+// HasCodec returns true if there is a codec, false otherwise
+func (obj *bridge) HasCodec() bool {
+	return obj.codec != nil
+}
+
+// Codec returns the codec, if any
+func (obj *bridge) Codec() Codec {
+	return obj.codec
+}
+
+// Version returns the version of the hydrated pointer
+func (obj *bridge) Version() uint {
+	return obj.version
+}
+
+// Migrate upgrades a payload from a version to the version of the bridge
+func (obj *bridge) Migrate(payload map[string]interface{}, from uint) (map[string]interface{}, error) {
+	if from > obj.version {
+		str := fmt.Sprintf("the payload version (%d) is newer than the bridge version (%d)", from, obj.version)
+		return nil, errors.New(str)
+	}
+
+	out := payload
+	for version := from; version < obj.version; version++ {
+		migrationFn, ok := obj.migrations[version]
+		if !ok {
+			str := fmt.Sprintf("there is no migration from the version %d", version)
+			return nil, errors.New(str)
+		}
+
+		migrated, err := migrationFn(out)
+		if err != nil {
+			return nil, err
+		}
+
+		out = migrated
+	}
+
+	return out, nil
+}
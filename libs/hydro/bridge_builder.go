@@ -2,6 +2,7 @@ package hydro
 
 import (
 	"errors"
+	"fmt"
 )
 
 type bridgeBuilder struct {
@@ -11,6 +12,9 @@ type bridgeBuilder struct {
 	hydratedPointer       interface{}
 	onHydrateFn           EventFn
 	onDehydrateFn         EventFn
+	codec                 Codec
+	version               uint
+	migrations            map[uint]MigrationFn
 }
 
 func createBridgeBuilder() BridgeBuilder {
@@ -21,6 +25,9 @@ func createBridgeBuilder() BridgeBuilder {
 		hydratedPointer:       nil,
 		onHydrateFn:           nil,
 		onDehydrateFn:         nil,
+		codec:                 nil,
+		version:               FirstVersion,
+		migrations:            map[uint]MigrationFn{},
 	}
 
 	return &out
@@ -67,13 +74,32 @@ func (app *bridgeBuilder) OnDehydrate(onDehydrateFn EventFn) BridgeBuilder {
 	return app
 }
 
+// WithCodec adds a generated codec to the builder
+func (app *bridgeBuilder) WithCodec(codec Codec) BridgeBuilder {
+	app.codec = codec
+	return app
+}
+
+// WithVersion adds the version of the hydrated pointer to the builder
+func (app *bridgeBuilder) WithVersion(version uint) BridgeBuilder {
+	app.version = version
+	return app
+}
+
+// WithMigration adds a migration, that upgrades a payload from a version to the next one, to the builder
+func (app *bridgeBuilder) WithMigration(from uint, migrationFn MigrationFn) BridgeBuilder {
+	app.migrations[from] = migrationFn
+	return app
+}
+
 // Now builds a new Bridge instance
 func (app *bridgeBuilder) Now() (Bridge, error) {
 	if app.dehydratedInterface == nil {
 		return nil, errors.New("the dehydrated interface name is mandatory in order to build a Bridge instance")
 	}
 
-	if app.dehydratedConstructor == nil {
+	// the constructor is only used by reflection, so a codec replaces it:
+	if app.dehydratedConstructor == nil && app.codec == nil {
 		return nil, errors.New("the dehydrated constructor is mandatory in order to build a Bridge instance")
 	}
 
@@ -85,6 +111,19 @@ func (app *bridgeBuilder) Now() (Bridge, error) {
 		return nil, errors.New("the hydrated pointer is mandatory in order to build a Bridge instance")
 	}
 
+	if app.version < FirstVersion {
+		str := fmt.Sprintf("the version must be at least %d, %d provided", FirstVersion, app.version)
+		return nil, errors.New(str)
+	}
+
+	// every old version must be upgradable to the current one:
+	for version := uint(FirstVersion); version < app.version; version++ {
+		if _, ok := app.migrations[version]; !ok {
+			str := fmt.Sprintf("the migration from the version %d is mandatory since the bridge version is %d", version, app.version)
+			return nil, errors.New(str)
+		}
+	}
+
 	var hydrated Hydrated
 	if app.onHydrateFn != nil {
 		hydrated = createHydratedWithEvent(app.hydratedPointer, app.onHydrateFn)
@@ -103,5 +142,9 @@ func (app *bridgeBuilder) Now() (Bridge, error) {
 		dehydrated = createDehydrated(app.dehydratedInterface, app.dehydratedConstructor, app.dehydratedPointer)
 	}
 
-	return createBridge(hydrated, dehydrated), nil
+	if app.codec != nil {
+		return createBridgeWithCodec(hydrated, dehydrated, app.codec, app.version, app.migrations), nil
+	}
+
+	return createBridge(hydrated, dehydrated, app.version, app.migrations), nil
 }
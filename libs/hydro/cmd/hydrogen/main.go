@@ -0,0 +1,119 @@
+// Command hydrogen generates the hydrated struct, the bridge and the round-trip test of a domain interface.
+//
+// It is meant to be used with go generate, from the package that registers the bridges:
+//
+//	//go:generate go run github.com/deepvalue-network/software/libs/hydro/cmd/hydrogen -dir ../../domain/genesis -interface Genesis
+//
+// The getters of the interface must return basic types, hashes, hash algorithms, uuids, times, durations, or other
+// interfaces of the same package, and the builder must have a setter per stored getter.  A computed hash, which has no
+// setter, is compared to the one of the rebuilt instance.  Instances referenced by hash and resolved through a
+// repository, or hash trees, are not supported and need a hand-written bridge.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/deepvalue-network/software/libs/hydro/generators"
+)
+
+func main() {
+	dir := flag.String("dir", "", "the directory of the domain package")
+	iface := flag.String("interface", "", "the name of the domain interface")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "the name of the generated package")
+	out := flag.String("out", ".", "the directory where the files are generated")
+	isUnexported := flag.Bool("unexported", false, "generate unexported hydrated structs")
+	flag.Parse()
+
+	importPath, err := toImportPath(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	builder := generators.NewSpecBuilder().Create().
+		WithDirectory(*dir).
+		WithImportPath(importPath).
+		WithInterface(*iface).
+		WithPackage(*pkg)
+
+	if *isUnexported {
+		builder.IsUnexported()
+	}
+
+	spec, err := builder.Now()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	output, err := generators.NewGenerator().Generate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bridgePath := filepath.Join(*out, fmt.Sprintf("hydrated_%s_generated.go", output.Name()))
+	err = ioutil.WriteFile(bridgePath, output.Bridge(), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !output.HasTest() {
+		return
+	}
+
+	testPath := filepath.Join(*out, fmt.Sprintf("hydrated_%s_generated_test.go", output.Name()))
+	err = ioutil.WriteFile(testPath, output.Test(), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// toImportPath finds the import path of a directory, using the module declared in the nearest go.mod
+func toImportPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	current := absDir
+	for {
+		modPath := filepath.Join(current, "go.mod")
+		if file, err := os.Open(modPath); err == nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module ") {
+					module := strings.TrimSpace(strings.TrimPrefix(line, "module "))
+					rel, err := filepath.Rel(current, absDir)
+					if err != nil {
+						return "", err
+					}
+
+					if rel == "." {
+						return module, nil
+					}
+
+					return fmt.Sprintf("%s/%s", module, filepath.ToSlash(rel)), nil
+				}
+			}
+
+			str := fmt.Sprintf("the go.mod file (%s) does not declare a module", modPath)
+			return "", errors.New(str)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			str := fmt.Sprintf("there is no go.mod file in the parents of the directory (%s)", absDir)
+			return "", errors.New(str)
+		}
+
+		current = parent
+	}
+}
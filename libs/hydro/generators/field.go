@@ -0,0 +1,352 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+)
+
+type fieldKind uint8
+
+const (
+	kindBasic fieldKind = iota + 1
+	kindHash
+	kindHashAlgorithm
+	kindUUID
+	kindTime
+	kindDuration
+	kindNested
+)
+
+// reservedNames represents the names the generated funcs use, and the Go keywords
+var reservedNames = []string{
+	"adapter", "app", "builder", "dehydrated", "err", "errors", "fmt", "hash", "hydrated", "hydro", "ins", "obj", "ok",
+	"out", "ptr", "str", "time", "uuid",
+	"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func", "go", "goto",
+	"if", "import", "interface", "map", "package", "range", "return", "select", "struct", "switch", "type", "var",
+}
+
+var basicTypes = map[string]string{
+	"bool":    "false",
+	"string":  `""`,
+	"int":     "0",
+	"int8":    "0",
+	"int16":   "0",
+	"int32":   "0",
+	"int64":   "0",
+	"uint":    "0",
+	"uint8":   "0",
+	"uint16":  "0",
+	"uint32":  "0",
+	"uint64":  "0",
+	"byte":    "0",
+	"rune":    "0",
+	"float32": "0",
+	"float64": "0",
+}
+
+// fieldType represents the type of a field, the domain and hydrated types are the ones of its elements when it is a slice
+type fieldType struct {
+	kind      fieldKind
+	isSlice   bool
+	isPointer bool
+	domain    string
+	hydrated  string
+}
+
+type field struct {
+	name       string
+	typ        fieldType
+	isOptional bool
+	setter     string
+}
+
+// names generates unique variable names in the generated funcs, from the names of the fields
+type names struct {
+	used map[string]bool
+}
+
+func createNames(reserved ...string) *names {
+	out := names{
+		used: map[string]bool{},
+	}
+
+	for _, oneName := range append(reservedNames, reserved...) {
+		out.used[oneName] = true
+	}
+
+	return &out
+}
+
+// next returns the prefixed base name, or the base name in lower camel case without prefix.  A taken name is prefixed
+// with ret, then numbered
+func (app *names) next(prefix string, base string) string {
+	candidates := []string{
+		toLowerCamelCase(base),
+		fmt.Sprintf("ret%s", base),
+	}
+
+	if prefix != "" {
+		candidates = []string{
+			fmt.Sprintf("%s%s", prefix, base),
+		}
+	}
+
+	for _, oneCandidate := range candidates {
+		if !app.used[oneCandidate] {
+			app.used[oneCandidate] = true
+			return oneCandidate
+		}
+	}
+
+	for index := 2; ; index++ {
+		candidate := fmt.Sprintf("%s%d", candidates[0], index)
+		if !app.used[candidate] {
+			app.used[candidate] = true
+			return candidate
+		}
+	}
+}
+
+func (obj field) jsonName() string {
+	return toSnakeCase(obj.name)
+}
+
+func (obj field) structType() string {
+	if obj.typ.isSlice {
+		return fmt.Sprintf("[]%s", obj.typ.hydrated)
+	}
+
+	return obj.typ.hydrated
+}
+
+// hydrate returns the statements that set the field of out from the getter of obj
+func (obj field) hydrate(nms *names) []string {
+	src := fmt.Sprintf("obj.%s()", obj.name)
+	dst := fmt.Sprintf("out.%s", obj.name)
+
+	out := []string{}
+	if obj.typ.isSlice {
+		one := nms.next("one", toSingular(obj.name))
+		stmts, expr := obj.typ.hydrateElement(one, toSingular(obj.name), nms)
+		out = append(out, fmt.Sprintf("%s = []%s{}", dst, obj.typ.hydrated))
+		out = append(out, fmt.Sprintf("for _, %s := range %s {", one, src))
+		out = append(out, stmts...)
+		out = append(out, fmt.Sprintf("%s = append(%s, %s)", dst, dst, expr))
+		out = append(out, "}")
+	} else {
+		stmts, expr := obj.typ.hydrateElement(src, obj.name, nms)
+		out = append(out, stmts...)
+		out = append(out, fmt.Sprintf("%s = %s", dst, expr))
+	}
+
+	if obj.isOptional {
+		return wrap(fmt.Sprintf("if obj.Has%s() {", obj.name), out)
+	}
+
+	if !obj.typ.isSlice && (obj.typ.isPointer || obj.typ.kind == kindNested) {
+		return wrap(fmt.Sprintf("if %s != nil {", src), out)
+	}
+
+	return out
+}
+
+// dehydrate returns the statements that add the field of ptr to the builder
+func (obj field) dehydrate(nms *names) []string {
+	src := fmt.Sprintf("ptr.%s", obj.name)
+
+	out := []string{}
+	if obj.typ.isSlice {
+		list := nms.next("", obj.name)
+		one := nms.next("one", toSingular(obj.name))
+		stmts, expr := obj.typ.dehydrateElement(one, toSingular(obj.name), nms)
+		out = append(out, fmt.Sprintf("%s := []%s{}", list, obj.typ.domain))
+		out = append(out, fmt.Sprintf("for _, %s := range %s {", one, src))
+		out = append(out, stmts...)
+		out = append(out, fmt.Sprintf("%s = append(%s, %s)", list, list, expr))
+		out = append(out, "}")
+		out = append(out, fmt.Sprintf("builder.%s(%s)", obj.setter, list))
+	} else {
+		stmts, expr := obj.typ.dehydrateElement(src, obj.name, nms)
+		out = append(out, stmts...)
+		out = append(out, fmt.Sprintf("builder.%s(%s)", obj.setter, expr))
+	}
+
+	// a nil nested pointer cannot be dehydrated, so it is always skipped:
+	if obj.typ.isSlice && obj.isOptional {
+		return wrap(fmt.Sprintf("if len(%s) > 0 {", src), out)
+	}
+
+	if !obj.typ.isSlice && (obj.isOptional || obj.typ.kind == kindNested) {
+		return wrap(fmt.Sprintf("if %s != %s {", src, obj.typ.zero()), out)
+	}
+
+	return out
+}
+
+func (obj fieldType) zero() string {
+	switch obj.kind {
+	case kindBasic:
+		return basicTypes[obj.domain]
+	case kindDuration:
+		return "0"
+	case kindNested:
+		return "nil"
+	}
+
+	return `""`
+}
+
+func (obj fieldType) hydrateElement(src string, base string, nms *names) ([]string, string) {
+	switch obj.kind {
+	case kindHash, kindHashAlgorithm, kindUUID:
+		return nil, fmt.Sprintf("%s.String()", src)
+	case kindTime:
+		return nil, fmt.Sprintf("%s.Format(time.RFC3339Nano)", src)
+	case kindDuration:
+		return nil, fmt.Sprintf("int64(%s)", src)
+	case kindNested:
+		hydrated := nms.next("ret", base)
+		casted := nms.next("", base)
+		return []string{
+			fmt.Sprintf("%s, err := adapter.Hydrate(%s)", hydrated, src),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+			fmt.Sprintf("%s, ok := %s.(%s)", casted, hydrated, obj.hydrated),
+			"if !ok {",
+			fmt.Sprintf(`return nil, errors.New("the hydrated instance was expected to be a %s instance")`, obj.hydrated),
+			"}",
+		}, casted
+	}
+
+	return nil, src
+}
+
+func (obj fieldType) dehydrateElement(src string, base string, nms *names) ([]string, string) {
+	switch obj.kind {
+	case kindHash:
+		hsh := nms.next("", base)
+		return []string{
+			fmt.Sprintf("%s, err := hash.NewAdapter().FromString(%s)", hsh, src),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+		}, fmt.Sprintf("*%s", hsh)
+	case kindHashAlgorithm:
+		// an empty algorithm has been hydrated before the algorithm was recorded, so it is the default one:
+		algorithm := nms.next("", base)
+		retAlgorithm := nms.next("ret", base)
+		return []string{
+			fmt.Sprintf("%s := hash.DefaultAlgorithm", algorithm),
+			fmt.Sprintf(`if %s != "" {`, src),
+			fmt.Sprintf("%s, err := hash.ToAlgorithm(%s)", retAlgorithm, src),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+			"",
+			fmt.Sprintf("%s = %s", algorithm, retAlgorithm),
+			"}",
+		}, algorithm
+	case kindUUID:
+		id := nms.next("", base)
+		return []string{
+			fmt.Sprintf("%s, err := uuid.FromString(%s)", id, src),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+		}, obj.reference(id)
+	case kindTime:
+		tm := nms.next("", base)
+		return []string{
+			fmt.Sprintf("%s, err := time.Parse(time.RFC3339Nano, %s)", tm, src),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+		}, obj.reference(tm)
+	case kindDuration:
+		return nil, fmt.Sprintf("time.Duration(%s)", src)
+	case kindNested:
+		dehydrated := nms.next("ret", base)
+		casted := nms.next("", base)
+		return []string{
+			fmt.Sprintf("%s, err := adapter.Dehydrate(%s)", dehydrated, src),
+			"if err != nil {",
+			"return nil, err",
+			"}",
+			fmt.Sprintf("%s, ok := %s.(%s)", casted, dehydrated, obj.domain),
+			"if !ok {",
+			fmt.Sprintf(`return nil, errors.New("the dehydrated instance was expected to be a %s instance")`, obj.domain),
+			"}",
+		}, casted
+	}
+
+	return nil, src
+}
+
+func (obj fieldType) reference(name string) string {
+	if obj.isPointer {
+		return fmt.Sprintf("&%s", name)
+	}
+
+	return name
+}
+
+func wrap(open string, stmts []string) []string {
+	out := []string{open}
+	out = append(out, stmts...)
+	return append(out, "}")
+}
+
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	out := []rune{}
+	for index, oneRune := range runes {
+		isUpper := oneRune >= 'A' && oneRune <= 'Z'
+		if isUpper && index > 0 {
+			prevIsLower := runes[index-1] < 'A' || runes[index-1] > 'Z'
+			nextIsLower := index+1 < len(runes) && (runes[index+1] < 'A' || runes[index+1] > 'Z')
+			if prevIsLower || nextIsLower {
+				out = append(out, '_')
+			}
+		}
+
+		out = append(out, []rune(strings.ToLower(string(oneRune)))...)
+	}
+
+	return string(out)
+}
+
+// toLowerCamelCase lower cases the leading upper case letters of a name, except the one that starts the next word
+func toLowerCamelCase(name string) string {
+	runes := []rune(name)
+	for index, oneRune := range runes {
+		isUpper := oneRune >= 'A' && oneRune <= 'Z'
+		if !isUpper {
+			break
+		}
+
+		isNextLower := index+1 < len(runes) && runes[index+1] >= 'a' && runes[index+1] <= 'z'
+		if index > 0 && isNextLower {
+			break
+		}
+
+		runes[index] = oneRune - 'A' + 'a'
+	}
+
+	return string(runes)
+}
+
+// toSingular returns the singular of a plural name, or the name if it is not plural
+func toSingular(name string) string {
+	for _, oneSuffix := range []string{"shes", "ches", "sses", "xes"} {
+		if strings.HasSuffix(name, oneSuffix) {
+			return strings.TrimSuffix(name, "es")
+		}
+	}
+
+	if strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") {
+		return strings.TrimSuffix(name, "s")
+	}
+
+	return name
+}
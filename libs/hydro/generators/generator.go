@@ -0,0 +1,519 @@
+package generators
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type declaredInterface struct {
+	iface *ast.InterfaceType
+	file  *ast.File
+}
+
+type generator struct {
+}
+
+func createGenerator() Generator {
+	out := generator{}
+	return &out
+}
+
+// Generate generates the hydrated struct, the codec, the bridge and its round-trip test of the spec
+func (app *generator) Generate(spec Spec) (Output, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, spec.Directory(), func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		str := fmt.Sprintf("the directory (%s) was expected to contain 1 package, %d found", spec.Directory(), len(pkgs))
+		return nil, errors.New(str)
+	}
+
+	domainPkg := ""
+	interfaces := map[string]declaredInterface{}
+	funcs := map[string]*ast.FuncDecl{}
+	for name, pkg := range pkgs {
+		domainPkg = name
+		for _, oneFile := range pkg.Files {
+			for _, oneDecl := range oneFile.Decls {
+				if fn, ok := oneDecl.(*ast.FuncDecl); ok && fn.Recv == nil {
+					funcs[fn.Name.Name] = fn
+					continue
+				}
+
+				if gen, ok := oneDecl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+					for _, oneSpec := range gen.Specs {
+						typeSpec := oneSpec.(*ast.TypeSpec)
+						if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+							interfaces[typeSpec.Name.Name] = declaredInterface{
+								iface: iface,
+								file:  oneFile,
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if domainPkg == spec.Package() {
+		str := fmt.Sprintf("the generated package (%s) cannot be the domain package", spec.Package())
+		return nil, errors.New(str)
+	}
+
+	ifaceName := spec.Interface()
+	declared, ok := interfaces[ifaceName]
+	if !ok {
+		str := fmt.Sprintf("the interface (%s) is not declared in the package (%s)", ifaceName, domainPkg)
+		return nil, errors.New(str)
+	}
+
+	getters, err := app.methods(ifaceName, interfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	// the builder is named after the interface, or is the main builder of the package:
+	builderName := ""
+	var builderMethods map[string]*ast.FuncType
+	for _, oneName := range []string{fmt.Sprintf("%sBuilder", ifaceName), "Builder"} {
+		if _, ok := interfaces[oneName]; !ok {
+			continue
+		}
+
+		methods, err := app.methods(oneName, interfaces)
+		if err != nil {
+			return nil, err
+		}
+
+		if now, ok := methods["Now"]; ok && now.Results != nil && len(now.Results.List) == 2 && types.ExprString(now.Results.List[0].Type) == ifaceName {
+			builderName = oneName
+			builderMethods = methods
+			break
+		}
+	}
+
+	if builderName == "" {
+		str := fmt.Sprintf("there is no builder that builds the interface (%s) in the package (%s)", ifaceName, domainPkg)
+		return nil, errors.New(str)
+	}
+
+	builderConstructor := fmt.Sprintf("New%s", builderName)
+	if !hasNoParams(funcs, builderConstructor) {
+		str := fmt.Sprintf("the builder constructor (%s) was expected to be declared without parameters in the package (%s)", builderConstructor, domainPkg)
+		return nil, errors.New(str)
+	}
+
+	pointerConstructor := fmt.Sprintf("New%sPointer", ifaceName)
+	if !hasNoParams(funcs, pointerConstructor) {
+		pointerConstructor = "NewPointer"
+		if builderName != "Builder" || !hasNoParams(funcs, pointerConstructor) {
+			str := fmt.Sprintf("the pointer constructor (New%sPointer) was expected to be declared without parameters in the package (%s)", ifaceName, domainPkg)
+			return nil, errors.New(str)
+		}
+	}
+
+	// the imports of the file that declares the interface:
+	fileImports := map[string]string{}
+	for _, oneImport := range declared.file.Imports {
+		importPath, _ := strconv.Unquote(oneImport.Path.Value)
+		name := path.Base(importPath)
+		if oneImport.Name != nil {
+			name = oneImport.Name.Name
+		}
+
+		fileImports[name] = importPath
+	}
+
+	imports := map[string]string{
+		"errors":  "errors",
+		"hydro":   hydroImportPath,
+		domainPkg: spec.ImportPath(),
+	}
+
+	hydratedName := func(name string) string {
+		if spec.IsUnexported() {
+			return fmt.Sprintf("hydrated%s", name)
+		}
+
+		return fmt.Sprintf("Hydrated%s", name)
+	}
+
+	fields := []field{}
+	hasNested := false
+	for _, oneName := range sortedMethodNames(declared.iface, interfaces) {
+		getter := getters[oneName]
+		if strings.HasPrefix(oneName, "Has") || strings.HasPrefix(oneName, "Is") {
+			if _, ok := getters[strings.TrimPrefix(strings.TrimPrefix(oneName, "Has"), "Is")]; ok {
+				continue
+			}
+		}
+
+		// only the getters are hydrated, the other methods are behaviors:
+		if (getter.Params != nil && len(getter.Params.List) > 0) || getter.Results == nil || len(getter.Results.List) != 1 {
+			continue
+		}
+
+		resultType := getter.Results.List[0].Type
+		typ, err := app.fieldType(resultType, domainPkg, fileImports, imports, interfaces, hydratedName)
+		if err != nil {
+			str := fmt.Sprintf("the method (%s) of the interface (%s) cannot be generated: %s", oneName, ifaceName, err.Error())
+			return nil, errors.New(str)
+		}
+
+		if typ.kind == kindNested {
+			hasNested = true
+		}
+
+		setter := ""
+		for _, oneSetter := range []string{fmt.Sprintf("With%s", oneName), oneName} {
+			if method, ok := builderMethods[oneSetter]; ok && method.Params != nil && len(method.Params.List) == 1 {
+				if types.ExprString(method.Params.List[0].Type) == types.ExprString(resultType) {
+					setter = oneSetter
+					break
+				}
+			}
+		}
+
+		_, isOptional := getters[fmt.Sprintf("Has%s", oneName)]
+		fields = append(fields, field{
+			name:       oneName,
+			typ:        typ,
+			isOptional: isOptional,
+			setter:     setter,
+		})
+	}
+
+	// the computed hashes are not given to the builder, they are compared to the rebuilt ones instead:
+	computedHashes := []field{}
+	for _, oneField := range fields {
+		if oneField.setter == "" && oneField.typ.kind == kindHash && !oneField.typ.isSlice && !oneField.isOptional {
+			computedHashes = append(computedHashes, oneField)
+		}
+	}
+
+	if len(computedHashes) > 0 {
+		imports["fmt"] = "fmt"
+	}
+
+	name := toSnakeCase(ifaceName)
+	lowerName := fmt.Sprintf("%s%s", strings.ToLower(ifaceName[:1]), ifaceName[1:])
+	qualifiedName := fmt.Sprintf("%s.%s", domainPkg, ifaceName)
+	hydrated := hydratedName(ifaceName)
+	codecName := fmt.Sprintf("%sCodec", lowerName)
+	bridgeConstructor := fmt.Sprintf("new%sBridge", ifaceName)
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%s\n\npackage %s\n\n", generatedHeader, spec.Package())
+	writeImports(buf, imports)
+
+	fmt.Fprintf(buf, "// %s represents an hydrated %s\n", hydrated, qualifiedName)
+	fmt.Fprintf(buf, "type %s struct {\n", hydrated)
+	for _, oneField := range fields {
+		fmt.Fprintf(buf, "%s %s `json:\"%s\"`\n", oneField.name, oneField.structType(), oneField.jsonName())
+	}
+
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "type %s struct {\n}\n\n", codecName)
+
+	hydrateNames := createNames(domainPkg)
+	fmt.Fprintf(buf, "// Hydrate hydrates a %s instance\n", qualifiedName)
+	fmt.Fprintf(buf, "func (app *%s) Hydrate(adapter hydro.Adapter, dehydrated interface{}) (interface{}, error) {\n", codecName)
+	fmt.Fprintf(buf, "obj, ok := dehydrated.(%s)\n", qualifiedName)
+	fmt.Fprintf(buf, "if !ok {\nreturn nil, errors.New(\"the dehydrated instance was expected to be a %s instance\")\n}\n\n", qualifiedName)
+	fmt.Fprintf(buf, "out := new(%s)\n", hydrated)
+	hydrateBlocks := [][]string{}
+	for _, oneField := range fields {
+		hydrateBlocks = append(hydrateBlocks, oneField.hydrate(hydrateNames))
+	}
+
+	writeBlocks(buf, hydrateBlocks)
+
+	fmt.Fprintf(buf, "\nreturn out, nil\n}\n\n")
+
+	dehydrateNames := createNames(domainPkg)
+	fmt.Fprintf(buf, "// Dehydrate dehydrates a %s instance\n", hydrated)
+	fmt.Fprintf(buf, "func (app *%s) Dehydrate(adapter hydro.Adapter, hydrated interface{}) (interface{}, error) {\n", codecName)
+	fmt.Fprintf(buf, "ptr, ok := hydrated.(*%s)\n", hydrated)
+	fmt.Fprintf(buf, "if !ok {\nreturn nil, errors.New(\"the hydrated instance was expected to be a *%s instance\")\n}\n\n", hydrated)
+	fmt.Fprintf(buf, "builder := %s.%s().Create()\n", domainPkg, builderConstructor)
+	dehydrateBlocks := [][]string{}
+	for _, oneField := range fields {
+		// computed fields are only hydrated:
+		if oneField.setter == "" {
+			continue
+		}
+
+		dehydrateBlocks = append(dehydrateBlocks, oneField.dehydrate(dehydrateNames))
+	}
+
+	writeBlocks(buf, dehydrateBlocks)
+
+	if len(computedHashes) <= 0 {
+		fmt.Fprintf(buf, "\nreturn builder.Now()\n}\n\n")
+	} else {
+		fmt.Fprintf(buf, "\nins, err := builder.Now()\nif err != nil {\nreturn nil, err\n}\n\n")
+		for _, oneField := range computedHashes {
+			fmt.Fprintf(buf, "if ins.%s().String() != ptr.%s {\n", oneField.name, oneField.name)
+			fmt.Fprintf(buf, "str := fmt.Sprintf(\"the stored %s (%%s) of the %s does not match the computed one (%%s)\", ptr.%s, ins.%s().String())\n", toSnakeCase(oneField.name), qualifiedName, oneField.name, oneField.name)
+			fmt.Fprintf(buf, "return nil, errors.New(str)\n}\n\n")
+		}
+
+		fmt.Fprintf(buf, "return ins, nil\n}\n\n")
+	}
+
+	fmt.Fprintf(buf, "func %s() (hydro.Bridge, error) {\n", bridgeConstructor)
+	fmt.Fprintf(buf, "return hydro.NewBridgeBuilder().Create().\n")
+	fmt.Fprintf(buf, "WithDehydratedInterface((*%s)(nil)).\n", qualifiedName)
+	fmt.Fprintf(buf, "WithDehydratedPointer(%s.%s()).\n", domainPkg, pointerConstructor)
+	fmt.Fprintf(buf, "WithHydratedPointer(new(%s)).\n", hydrated)
+	fmt.Fprintf(buf, "WithCodec(new(%s)).\n", codecName)
+	fmt.Fprintf(buf, "Now()\n}\n")
+
+	bridge, err := format.Source(buf.Bytes())
+	if err != nil {
+		str := fmt.Sprintf("the generated bridge of the interface (%s) is invalid: %s\n%s", ifaceName, err.Error(), buf.String())
+		return nil, errors.New(str)
+	}
+
+	// the round-trip test needs the test helper of the domain, and the bridges of nested instances are unknown here:
+	testHelper := fmt.Sprintf("Create%sForTests", ifaceName)
+	if hasNested || !hasNoParams(funcs, testHelper) {
+		return createOutput(name, bridge), nil
+	}
+
+	testBuf := new(bytes.Buffer)
+	fmt.Fprintf(testBuf, "%s\n\npackage %s\n\n", generatedHeader, spec.Package())
+	writeImports(testBuf, map[string]string{
+		"testing": "testing",
+		"hydro":   hydroImportPath,
+		domainPkg: spec.ImportPath(),
+	})
+
+	fmt.Fprintf(testBuf, "func TestGenerated%sBridge_Success(t *testing.T) {\n", ifaceName)
+	fmt.Fprintf(testBuf, "bridge, err := %s()\n", bridgeConstructor)
+	fmt.Fprintf(testBuf, "if err != nil {\n%s\nreturn\n}\n\n", testErrorLine)
+	fmt.Fprintf(testBuf, "manager := hydro.NewManagerFactory().Create()\nmanager.Register(bridge)\n")
+	fmt.Fprintf(testBuf, "adapter, err := hydro.NewAdapterBuilder().Create().WithManager(manager).Now()\n")
+	fmt.Fprintf(testBuf, "if err != nil {\n%s\nreturn\n}\n\n", testErrorLine)
+	fmt.Fprintf(testBuf, "hydro.VerifyAdapterUsingJSForTests(adapter, %s.%s(), t)\n}\n", domainPkg, testHelper)
+
+	test, err := format.Source(testBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return createOutputWithTest(name, bridge, test), nil
+}
+
+// methods returns the methods of an interface, including the ones of its embedded interfaces
+func (app *generator) methods(name string, interfaces map[string]declaredInterface) (map[string]*ast.FuncType, error) {
+	declared, ok := interfaces[name]
+	if !ok {
+		str := fmt.Sprintf("the interface (%s) is not declared in the domain package", name)
+		return nil, errors.New(str)
+	}
+
+	out := map[string]*ast.FuncType{}
+	for _, oneMethod := range declared.iface.Methods.List {
+		if len(oneMethod.Names) <= 0 {
+			embedded, err := app.methods(types.ExprString(oneMethod.Type), interfaces)
+			if err != nil {
+				return nil, err
+			}
+
+			for name, fn := range embedded {
+				out[name] = fn
+			}
+
+			continue
+		}
+
+		out[oneMethod.Names[0].Name] = oneMethod.Type.(*ast.FuncType)
+	}
+
+	return out, nil
+}
+
+func (app *generator) fieldType(
+	expr ast.Expr,
+	domainPkg string,
+	fileImports map[string]string,
+	imports map[string]string,
+	interfaces map[string]declaredInterface,
+	hydratedName func(name string) string,
+) (fieldType, error) {
+	isSlice := false
+	if array, ok := expr.(*ast.ArrayType); ok && array.Len == nil {
+		isSlice = true
+		expr = array.Elt
+	}
+
+	exprStr := types.ExprString(expr)
+	useImport := func(name string) error {
+		importPath, ok := fileImports[name]
+		if !ok {
+			str := fmt.Sprintf("the package (%s) is not imported", name)
+			return errors.New(str)
+		}
+
+		imports[name] = importPath
+		return nil
+	}
+
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		if _, ok := basicTypes[exprStr]; ok {
+			return fieldType{kind: kindBasic, isSlice: isSlice, domain: exprStr, hydrated: exprStr}, nil
+		}
+
+		if _, ok := interfaces[exprStr]; ok {
+			return fieldType{
+				kind:     kindNested,
+				isSlice:  isSlice,
+				domain:   fmt.Sprintf("%s.%s", domainPkg, exprStr),
+				hydrated: fmt.Sprintf("*%s", hydratedName(exprStr)),
+			}, nil
+		}
+	case *ast.StarExpr:
+		switch types.ExprString(typed.X) {
+		case "uuid.UUID":
+			if fileImports["uuid"] == uuidImportPath {
+				imports["uuid"] = uuidImportPath
+				return fieldType{kind: kindUUID, isSlice: isSlice, isPointer: true, domain: exprStr, hydrated: "string"}, nil
+			}
+		case "time.Time":
+			imports["time"] = "time"
+			return fieldType{kind: kindTime, isSlice: isSlice, isPointer: true, domain: exprStr, hydrated: "string"}, nil
+		}
+	case *ast.SelectorExpr:
+		pkgName := types.ExprString(typed.X)
+		switch {
+		case exprStr == "hash.Hash" && fileImports[pkgName] == hashImportPath:
+			imports[pkgName] = hashImportPath
+			return fieldType{kind: kindHash, isSlice: isSlice, domain: exprStr, hydrated: "string"}, nil
+		case exprStr == "hash.Algorithm" && fileImports[pkgName] == hashImportPath:
+			imports[pkgName] = hashImportPath
+			return fieldType{kind: kindHashAlgorithm, isSlice: isSlice, domain: exprStr, hydrated: "string"}, nil
+		case exprStr == "time.Time":
+			imports["time"] = "time"
+			return fieldType{kind: kindTime, isSlice: isSlice, domain: exprStr, hydrated: "string"}, nil
+		case exprStr == "time.Duration":
+			imports["time"] = "time"
+			return fieldType{kind: kindDuration, isSlice: isSlice, domain: exprStr, hydrated: "int64"}, nil
+		}
+
+		// any other qualified type is an interface of another domain package:
+		if err := useImport(pkgName); err != nil {
+			return fieldType{}, err
+		}
+
+		return fieldType{
+			kind:     kindNested,
+			isSlice:  isSlice,
+			domain:   exprStr,
+			hydrated: fmt.Sprintf("*%s", hydratedName(typed.Sel.Name)),
+		}, nil
+	}
+
+	str := fmt.Sprintf("the type (%s) is not supported", exprStr)
+	return fieldType{}, errors.New(str)
+}
+
+// sortedMethodNames returns the method names in declaration order, the embedded ones first
+func sortedMethodNames(iface *ast.InterfaceType, interfaces map[string]declaredInterface) []string {
+	out := []string{}
+	for _, oneMethod := range iface.Methods.List {
+		if len(oneMethod.Names) <= 0 {
+			if embedded, ok := interfaces[types.ExprString(oneMethod.Type)]; ok {
+				out = append(out, sortedMethodNames(embedded.iface, interfaces)...)
+			}
+
+			continue
+		}
+
+		out = append(out, oneMethod.Names[0].Name)
+	}
+
+	return out
+}
+
+func hasNoParams(funcs map[string]*ast.FuncDecl, name string) bool {
+	fn, ok := funcs[name]
+	if !ok {
+		return false
+	}
+
+	return fn.Type.Params == nil || len(fn.Type.Params.List) <= 0
+}
+
+func writeImports(buf *bytes.Buffer, imports map[string]string) {
+	std := []string{}
+	others := []string{}
+	for name, importPath := range imports {
+		line := fmt.Sprintf("%q", importPath)
+		if path.Base(importPath) != name {
+			line = fmt.Sprintf("%s %q", name, importPath)
+		}
+
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			others = append(others, line)
+			continue
+		}
+
+		std = append(std, line)
+	}
+
+	sort.Strings(std)
+	sort.Strings(others)
+	fmt.Fprintf(buf, "import (\n")
+	for _, oneLine := range std {
+		fmt.Fprintf(buf, "%s\n", oneLine)
+	}
+
+	if len(std) > 0 && len(others) > 0 {
+		fmt.Fprintf(buf, "\n")
+	}
+
+	for _, oneLine := range others {
+		fmt.Fprintf(buf, "%s\n", oneLine)
+	}
+
+	fmt.Fprintf(buf, ")\n\n")
+}
+
+// writeBlocks writes the statements of each field, separating the multi-line ones with an empty line
+func writeBlocks(buf *bytes.Buffer, blocks [][]string) {
+	prevIsMultiLine := true
+	for _, oneBlock := range blocks {
+		isMultiLine := len(oneBlock) > 1
+		if isMultiLine || prevIsMultiLine {
+			fmt.Fprintf(buf, "\n")
+		}
+
+		for index, oneStmt := range oneBlock {
+			fmt.Fprintf(buf, "%s\n", oneStmt)
+			if oneStmt == "}" && index+1 < len(oneBlock) && oneBlock[index+1] != "}" {
+				fmt.Fprintf(buf, "\n")
+			}
+		}
+
+		prevIsMultiLine = isMultiLine
+	}
+}
@@ -0,0 +1,156 @@
+package generators
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_Success(t *testing.T) {
+	spec, err := NewSpecBuilder().Create().
+		WithDirectory("./testdata/things").
+		WithImportPath("github.com/deepvalue-network/software/libs/hydro/generators/testdata/things").
+		WithInterface("Thing").
+		WithPackage("disks").
+		Now()
+
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	output, err := NewGenerator().Generate(spec)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if output.Name() != "thing" {
+		t.Errorf("the name was expected to be %s, %s returned", "thing", output.Name())
+		return
+	}
+
+	_, err = parser.ParseFile(token.NewFileSet(), "bridge.go", output.Bridge(), 0)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the alignment of the struct is not relevant:
+	bridge := strings.Join(strings.Fields(string(output.Bridge())), " ")
+	expected := []string{
+		"type HydratedThing struct",
+		"ID string `json:\"id\"`",
+		"Parts []*HydratedPart `json:\"parts\"`",
+		"CreatedOn string `json:\"created_on\"`",
+		"if obj.HasParts() {",
+		"builder.WithID(&",
+		"builder.CreatedOn(",
+		"WithCodec(new(thingCodec))",
+		"for _, onePart := range ptr.Parts {",
+		"parts = append(parts, part)",
+		"builder.WithHash(*retHash)",
+		"builder.CreatedOn(createdOn)",
+	}
+
+	for _, oneExpected := range expected {
+		if !strings.Contains(bridge, oneExpected) {
+			t.Errorf("the generated bridge was expected to contain '%s':\n%s", oneExpected, bridge)
+			return
+		}
+	}
+
+	// the Name getter has no setter, and the Add method is a behavior:
+	if strings.Contains(bridge, "builder.WithName") || strings.Contains(bridge, "Add") {
+		t.Errorf("the generated bridge was expected to skip the Name setter and the Add method:\n%s", bridge)
+		return
+	}
+
+	// the bridge of the nested parts is unknown, so there is no test:
+	if output.HasTest() {
+		t.Errorf("the output was expected to not contain a test")
+		return
+	}
+}
+
+func TestGenerate_withTest_Success(t *testing.T) {
+	spec, _ := NewSpecBuilder().Create().
+		WithDirectory("./testdata/things").
+		WithImportPath("github.com/deepvalue-network/software/libs/hydro/generators/testdata/things").
+		WithInterface("Part").
+		WithPackage("servers").
+		IsUnexported().
+		Now()
+
+	output, err := NewGenerator().Generate(spec)
+	if err == nil {
+		t.Errorf("the error was expected to be valid since Part has no builder, nil returned")
+		return
+	}
+
+	spec, _ = NewSpecBuilder().Create().
+		WithDirectory("./testdata/things").
+		WithImportPath("github.com/deepvalue-network/software/libs/hydro/generators/testdata/things").
+		WithInterface("Thing").
+		WithPackage("servers").
+		IsUnexported().
+		Now()
+
+	output, err = NewGenerator().Generate(spec)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !strings.Contains(string(output.Bridge()), "type hydratedThing struct") {
+		t.Errorf("the hydrated struct was expected to be unexported:\n%s", output.Bridge())
+		return
+	}
+}
+
+func TestGenerate_withComputedHash_Success(t *testing.T) {
+	spec, _ := NewSpecBuilder().Create().
+		WithDirectory("./testdata/things").
+		WithImportPath("github.com/deepvalue-network/software/libs/hydro/generators/testdata/things").
+		WithInterface("Label").
+		WithPackage("disks").
+		Now()
+
+	output, err := NewGenerator().Generate(spec)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the hash has no setter, so the rebuilt one is compared to the stored one:
+	bridge := strings.Join(strings.Fields(string(output.Bridge())), " ")
+	expected := []string{
+		"builder.WithName(ptr.Name)",
+		"ins, err := builder.Now()",
+		"if ins.Hash().String() != ptr.Hash {",
+		"return ins, nil",
+	}
+
+	for _, oneExpected := range expected {
+		if !strings.Contains(bridge, oneExpected) {
+			t.Errorf("the generated bridge was expected to contain '%s':\n%s", oneExpected, bridge)
+			return
+		}
+	}
+}
+
+func TestGenerate_interfaceNotDeclared_returnsError(t *testing.T) {
+	spec, _ := NewSpecBuilder().Create().
+		WithDirectory("./testdata/things").
+		WithImportPath("github.com/deepvalue-network/software/libs/hydro/generators/testdata/things").
+		WithInterface("Unknown").
+		WithPackage("disks").
+		Now()
+
+	_, err := NewGenerator().Generate(spec)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
@@ -0,0 +1,56 @@
+package generators
+
+type output struct {
+	name   string
+	bridge []byte
+	test   []byte
+}
+
+func createOutput(
+	name string,
+	bridge []byte,
+) Output {
+	return createOutputInternally(name, bridge, nil)
+}
+
+func createOutputWithTest(
+	name string,
+	bridge []byte,
+	test []byte,
+) Output {
+	return createOutputInternally(name, bridge, test)
+}
+
+func createOutputInternally(
+	name string,
+	bridge []byte,
+	test []byte,
+) Output {
+	out := output{
+		name:   name,
+		bridge: bridge,
+		test:   test,
+	}
+
+	return &out
+}
+
+// Name returns the snake case name of the interface, used to name the generated files
+func (obj *output) Name() string {
+	return obj.name
+}
+
+// Bridge returns the generated hydrated struct, codec and bridge
+func (obj *output) Bridge() []byte {
+	return obj.bridge
+}
+
+// HasTest returns true if there is a generated test, false otherwise
+func (obj *output) HasTest() bool {
+	return obj.test != nil
+}
+
+// Test returns the generated round-trip test, if any
+func (obj *output) Test() []byte {
+	return obj.test
+}
@@ -0,0 +1,54 @@
+package generators
+
+const generatedHeader = "// Code generated by hydrogen. DO NOT EDIT."
+
+const hydroImportPath = "github.com/deepvalue-network/software/libs/hydro"
+
+const hashImportPath = "github.com/deepvalue-network/software/libs/hash"
+
+const uuidImportPath = "github.com/satori/go.uuid"
+
+const testErrorLine = `t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())`
+
+// NewGenerator creates a new generator instance
+func NewGenerator() Generator {
+	return createGenerator()
+}
+
+// NewSpecBuilder creates a new spec builder instance
+func NewSpecBuilder() SpecBuilder {
+	return createSpecBuilder()
+}
+
+// Generator generates the hydrated struct, the bridge and its tests of a domain interface
+type Generator interface {
+	Generate(spec Spec) (Output, error)
+}
+
+// SpecBuilder represents a spec builder
+type SpecBuilder interface {
+	Create() SpecBuilder
+	WithDirectory(dir string) SpecBuilder
+	WithImportPath(importPath string) SpecBuilder
+	WithInterface(name string) SpecBuilder
+	WithPackage(pkg string) SpecBuilder
+	IsUnexported() SpecBuilder
+	Now() (Spec, error)
+}
+
+// Spec represents what to generate: the domain interface, where it is declared and the package to generate in
+type Spec interface {
+	Directory() string
+	ImportPath() string
+	Interface() string
+	Package() string
+	IsUnexported() bool
+}
+
+// Output represents the generated files
+type Output interface {
+	Name() string
+	Bridge() []byte
+	HasTest() bool
+	Test() []byte
+}
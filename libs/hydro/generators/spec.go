@@ -0,0 +1,52 @@
+package generators
+
+type spec struct {
+	dir          string
+	importPath   string
+	iface        string
+	pkg          string
+	isUnexported bool
+}
+
+func createSpec(
+	dir string,
+	importPath string,
+	iface string,
+	pkg string,
+	isUnexported bool,
+) Spec {
+	out := spec{
+		dir:          dir,
+		importPath:   importPath,
+		iface:        iface,
+		pkg:          pkg,
+		isUnexported: isUnexported,
+	}
+
+	return &out
+}
+
+// Directory returns the directory of the domain package
+func (obj *spec) Directory() string {
+	return obj.dir
+}
+
+// ImportPath returns the import path of the domain package
+func (obj *spec) ImportPath() string {
+	return obj.importPath
+}
+
+// Interface returns the name of the domain interface
+func (obj *spec) Interface() string {
+	return obj.iface
+}
+
+// Package returns the name of the generated package
+func (obj *spec) Package() string {
+	return obj.pkg
+}
+
+// IsUnexported returns true if the hydrated structs are unexported, false otherwise
+func (obj *spec) IsUnexported() bool {
+	return obj.isUnexported
+}
@@ -0,0 +1,79 @@
+package generators
+
+import "errors"
+
+type specBuilder struct {
+	dir          string
+	importPath   string
+	iface        string
+	pkg          string
+	isUnexported bool
+}
+
+func createSpecBuilder() SpecBuilder {
+	out := specBuilder{
+		dir:          "",
+		importPath:   "",
+		iface:        "",
+		pkg:          "",
+		isUnexported: false,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *specBuilder) Create() SpecBuilder {
+	return createSpecBuilder()
+}
+
+// WithDirectory adds the directory of the domain package to the builder
+func (app *specBuilder) WithDirectory(dir string) SpecBuilder {
+	app.dir = dir
+	return app
+}
+
+// WithImportPath adds the import path of the domain package to the builder
+func (app *specBuilder) WithImportPath(importPath string) SpecBuilder {
+	app.importPath = importPath
+	return app
+}
+
+// WithInterface adds the name of the domain interface to the builder
+func (app *specBuilder) WithInterface(name string) SpecBuilder {
+	app.iface = name
+	return app
+}
+
+// WithPackage adds the name of the generated package to the builder
+func (app *specBuilder) WithPackage(pkg string) SpecBuilder {
+	app.pkg = pkg
+	return app
+}
+
+// IsUnexported flags the builder so that the hydrated structs are unexported
+func (app *specBuilder) IsUnexported() SpecBuilder {
+	app.isUnexported = true
+	return app
+}
+
+// Now builds a new Spec instance
+func (app *specBuilder) Now() (Spec, error) {
+	if app.dir == "" {
+		return nil, errors.New("the directory is mandatory in order to build a Spec instance")
+	}
+
+	if app.importPath == "" {
+		return nil, errors.New("the import path is mandatory in order to build a Spec instance")
+	}
+
+	if app.iface == "" {
+		return nil, errors.New("the interface is mandatory in order to build a Spec instance")
+	}
+
+	if app.pkg == "" {
+		return nil, errors.New("the package is mandatory in order to build a Spec instance")
+	}
+
+	return createSpec(app.dir, app.importPath, app.iface, app.pkg, app.isUnexported), nil
+}
@@ -0,0 +1,72 @@
+package things
+
+import (
+	"time"
+
+	"github.com/deepvalue-network/software/libs/hash"
+	uuid "github.com/satori/go.uuid"
+)
+
+// NewBuilder creates a new builder instance
+func NewBuilder() Builder {
+	return nil
+}
+
+// NewPointer returns a new thing pointer
+func NewPointer() *Thing {
+	return nil
+}
+
+// NewLabelBuilder creates a new label builder instance
+func NewLabelBuilder() LabelBuilder {
+	return nil
+}
+
+// NewLabelPointer returns a new label pointer
+func NewLabelPointer() *Label {
+	return nil
+}
+
+// CreateThingForTests creates a new thing instance for tests
+func CreateThingForTests() Thing {
+	return nil
+}
+
+// Builder represents a thing builder
+type Builder interface {
+	Create() Builder
+	WithID(id *uuid.UUID) Builder
+	WithHash(hash hash.Hash) Builder
+	WithParts(parts []Part) Builder
+	CreatedOn(createdOn time.Time) Builder
+	Now() (Thing, error)
+}
+
+// Thing represents a thing
+type Thing interface {
+	ID() *uuid.UUID
+	Hash() hash.Hash
+	Name() string
+	HasParts() bool
+	Parts() []Part
+	CreatedOn() time.Time
+	Add(part Part) error
+}
+
+// Part represents a part
+type Part interface {
+	Name() string
+}
+
+// LabelBuilder represents a label builder
+type LabelBuilder interface {
+	Create() LabelBuilder
+	WithName(name string) LabelBuilder
+	Now() (Label, error)
+}
+
+// Label represents a label, whose hash is computed from its name
+type Label interface {
+	Hash() hash.Hash
+	Name() string
+}
@@ -2,9 +2,45 @@ package hydro
 
 const doubleStringPattern = "%s/%s"
 
+// VersionKey represents the key of the version in an encoded payload
+const VersionKey = "hydro_version"
+
+// FirstVersion represents the version of the bridges without migrations, and of the payloads encoded without a version
+const FirstVersion = 1
+
+const (
+	// JSON represents the JSON format
+	JSON Format = iota + 1
+
+	// Binary represents the canonical, length-prefixed, binary format
+	Binary
+)
+
+// Format represents an encoding format
+type Format uint8
+
 // EventFn represents an event func
 type EventFn func(ins interface{}, fieldName string, structName string) (interface{}, error)
 
+// MigrationFn represents a func that upgrades a payload from a version to the next one; the numbers of the payload are
+// json.Number instances, and the nested hydrated structs are plain objects of the payload
+type MigrationFn func(payload map[string]interface{}) (map[string]interface{}, error)
+
+// FormatOf returns the format of an encoded payload
+func FormatOf(data []byte) Format {
+	return formatOf(data)
+}
+
+// MarshalBinary encodes a plain value, such as an hydrated instance or a list of strings, to its canonical binary form
+func MarshalBinary(ins interface{}) ([]byte, error) {
+	return marshalBinary(ins)
+}
+
+// UnmarshalBinary decodes a canonical binary form to the given pointer
+func UnmarshalBinary(data []byte, ptr interface{}) error {
+	return unmarshalBinary(data, ptr)
+}
+
 // NewAdapterBuilder creates a new adapter builder instance
 func NewAdapterBuilder() AdapterBuilder {
 	return createAdapterBuilder()
@@ -31,6 +67,11 @@ type AdapterBuilder interface {
 type Adapter interface {
 	Hydrate(dehydrate interface{}) (interface{}, error)
 	Dehydrate(hydrate interface{}) (interface{}, error)
+	Encode(dehydrated interface{}) ([]byte, error)
+	EncodeWithFormat(dehydrated interface{}, format Format) ([]byte, error)
+	Decode(data []byte, ptr interface{}) (interface{}, error)
+	Unmarshal(data []byte, ptr interface{}) error
+	Upgrade(data []byte, ptr interface{}) ([]byte, error)
 }
 
 // ManagerFactory represents a manager factory
@@ -53,6 +94,9 @@ type BridgeBuilder interface {
 	WithHydratedPointer(hydratedPointer interface{}) BridgeBuilder
 	OnHydrate(onHydrateFn EventFn) BridgeBuilder
 	OnDehydrate(onDehydrateFn EventFn) BridgeBuilder
+	WithCodec(codec Codec) BridgeBuilder
+	WithVersion(version uint) BridgeBuilder
+	WithMigration(from uint, migrationFn MigrationFn) BridgeBuilder
 	Now() (Bridge, error)
 }
 
@@ -60,6 +104,16 @@ type BridgeBuilder interface {
 type Bridge interface {
 	Hydrated() Hydrated
 	Dehydrated() Dehydrated
+	HasCodec() bool
+	Codec() Codec
+	Version() uint
+	Migrate(payload map[string]interface{}, from uint) (map[string]interface{}, error)
+}
+
+// Codec represents the generated conversions of a bridge, used instead of reflection when present
+type Codec interface {
+	Hydrate(adapter Adapter, dehydrated interface{}) (interface{}, error)
+	Dehydrate(adapter Adapter, hydrated interface{}) (interface{}, error)
 }
 
 // Hydrated represents an hydrated bridge
@@ -0,0 +1,367 @@
+package keystores
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/deepvalue-network/software/libs/cryptography/encryption"
+	pk_encryption "github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/hash"
+)
+
+type unlocked struct {
+	key   Key
+	timer *time.Timer
+}
+
+type application struct {
+	hashAdapter       hash.Adapter
+	keyBuilder        KeyBuilder
+	signatureAdapter  signature.PrivateKeyAdapter
+	encryptionAdapter pk_encryption.Adapter
+	eciesAdapter      ecies.Adapter
+	repository        files.Repository
+	service           files.Service
+	timeout           time.Duration
+	unlocked          map[string]*unlocked
+	mutex             sync.Mutex
+}
+
+func createApplication(
+	hashAdapter hash.Adapter,
+	keyBuilder KeyBuilder,
+	signatureAdapter signature.PrivateKeyAdapter,
+	encryptionAdapter pk_encryption.Adapter,
+	eciesAdapter ecies.Adapter,
+	repository files.Repository,
+	service files.Service,
+	timeout time.Duration,
+) Application {
+	out := application{
+		hashAdapter:       hashAdapter,
+		keyBuilder:        keyBuilder,
+		signatureAdapter:  signatureAdapter,
+		encryptionAdapter: encryptionAdapter,
+		eciesAdapter:      eciesAdapter,
+		repository:        repository,
+		service:           service,
+		timeout:           timeout,
+		unlocked:          map[string]*unlocked{},
+	}
+
+	return &out
+}
+
+// List lists the names of the stored keys
+func (app *application) List() ([]string, error) {
+	hashes, err := app.repository.List()
+	if err != nil {
+		return nil, err
+	}
+
+	out := []string{}
+	for _, oneHash := range hashes {
+		file, err := app.retrieveFile(oneHash.String())
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, file.Name)
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
+
+// Insert encrypts a key using the password and stores it
+func (app *application) Insert(key Key, password string) error {
+	fileName, err := app.fileName(key.Name())
+	if err != nil {
+		return err
+	}
+
+	content, err := app.encrypt(key, password)
+	if err != nil {
+		return err
+	}
+
+	return app.service.Insert(fileName, content)
+}
+
+// Retrieve retrieves a key by name and decrypts it using the password
+func (app *application) Retrieve(name string, password string) (Key, error) {
+	fileName, err := app.fileName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := app.retrieveFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.decrypt(file, password)
+}
+
+// Import stores an exported key file and returns the name of its key
+func (app *application) Import(content []byte) (string, error) {
+	file, err := fromBytesToKeyFile(content)
+	if err != nil {
+		return "", err
+	}
+
+	fileName, err := app.fileName(file.Name)
+	if err != nil {
+		return "", err
+	}
+
+	err = app.service.Insert(fileName, content)
+	if err != nil {
+		return "", err
+	}
+
+	return file.Name, nil
+}
+
+// Export exports the encrypted key file of a key
+func (app *application) Export(name string) ([]byte, error) {
+	fileName, err := app.fileName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.retrieveContent(fileName)
+}
+
+// ChangePassword re-encrypts a key using the updated password
+func (app *application) ChangePassword(name string, originalPassword string, updatedPassword string) error {
+	key, err := app.Retrieve(name, originalPassword)
+	if err != nil {
+		return err
+	}
+
+	fileName, err := app.fileName(name)
+	if err != nil {
+		return err
+	}
+
+	content, err := app.encrypt(key, updatedPassword)
+	if err != nil {
+		return err
+	}
+
+	return app.service.Update(fileName, content)
+}
+
+// Delete deletes a key, the password must decrypt it
+func (app *application) Delete(name string, password string) error {
+	_, err := app.Retrieve(name, password)
+	if err != nil {
+		return err
+	}
+
+	fileName, err := app.fileName(name)
+	if err != nil {
+		return err
+	}
+
+	app.Lock(name)
+	return app.service.Delete(fileName)
+}
+
+// Unlock decrypts a key and keeps it in memory until it is locked or the timeout is elapsed
+func (app *application) Unlock(name string, password string) error {
+	key, err := app.Retrieve(name, password)
+	if err != nil {
+		return err
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	app.lock(name)
+	entry := &unlocked{
+		key: key,
+	}
+
+	if app.timeout > 0 {
+		entry.timer = time.AfterFunc(app.timeout, func() {
+			app.mutex.Lock()
+			defer app.mutex.Unlock()
+
+			// the key could have been unlocked again in the meantime:
+			if current, ok := app.unlocked[name]; ok && current == entry {
+				delete(app.unlocked, name)
+			}
+		})
+	}
+
+	app.unlocked[name] = entry
+	return nil
+}
+
+// Lock removes an unlocked key from memory
+func (app *application) Lock(name string) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.lock(name)
+}
+
+// IsUnlocked returns true if the key is unlocked, false otherwise
+func (app *application) IsUnlocked(name string) bool {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	_, ok := app.unlocked[name]
+	return ok
+}
+
+// Unlocked returns an unlocked key
+func (app *application) Unlocked(name string) (Key, error) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if entry, ok := app.unlocked[name]; ok {
+		return entry.key, nil
+	}
+
+	str := fmt.Sprintf("the key (name: %s) is locked", name)
+	return nil, errors.New(str)
+}
+
+func (app *application) lock(name string) {
+	if entry, ok := app.unlocked[name]; ok {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+
+		delete(app.unlocked, name)
+	}
+}
+
+func (app *application) fileName(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("the name of the key is mandatory")
+	}
+
+	hsh, err := app.hashAdapter.Hash([]byte(name))
+	if err != nil {
+		return "", err
+	}
+
+	return hsh.String(), nil
+}
+
+func (app *application) retrieveContent(fileName string) ([]byte, error) {
+	ins, err := app.repository.Retrieve(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if content, ok := ins.([]byte); ok {
+		return content, nil
+	}
+
+	str := fmt.Sprintf("the key file (%s) was expected to contain []byte", fileName)
+	return nil, errors.New(str)
+}
+
+func (app *application) retrieveFile(fileName string) (*keyFile, error) {
+	content, err := app.retrieveContent(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromBytesToKeyFile(content)
+}
+
+func (app *application) encrypt(key Key, password string) ([]byte, error) {
+	encoded := ""
+	if key.IsSignature() {
+		encoded = key.Signature().String()
+	}
+
+	if key.IsEncryption() {
+		encoded = app.encryptionAdapter.ToEncoded(key.Encryption())
+	}
+
+	if key.IsECIES() {
+		encoded = app.eciesAdapter.ToEncoded(key.ECIES())
+	}
+
+	secret, err := json.Marshal(keySecret{
+		Name: key.Name(),
+		Kind: key.Kind(),
+		Key:  encoded,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	cipher, err := encryption.NewEncryption(password).Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(keyFile{
+		Version: versionOne,
+		Name:    key.Name(),
+		Kind:    key.Kind(),
+		Cipher:  cipher,
+	})
+}
+
+func (app *application) decrypt(file *keyFile, password string) (Key, error) {
+	decrypted, err := encryption.NewEncryption(password).Decrypt(file.Cipher)
+	if err != nil {
+		str := fmt.Sprintf("the key (name: %s) could not be decrypted: the password is invalid", file.Name)
+		return nil, errors.New(str)
+	}
+
+	secret := new(keySecret)
+	err = json.Unmarshal(decrypted, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// the header is not encrypted, so it must match the encrypted secret:
+	if secret.Name != file.Name || secret.Kind != file.Kind {
+		str := fmt.Sprintf("the key file (name: %s) has been tampered with", file.Name)
+		return nil, errors.New(str)
+	}
+
+	builder := app.keyBuilder.Create().WithName(secret.Name)
+	switch secret.Kind {
+	case KindSignature:
+		sig, err := app.signatureAdapter.ToPrivateKey(secret.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.WithSignature(sig)
+	case KindEncryption:
+		enc, err := app.encryptionAdapter.FromEncoded(secret.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.WithEncryption(enc)
+	case KindECIES:
+		pk, err := app.eciesAdapter.FromEncoded(secret.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.WithECIES(pk)
+	}
+
+	return builder.Now()
+}
@@ -0,0 +1,239 @@
+package keystores
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+)
+
+func TestApplication_Success(t *testing.T) {
+	basePath, err := ioutil.TempDir("", "keystores")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer os.RemoveAll(basePath)
+
+	application, err := NewDiskApplication(basePath, 0777, 0)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	sigPK := signature.NewPrivateKeyFactory().Create()
+	sigKey, err := NewKeyBuilder().Create().WithName("signature").WithSignature(sigPK).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	encPK, err := ecies.NewFactory().Create()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	encKey, err := NewKeyBuilder().Create().WithName("encryption").WithECIES(encPK).Now()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = application.Insert(sigKey, "first password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = application.Insert(encKey, "second password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// insert twice:
+	err = application.Insert(sigKey, "first password")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	names, err := application.List()
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(names, []string{"encryption", "signature"}) {
+		t.Errorf("the listed names are invalid: %v", names)
+		return
+	}
+
+	retSigKey, err := application.Retrieve("signature", "first password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retSigKey.IsSignature() || retSigKey.Signature().String() != sigPK.String() {
+		t.Errorf("the retrieved signature key is invalid")
+		return
+	}
+
+	_, err = application.Retrieve("signature", "invalid password")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	err = application.ChangePassword("encryption", "second password", "updated password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = application.Retrieve("encryption", "second password")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	retEncKey, err := application.Retrieve("encryption", "updated password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retEncKey.IsECIES() || !retEncKey.ECIES().Key().Equal(encPK.Key()) {
+		t.Errorf("the retrieved ecies key is invalid")
+		return
+	}
+
+	// export, then import in another keystore:
+	exported, err := application.Export("encryption")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	otherPath, err := ioutil.TempDir("", "keystores")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer os.RemoveAll(otherPath)
+
+	other, err := NewDiskApplication(otherPath, 0777, 0)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	name, err := other.Import(exported)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if name != "encryption" {
+		t.Errorf("the imported name was expected to be %s, %s returned", "encryption", name)
+		return
+	}
+
+	_, err = other.Retrieve("encryption", "updated password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = application.Delete("signature", "invalid password")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	err = application.Delete("signature", "first password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	names, _ = application.List()
+	if !reflect.DeepEqual(names, []string{"encryption"}) {
+		t.Errorf("the listed names are invalid: %v", names)
+		return
+	}
+}
+
+func TestApplication_lockUnlock_Success(t *testing.T) {
+	basePath, err := ioutil.TempDir("", "keystores")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer os.RemoveAll(basePath)
+
+	application, err := NewDiskApplication(basePath, 0777, 50*time.Millisecond)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	key, _ := NewKeyBuilder().Create().WithName("signature").WithSignature(signature.NewPrivateKeyFactory().Create()).Now()
+	err = application.Insert(key, "password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = application.Unlock("signature", "invalid password")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	err = application.Unlock("signature", "password")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	unlocked, err := application.Unlocked("signature")
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if unlocked.Name() != "signature" {
+		t.Errorf("the unlocked key is invalid")
+		return
+	}
+
+	application.Lock("signature")
+	if application.IsUnlocked("signature") {
+		t.Errorf("the key was expected to be locked")
+		return
+	}
+
+	// the timeout locks the key again:
+	application.Unlock("signature", "password")
+	time.Sleep(200 * time.Millisecond)
+	if application.IsUnlocked("signature") {
+		t.Errorf("the key was expected to be locked once the timeout is elapsed")
+		return
+	}
+
+	_, err = application.Unlocked("signature")
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
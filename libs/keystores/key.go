@@ -0,0 +1,99 @@
+package keystores
+
+import (
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+)
+
+type key struct {
+	name  string
+	sig   signature.PrivateKey
+	enc   encryption.PrivateKey
+	ecies ecies.PrivateKey
+}
+
+func createKeyWithSignature(
+	name string,
+	sig signature.PrivateKey,
+) Key {
+	return createKeyInternally(name, sig, nil, nil)
+}
+
+func createKeyWithEncryption(
+	name string,
+	enc encryption.PrivateKey,
+) Key {
+	return createKeyInternally(name, nil, enc, nil)
+}
+
+func createKeyWithECIES(
+	name string,
+	ecies ecies.PrivateKey,
+) Key {
+	return createKeyInternally(name, nil, nil, ecies)
+}
+
+func createKeyInternally(
+	name string,
+	sig signature.PrivateKey,
+	enc encryption.PrivateKey,
+	ecies ecies.PrivateKey,
+) Key {
+	out := key{
+		name:  name,
+		sig:   sig,
+		enc:   enc,
+		ecies: ecies,
+	}
+
+	return &out
+}
+
+// Name returns the name
+func (obj *key) Name() string {
+	return obj.name
+}
+
+// Kind returns the kind
+func (obj *key) Kind() Kind {
+	if obj.IsSignature() {
+		return KindSignature
+	}
+
+	if obj.IsEncryption() {
+		return KindEncryption
+	}
+
+	return KindECIES
+}
+
+// IsSignature returns true if there is a signature private key, false otherwise
+func (obj *key) IsSignature() bool {
+	return obj.sig != nil
+}
+
+// Signature returns the signature private key, if any
+func (obj *key) Signature() signature.PrivateKey {
+	return obj.sig
+}
+
+// IsEncryption returns true if there is an rsa encryption private key, false otherwise
+func (obj *key) IsEncryption() bool {
+	return obj.enc != nil
+}
+
+// Encryption returns the rsa encryption private key, if any
+func (obj *key) Encryption() encryption.PrivateKey {
+	return obj.enc
+}
+
+// IsECIES returns true if there is an ecies encryption private key, false otherwise
+func (obj *key) IsECIES() bool {
+	return obj.ecies != nil
+}
+
+// ECIES returns the ecies encryption private key, if any
+func (obj *key) ECIES() ecies.PrivateKey {
+	return obj.ecies
+}
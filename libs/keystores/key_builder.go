@@ -0,0 +1,77 @@
+package keystores
+
+import (
+	"errors"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+)
+
+type keyBuilder struct {
+	name  string
+	sig   signature.PrivateKey
+	enc   encryption.PrivateKey
+	ecies ecies.PrivateKey
+}
+
+func createKeyBuilder() KeyBuilder {
+	out := keyBuilder{
+		name:  "",
+		sig:   nil,
+		enc:   nil,
+		ecies: nil,
+	}
+
+	return &out
+}
+
+// Create initializes the builder
+func (app *keyBuilder) Create() KeyBuilder {
+	return createKeyBuilder()
+}
+
+// WithName adds a name to the builder
+func (app *keyBuilder) WithName(name string) KeyBuilder {
+	app.name = name
+	return app
+}
+
+// WithSignature adds a signature private key to the builder
+func (app *keyBuilder) WithSignature(sig signature.PrivateKey) KeyBuilder {
+	app.sig = sig
+	return app
+}
+
+// WithEncryption adds an rsa encryption private key to the builder
+func (app *keyBuilder) WithEncryption(enc encryption.PrivateKey) KeyBuilder {
+	app.enc = enc
+	return app
+}
+
+// WithECIES adds an ecies encryption private key to the builder
+func (app *keyBuilder) WithECIES(ecies ecies.PrivateKey) KeyBuilder {
+	app.ecies = ecies
+	return app
+}
+
+// Now builds a new Key instance
+func (app *keyBuilder) Now() (Key, error) {
+	if app.name == "" {
+		return nil, errors.New("the name is mandatory in order to build a Key instance")
+	}
+
+	if app.sig != nil {
+		return createKeyWithSignature(app.name, app.sig), nil
+	}
+
+	if app.enc != nil {
+		return createKeyWithEncryption(app.name, app.enc), nil
+	}
+
+	if app.ecies != nil {
+		return createKeyWithECIES(app.name, app.ecies), nil
+	}
+
+	return nil, errors.New("the Key is invalid")
+}
@@ -0,0 +1,51 @@
+package keystores
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// keyFile represents the content of a key file, only the cipher is encrypted
+type keyFile struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+	Kind    Kind   `json:"kind"`
+	Cipher  string `json:"cipher"`
+}
+
+// keySecret represents the encrypted part of a key file
+type keySecret struct {
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+	Key  string `json:"key"`
+}
+
+func fromBytesToKeyFile(content []byte) (*keyFile, error) {
+	ptr := new(keyFile)
+	err := json.Unmarshal(content, ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	if ptr.Version != versionOne {
+		str := fmt.Sprintf("the key file version (%d) is not supported", ptr.Version)
+		return nil, errors.New(str)
+	}
+
+	if ptr.Name == "" {
+		return nil, errors.New("the name is mandatory in a key file")
+	}
+
+	if ptr.Kind < KindSignature || ptr.Kind > KindECIES {
+		str := fmt.Sprintf("the kind (%d) of the key file (name: %s) is invalid", ptr.Kind, ptr.Name)
+		return nil, errors.New(str)
+	}
+
+	if ptr.Cipher == "" {
+		str := fmt.Sprintf("the cipher is mandatory in the key file (name: %s)", ptr.Name)
+		return nil, errors.New(str)
+	}
+
+	return ptr, nil
+}
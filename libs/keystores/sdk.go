@@ -0,0 +1,114 @@
+package keystores
+
+import (
+	"os"
+	"time"
+
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/encryption/ecies"
+	"github.com/deepvalue-network/software/libs/cryptography/pk/signature"
+	"github.com/deepvalue-network/software/libs/files/domain/files"
+	"github.com/deepvalue-network/software/libs/files/infrastructure/disks"
+	"github.com/deepvalue-network/software/libs/hash"
+	"github.com/deepvalue-network/software/libs/hydro"
+)
+
+// Kind represents the kind of a stored private key
+type Kind uint8
+
+const (
+	// KindSignature represents a signature private key
+	KindSignature Kind = iota + 1
+
+	// KindEncryption represents an rsa encryption private key
+	KindEncryption
+
+	// KindECIES represents an ecies encryption private key
+	KindECIES
+)
+
+// versionOne represents the first version of the key file format
+const versionOne = 1
+
+// NewKeyBuilder creates a new key builder
+func NewKeyBuilder() KeyBuilder {
+	return createKeyBuilder()
+}
+
+// NewApplication creates a new keystore application, on top of a files repository and service.
+// An unlocked key is locked again once the timeout is elapsed, a zero timeout keeps it unlocked until locked
+func NewApplication(
+	repository files.Repository,
+	service files.Service,
+	timeout time.Duration,
+) Application {
+	hashAdapter := hash.NewAdapter()
+	keyBuilder := NewKeyBuilder()
+	signatureAdapter := signature.NewPrivateKeyAdapter()
+	encryptionAdapter := encryption.NewAdapter()
+	eciesAdapter := ecies.NewAdapter()
+	return createApplication(
+		hashAdapter,
+		keyBuilder,
+		signatureAdapter,
+		encryptionAdapter,
+		eciesAdapter,
+		repository,
+		service,
+		timeout,
+	)
+}
+
+// NewDiskApplication creates a new keystore application that stores its key files on disk
+func NewDiskApplication(
+	basePath string,
+	fileMode os.FileMode,
+	timeout time.Duration,
+) (Application, error) {
+	manager := hydro.NewManagerFactory().Create()
+	hydroAdapter, err := hydro.NewAdapterBuilder().Create().WithManager(manager).Now()
+	if err != nil {
+		return nil, err
+	}
+
+	service := disks.NewService(hydroAdapter, basePath, fileMode)
+	repository := disks.NewRepository(hydroAdapter, basePath, nil)
+	return NewApplication(repository, service, timeout), nil
+}
+
+// Application represents the keystore application
+type Application interface {
+	List() ([]string, error)
+	Insert(key Key, password string) error
+	Retrieve(name string, password string) (Key, error)
+	Import(content []byte) (string, error)
+	Export(name string) ([]byte, error)
+	ChangePassword(name string, originalPassword string, updatedPassword string) error
+	Delete(name string, password string) error
+	Unlock(name string, password string) error
+	Lock(name string)
+	IsUnlocked(name string) bool
+	Unlocked(name string) (Key, error)
+}
+
+// KeyBuilder represents a key builder
+type KeyBuilder interface {
+	Create() KeyBuilder
+	WithName(name string) KeyBuilder
+	WithSignature(sig signature.PrivateKey) KeyBuilder
+	WithEncryption(enc encryption.PrivateKey) KeyBuilder
+	WithECIES(ecies ecies.PrivateKey) KeyBuilder
+	Now() (Key, error)
+}
+
+// Key represents a named private key
+type Key interface {
+	Name() string
+	Kind() Kind
+	IsSignature() bool
+	Signature() signature.PrivateKey
+	IsEncryption() bool
+	Encryption() encryption.PrivateKey
+	IsECIES() bool
+	ECIES() ecies.PrivateKey
+}
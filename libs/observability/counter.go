@@ -0,0 +1,39 @@
+package observability
+
+import (
+	"sync/atomic"
+)
+
+type counter struct {
+	name  string
+	help  string
+	value uint64
+}
+
+func createCounter(
+	name string,
+	help string,
+) *counter {
+	out := counter{
+		name:  name,
+		help:  help,
+		value: 0,
+	}
+
+	return &out
+}
+
+// Inc increments the counter
+func (obj *counter) Inc() {
+	obj.Add(1)
+}
+
+// Add adds a delta to the counter
+func (obj *counter) Add(delta uint64) {
+	atomic.AddUint64(&obj.value, delta)
+}
+
+// Value returns the value of the counter
+func (obj *counter) Value() uint64 {
+	return atomic.LoadUint64(&obj.value)
+}
@@ -0,0 +1,71 @@
+package observability
+
+import (
+	"sort"
+	"sync"
+)
+
+type histogram struct {
+	name    string
+	help    string
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+	mutex   sync.Mutex
+}
+
+func createHistogram(
+	name string,
+	help string,
+	buckets []float64,
+) *histogram {
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+	out := histogram{
+		name:    name,
+		help:    help,
+		buckets: sorted,
+		counts:  make([]uint64, len(sorted)),
+		count:   0,
+		sum:     0,
+	}
+
+	return &out
+}
+
+// Observe adds a value to the histogram
+func (obj *histogram) Observe(value float64) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	for index, oneBucket := range obj.buckets {
+		if value <= oneBucket {
+			obj.counts[index]++
+		}
+	}
+
+	obj.count++
+	obj.sum += value
+}
+
+// Count returns the amount of observed values
+func (obj *histogram) Count() uint64 {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	return obj.count
+}
+
+// Sum returns the sum of the observed values
+func (obj *histogram) Sum() float64 {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	return obj.sum
+}
+
+// snapshot returns the cumulative bucket counts, the count and the sum, all read together
+func (obj *histogram) snapshot() ([]uint64, uint64, float64) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	return append([]uint64{}, obj.counts...), obj.count, obj.sum
+}
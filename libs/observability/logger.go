@@ -0,0 +1,120 @@
+package observability
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type logger struct {
+	writer io.Writer
+	level  Level
+	fields []interface{}
+	mutex  *sync.Mutex
+}
+
+func createLogger(
+	writer io.Writer,
+	level Level,
+) Logger {
+	return createLoggerInternally(writer, level, []interface{}{}, new(sync.Mutex))
+}
+
+func createLoggerInternally(
+	writer io.Writer,
+	level Level,
+	fields []interface{},
+	mutex *sync.Mutex,
+) Logger {
+	out := logger{
+		writer: writer,
+		level:  level,
+		fields: fields,
+		mutex:  mutex,
+	}
+
+	return &out
+}
+
+// Debug logs a debug entry
+func (app *logger) Debug(msg string, keyValues ...interface{}) {
+	app.log(LevelDebug, msg, keyValues)
+}
+
+// Info logs an info entry
+func (app *logger) Info(msg string, keyValues ...interface{}) {
+	app.log(LevelInfo, msg, keyValues)
+}
+
+// Error logs an error entry
+func (app *logger) Error(msg string, keyValues ...interface{}) {
+	app.log(LevelError, msg, keyValues)
+}
+
+// With returns a logger that adds the given fields to every entry
+func (app *logger) With(keyValues ...interface{}) Logger {
+	fields := append(append([]interface{}{}, app.fields...), normalize(keyValues)...)
+	return createLoggerInternally(app.writer, app.level, fields, app.mutex)
+}
+
+func (app *logger) log(level Level, msg string, keyValues []interface{}) {
+	if level < app.level {
+		return
+	}
+
+	line := new(bytes.Buffer)
+	fmt.Fprintf(line, "time=%s level=%s msg=%s", time.Now().UTC().Format(time.RFC3339Nano), level.String(), quote(msg))
+	fields := append(append([]interface{}{}, app.fields...), normalize(keyValues)...)
+	for i := 0; i < len(fields); i += 2 {
+		fmt.Fprintf(line, " %s=%s", quote(fmt.Sprint(fields[i])), quote(format(fields[i+1])))
+	}
+
+	line.WriteString("\n")
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.writer.Write(line.Bytes())
+}
+
+// String returns the name of the level
+func (obj Level) String() string {
+	switch obj {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelError:
+		return "error"
+	}
+
+	return strconv.Itoa(int(obj))
+}
+
+// normalize pairs an odd last key with a missing value
+func normalize(keyValues []interface{}) []interface{} {
+	if len(keyValues)%2 == 0 {
+		return keyValues
+	}
+
+	return append(append([]interface{}{}, keyValues...), missingValue)
+}
+
+func format(value interface{}) string {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+
+	return fmt.Sprint(value)
+}
+
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " =\"\t\r\n") {
+		return value
+	}
+
+	return strconv.Quote(value)
+}
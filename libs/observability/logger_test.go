@@ -0,0 +1,27 @@
+package observability
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLogger_Success(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	logger := NewLogger(buffer, LevelInfo).With("component", "sync")
+	logger.Debug("skipped")
+	logger.Error("the sync failed", "chain", "abc", "error", errors.New("peer unreachable"), "odd")
+
+	line := buffer.String()
+	if strings.Count(line, "\n") != 1 {
+		t.Errorf("one line was expected to be logged, returned: %s", line)
+		return
+	}
+
+	expected := `level=error msg="the sync failed" component=sync chain=abc error="peer unreachable" odd=(missing)` + "\n"
+	if !strings.HasSuffix(line, expected) {
+		t.Errorf("the line was expected to end with: %s, returned: %s", expected, line)
+		return
+	}
+}
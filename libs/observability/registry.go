@@ -0,0 +1,127 @@
+package observability
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var metricNameRegex = regexp.MustCompile("^[a-zA-Z_:][a-zA-Z0-9_:]*$")
+
+var helpReplacer = strings.NewReplacer("\\", "\\\\", "\n", "\\n")
+
+type registry struct {
+	counters   map[string]*counter
+	histograms map[string]*histogram
+	mutex      sync.Mutex
+}
+
+func createRegistry() Registry {
+	out := registry{
+		counters:   map[string]*counter{},
+		histograms: map[string]*histogram{},
+	}
+
+	return &out
+}
+
+// Counter returns the counter registered by name, registering it the first time
+func (app *registry) Counter(name string, help string) Counter {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if ins, ok := app.counters[name]; ok {
+		return ins
+	}
+
+	app.validate(name, "histogram", app.histograms[name] != nil)
+	ins := createCounter(name, help)
+	app.counters[name] = ins
+	return ins
+}
+
+// Histogram returns the histogram registered by name, registering it with the given buckets the first time
+func (app *registry) Histogram(name string, help string, buckets []float64) Histogram {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if ins, ok := app.histograms[name]; ok {
+		return ins
+	}
+
+	app.validate(name, "counter", app.counters[name] != nil)
+	ins := createHistogram(name, help, buckets)
+	app.histograms[name] = ins
+	return ins
+}
+
+// Export writes the metrics in the Prometheus text format, sorted by name
+func (app *registry) Export(writer io.Writer) error {
+	app.mutex.Lock()
+	names := []string{}
+	counters := map[string]*counter{}
+	histograms := map[string]*histogram{}
+	for name, ins := range app.counters {
+		names = append(names, name)
+		counters[name] = ins
+	}
+
+	for name, ins := range app.histograms {
+		names = append(names, name)
+		histograms[name] = ins
+	}
+
+	app.mutex.Unlock()
+
+	sort.Strings(names)
+	buffer := bufio.NewWriter(writer)
+	for _, oneName := range names {
+		if ins, ok := counters[oneName]; ok {
+			writeHeader(buffer, oneName, ins.help, "counter")
+			fmt.Fprintf(buffer, "%s %d\n", oneName, ins.Value())
+			continue
+		}
+
+		ins := histograms[oneName]
+		counts, count, sum := ins.snapshot()
+		writeHeader(buffer, oneName, ins.help, "histogram")
+		for index, oneBucket := range ins.buckets {
+			fmt.Fprintf(buffer, "%s_bucket{le=\"%s\"} %d\n", oneName, formatFloat(oneBucket), counts[index])
+		}
+
+		fmt.Fprintf(buffer, "%s_bucket{le=\"+Inf\"} %d\n", oneName, count)
+		fmt.Fprintf(buffer, "%s_sum %s\n", oneName, formatFloat(sum))
+		fmt.Fprintf(buffer, "%s_count %d\n", oneName, count)
+	}
+
+	return buffer.Flush()
+}
+
+func (app *registry) validate(name string, otherKind string, isOtherKind bool) {
+	if !metricNameRegex.MatchString(name) {
+		str := fmt.Sprintf(invalidMetricNamePattern, name)
+		panic(str)
+	}
+
+	if isOtherKind {
+		str := fmt.Sprintf(metricKindConflictPattern, name, otherKind)
+		panic(str)
+	}
+}
+
+func writeHeader(writer io.Writer, name string, help string, kind string) {
+	if help != "" {
+		fmt.Fprintf(writer, "# HELP %s %s\n", name, helpReplacer.Replace(help))
+	}
+
+	fmt.Fprintf(writer, "# TYPE %s %s\n", name, kind)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
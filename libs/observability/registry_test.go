@@ -0,0 +1,62 @@
+package observability
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_Success(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.Counter("test_total", "the amount of tests")
+	counter.Inc()
+	counter.Add(2)
+
+	if registry.Counter("test_total", "") != counter {
+		t.Errorf("the same counter was expected to be returned")
+		return
+	}
+
+	histogram := registry.Histogram("test_seconds", "the duration of tests", []float64{1, 0.5})
+	histogram.Observe(0.25)
+	histogram.Observe(0.75)
+	histogram.Observe(2)
+
+	buffer := new(bytes.Buffer)
+	err := registry.Export(buffer)
+	if err != nil {
+		t.Errorf("the returned error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	expected := strings.Join([]string{
+		"# HELP test_seconds the duration of tests",
+		"# TYPE test_seconds histogram",
+		`test_seconds_bucket{le="0.5"} 1`,
+		`test_seconds_bucket{le="1"} 2`,
+		`test_seconds_bucket{le="+Inf"} 3`,
+		"test_seconds_sum 3",
+		"test_seconds_count 3",
+		"# HELP test_total the amount of tests",
+		"# TYPE test_total counter",
+		"test_total 3",
+		"",
+	}, "\n")
+
+	if buffer.String() != expected {
+		t.Errorf("the exported metrics were expected to be:\n%s\n, returned:\n%s", expected, buffer.String())
+		return
+	}
+}
+
+func TestRegistry_withKindConflict_panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("the registration was expected to panic")
+		}
+	}()
+
+	registry := NewRegistry()
+	registry.Counter("test_total", "")
+	registry.Histogram("test_total", "", DefaultBuckets)
+}
@@ -0,0 +1,97 @@
+package observability
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	// LevelDebug represents the debug log level
+	LevelDebug Level = iota + 1
+
+	// LevelInfo represents the info log level
+	LevelInfo
+
+	// LevelError represents the error log level
+	LevelError
+)
+
+// Level represents a log level
+type Level uint8
+
+// ContentType represents the content type of the exported metrics
+const ContentType = "text/plain; version=0.0.4"
+
+// DefaultBuckets represents the default upper bounds of the histogram buckets, in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+const missingValue = "(missing)"
+
+const invalidMetricNamePattern = "the metric name (%s) is invalid"
+
+const metricKindConflictPattern = "the metric (%s) is already registered as a %s"
+
+// the process wide registry and logger:
+var defaultRegistry = NewRegistry()
+var defaultLogger = NewLogger(os.Stderr, LevelInfo)
+var defaultMutex sync.RWMutex
+
+// NewRegistry creates a new registry instance
+func NewRegistry() Registry {
+	return createRegistry()
+}
+
+// NewLogger creates a new logger instance, that writes one logfmt line per entry of at least the given level
+func NewLogger(writer io.Writer, level Level) Logger {
+	return createLogger(writer, level)
+}
+
+// DefaultRegistry returns the process wide registry
+func DefaultRegistry() Registry {
+	return defaultRegistry
+}
+
+// DefaultLogger returns the process wide logger, writing on stderr by default
+func DefaultLogger() Logger {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+	return defaultLogger
+}
+
+// SetDefaultLogger replaces the process wide logger
+func SetDefaultLogger(logger Logger) {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	defaultLogger = logger
+}
+
+// Registry represents a set of metrics.  Requesting a metric twice returns the same instance; an invalid name, or a
+// name registered by another kind of metric, panics
+type Registry interface {
+	Counter(name string, help string) Counter
+	Histogram(name string, help string, buckets []float64) Histogram
+	Export(writer io.Writer) error
+}
+
+// Counter represents a monotonic counter
+type Counter interface {
+	Inc()
+	Add(delta uint64)
+	Value() uint64
+}
+
+// Histogram represents the distribution of observed values
+type Histogram interface {
+	Observe(value float64)
+	Count() uint64
+	Sum() float64
+}
+
+// Logger represents a structured logger; the fields are given as key-value pairs
+type Logger interface {
+	Debug(msg string, keyValues ...interface{})
+	Info(msg string, keyValues ...interface{})
+	Error(msg string, keyValues ...interface{})
+	With(keyValues ...interface{}) Logger
+}
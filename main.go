@@ -1,5 +1,228 @@
+// Command software runs a blockchain node, and manages the chains of its data directory.
+//
+// The node reads a JSON config file:
+//
+//	{
+//		"data_dir": "./data",
+//		"port": 8080,
+//		"peers": ["https://127.0.0.1:8081"],
+//		"sync_interval": "1m",
+//		"mining": true,
+//		"orphan_depth": 16
+//	}
+//
+// Usage:
+//
+//	software -config node.json run
+//	software -config node.json chain create -hashes <hash>,<hash> [-mining-value 2 -block-difficulty 1 -block-increase 0.0001 -link-difficulty 1 -target-link-interval 1m -retarget-window 0]
+//	software -config node.json chain list
+//	software -config node.json chain inspect -id <uuid>
 package main
 
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/deepvalue-network/software/blockchain/infrastructure/nodes"
+	"github.com/deepvalue-network/software/blockchain/infrastructure/restapis/clients"
+	"github.com/deepvalue-network/software/libs/hash"
+	uuid "github.com/satori/go.uuid"
+)
+
+const usage = `usage: software [-config <path>] <command>
+
+commands:
+	run                  serves, syncs and mines the chains, until interrupted
+	chain create         creates a new chain
+	chain list           lists the chain ids
+	chain inspect -id    prints a chain`
+
 func main() {
+	configPath := flag.String("config", "", "the path of the JSON config file; the default config is used if none is given")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+	args := flag.Args()
+	if len(args) <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("the config could not be loaded: %s", err.Error())
+	}
+
+	switch args[0] {
+	case "run":
+		err = run(config)
+	case "chain":
+		err = chain(config, args[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadConfig(path string) (nodes.Config, error) {
+	if path == "" {
+		return nodes.NewConfig([]byte("{}"))
+	}
+
+	return nodes.NewConfigFromFile(path)
+}
+
+func run(config nodes.Config) error {
+	node, err := nodes.NewNode(config, clients.NewRemoteBuilder(), clients.NewPublisher())
+	if err != nil {
+		return err
+	}
+
+	err = node.Start()
+	if err != nil {
+		return err
+	}
+
+	// wait for a signal, then shutdown cleanly:
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+
+	return node.Stop()
+}
+
+func chain(config nodes.Config, args []string) error {
+	if len(args) <= 0 {
+		return fmt.Errorf("the chain command expects a sub command: create, list or inspect")
+	}
+
+	node, err := nodes.NewNode(config, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "create":
+		return chainCreate(node, args[1:])
+	case "list":
+		return chainList(node)
+	case "inspect":
+		return chainInspect(node, args[1:])
+	}
+
+	return fmt.Errorf("the chain sub command (%s) is invalid", args[0])
+}
+
+func chainCreate(node nodes.Node, args []string) error {
+	flags := flag.NewFlagSet("chain create", flag.ExitOnError)
+	id := flags.String("id", "", "the id of the chain; a new one is generated if none is given")
+	miningValue := flags.Uint("mining-value", 2, "the value the mined hashes must be prefixed with")
+	blockDifficulty := flags.Uint("block-difficulty", 1, "the base difficulty of the blocks")
+	blockIncrease := flags.Float64("block-increase", 0.0001, "the block difficulty added per hash")
+	linkDifficulty := flags.Uint("link-difficulty", 1, "the initial difficulty of the links")
+	targetLinkInterval := flags.Duration("target-link-interval", time.Minute, "the interval the link difficulty is retargeted to reach between two links")
+	retargetWindow := flags.Uint("retarget-window", 0, "the amount of links between two link difficulty retargets; zero never retargets")
+	hashesList := flags.String("hashes", "", "the comma separated hashes of the root block")
+	flags.Parse(args)
+
+	if *hashesList == "" {
+		return fmt.Errorf("the root block of a chain needs at least one hash")
+	}
+
+	hashAdapter := hash.NewAdapter()
+	hashes := []hash.Hash{}
+	for _, oneHashStr := range strings.Split(*hashesList, ",") {
+		hsh, err := hashAdapter.FromString(strings.TrimSpace(oneHashStr))
+		if err != nil {
+			return err
+		}
+
+		hashes = append(hashes, *hsh)
+	}
+
+	var chainID *uuid.UUID
+	if *id != "" {
+		parsed, err := uuid.FromString(*id)
+		if err != nil {
+			return err
+		}
+
+		chainID = &parsed
+	}
+
+	created, err := node.Application().Chain().Create(
+		chainID,
+		uint8(*miningValue),
+		*blockDifficulty,
+		*blockIncrease,
+		*linkDifficulty,
+		*targetLinkInterval,
+		*retargetWindow,
+		hashes,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(created.ID().String())
+	return nil
+}
+
+func chainList(node nodes.Node) error {
+	ids, err := node.Repository().Chain().List()
+	if err != nil {
+		return err
+	}
+
+	for _, oneID := range ids {
+		fmt.Println(oneID.String())
+	}
+
+	return nil
+}
+
+func chainInspect(node nodes.Node, args []string) error {
+	flags := flag.NewFlagSet("chain inspect", flag.ExitOnError)
+	id := flags.String("id", "", "the id of the chain")
+	flags.Parse(args)
+
+	chainID, err := uuid.FromString(*id)
+	if err != nil {
+		return fmt.Errorf("the chain id (%s) is invalid: %s", *id, err.Error())
+	}
+
+	chain, err := node.Repository().Chain().Retrieve(&chainID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("id:           %s\n", chain.ID().String())
+	fmt.Printf("genesis:      %s\n", chain.Genesis().Hash().String())
+	fmt.Printf("root:         %s\n", chain.Root().Hash().String())
+	fmt.Printf("height:       %d\n", chain.Height())
+	fmt.Printf("total hashes: %d\n", chain.TotalHashes())
+	fmt.Printf("created on:   %s\n", chain.CreatedOn().String())
+	if chain.HasHead() {
+		fmt.Printf("head:         %s\n", chain.Head().Hash().String())
+	}
+
+	for _, onePeer := range chain.Peers().All() {
+		fmt.Printf("peer:         %s\n", onePeer.Content().String())
+	}
 
+	return nil
 }
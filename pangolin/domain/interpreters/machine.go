@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/deepvalue-network/software/libs/observability"
 	"github.com/deepvalue-network/software/pangolin/domain/lexers"
 	lexer_parser "github.com/deepvalue-network/software/pangolin/domain/lexers/parser"
 	"github.com/deepvalue-network/software/pangolin/domain/middle"
@@ -23,6 +24,8 @@ type machine struct {
 	lbls                   map[string]label_instructions.Instructions
 	patternMatches         map[string]middle.PatternMatch
 	lexerAdapterBuilder    lexers.AdapterBuilder
+	steps                  observability.Counter
+	failedSteps            observability.Counter
 }
 
 func createMachine(
@@ -31,8 +34,10 @@ func createMachine(
 	lexerParserBuilder lexer_parser.Builder,
 	stkFrame StackFrame,
 	lbls map[string]label_instructions.Instructions,
+	steps observability.Counter,
+	failedSteps observability.Counter,
 ) Machine {
-	return createMachineInternally(computableValueBuilder, lexerParserApplication, lexerParserBuilder, stkFrame, lbls, nil, nil)
+	return createMachineInternally(computableValueBuilder, lexerParserApplication, lexerParserBuilder, stkFrame, lbls, nil, nil, steps, failedSteps)
 }
 
 func createMachineWithPatternMatches(
@@ -43,8 +48,10 @@ func createMachineWithPatternMatches(
 	lbls map[string]label_instructions.Instructions,
 	patternMatches map[string]middle.PatternMatch,
 	lexerAdapterBuilder lexers.AdapterBuilder,
+	steps observability.Counter,
+	failedSteps observability.Counter,
 ) Machine {
-	return createMachineInternally(computableValueBuilder, lexerParserApplication, lexerParserBuilder, stkFrame, lbls, patternMatches, lexerAdapterBuilder)
+	return createMachineInternally(computableValueBuilder, lexerParserApplication, lexerParserBuilder, stkFrame, lbls, patternMatches, lexerAdapterBuilder, steps, failedSteps)
 }
 
 func createMachineInternally(
@@ -55,6 +62,8 @@ func createMachineInternally(
 	lbls map[string]label_instructions.Instructions,
 	patternMatches map[string]middle.PatternMatch,
 	lexerAdapterBuilder lexers.AdapterBuilder,
+	steps observability.Counter,
+	failedSteps observability.Counter,
 ) Machine {
 	out := machine{
 		computableValueBuilder: computableValueBuilder,
@@ -64,6 +73,8 @@ func createMachineInternally(
 		lbls:                   lbls,
 		patternMatches:         patternMatches,
 		lexerAdapterBuilder:    lexerAdapterBuilder,
+		steps:                  steps,
+		failedSteps:            failedSteps,
 	}
 
 	return &out
@@ -76,6 +87,16 @@ func (app *machine) StackFrame() StackFrame {
 
 // Receive receives an instruction
 func (app *machine) Receive(ins instruction.Instruction) error {
+	app.steps.Inc()
+	err := app.receive(ins)
+	if err != nil {
+		app.failedSteps.Inc()
+	}
+
+	return err
+}
+
+func (app *machine) receive(ins instruction.Instruction) error {
 	if ins.IsStackframe() {
 		stkFrame := ins.Stackframe()
 		if stkFrame.IsPush() {
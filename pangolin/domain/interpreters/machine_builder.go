@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/deepvalue-network/software/libs/observability"
 	"github.com/deepvalue-network/software/pangolin/domain/lexers"
 	"github.com/deepvalue-network/software/pangolin/domain/lexers/grammar"
 	lexer_parser "github.com/deepvalue-network/software/pangolin/domain/lexers/parser"
@@ -26,6 +27,8 @@ type machineBuilder struct {
 	grammarRetrieverCriteriaBuilder grammar.RetrieverCriteriaBuilder
 	stackFrameBuilder               StackFrameBuilder
 	events                          []lexers.Event
+	steps                           observability.Counter
+	failedSteps                     observability.Counter
 	globalConstants                 map[string]computable.Value
 	globalVariables                 map[string]computable.Value
 	lbls                            map[string]label_instructions.Instructions
@@ -42,6 +45,8 @@ func createMachineBuilder(
 	grammarRetrieverCriteriaBuilder grammar.RetrieverCriteriaBuilder,
 	stackFrameBuilder StackFrameBuilder,
 	events []lexers.Event,
+	steps observability.Counter,
+	failedSteps observability.Counter,
 ) MachineBuilder {
 	out := machineBuilder{
 		computableValueBuilder:          computableValueBuilder,
@@ -51,6 +56,8 @@ func createMachineBuilder(
 		grammarRetrieverCriteriaBuilder: grammarRetrieverCriteriaBuilder,
 		stackFrameBuilder:               stackFrameBuilder,
 		events:                          events,
+		steps:                           steps,
+		failedSteps:                     failedSteps,
 		globalConstants:                 map[string]computable.Value{},
 		globalVariables:                 map[string]computable.Value{},
 		lbls:                            map[string]label_instructions.Instructions{},
@@ -72,6 +79,8 @@ func (app *machineBuilder) Create() MachineBuilder {
 		app.grammarRetrieverCriteriaBuilder,
 		app.stackFrameBuilder,
 		app.events,
+		app.steps,
+		app.failedSteps,
 	)
 }
 
@@ -154,10 +163,10 @@ func (app *machineBuilder) Now() (Machine, error) {
 		}
 
 		lexerAdapterBuilder := app.lexerAdapterBuilder.Create().WithGrammarRetrieverCriteria(retrieverCriteria).WithEvents(app.events)
-		return createMachineWithPatternMatches(app.computableValueBuilder, app.lexerParserApplication, app.lexerParserBuilder, stackFrame, app.lbls, patternMatches, lexerAdapterBuilder), nil
+		return createMachineWithPatternMatches(app.computableValueBuilder, app.lexerParserApplication, app.lexerParserBuilder, stackFrame, app.lbls, patternMatches, lexerAdapterBuilder, app.steps, app.failedSteps), nil
 	}
 
-	return createMachine(app.computableValueBuilder, app.lexerParserApplication, app.lexerParserBuilder, stackFrame, app.lbls), nil
+	return createMachine(app.computableValueBuilder, app.lexerParserApplication, app.lexerParserBuilder, stackFrame, app.lbls, app.steps, app.failedSteps), nil
 }
 
 func (app *machineBuilder) variables(variables variables.Variables) error {
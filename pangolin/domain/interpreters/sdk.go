@@ -1,6 +1,7 @@
 package interpreters
 
 import (
+	"github.com/deepvalue-network/software/libs/observability"
 	"github.com/deepvalue-network/software/pangolin/domain/lexers"
 	"github.com/deepvalue-network/software/pangolin/domain/lexers/grammar"
 	lexer_parser "github.com/deepvalue-network/software/pangolin/domain/lexers/parser"
@@ -13,6 +14,10 @@ import (
 	"github.com/deepvalue-network/software/pangolin/domain/middle/variables/variable/value/computable"
 )
 
+const stepsMetric = "pangolin_interpreter_steps_total"
+
+const failedStepsMetric = "pangolin_interpreter_failed_steps_total"
+
 // NewBuilder creates a new interpreter builder instance
 func NewBuilder(machineBuilder MachineBuilder) Builder {
 	valueBuilder := computable.NewBuilder()
@@ -29,6 +34,9 @@ func NewMachineBuilder(
 	lexerParserBuilder := lexer_parser.NewBuilder()
 	grammarRetrieverCriteriaBuilder := grammar.NewRetrieverCriteriaBuilder()
 	stackFrameBuilder := NewStackFrameBuilder()
+	registry := observability.DefaultRegistry()
+	steps := registry.Counter(stepsMetric, "The amount of instructions received by the interpreter machines")
+	failedSteps := registry.Counter(failedStepsMetric, "The amount of instructions that failed in the interpreter machines")
 	return createMachineBuilder(
 		computableValueBuilder,
 		lexerParserApplication,
@@ -37,6 +45,8 @@ func NewMachineBuilder(
 		grammarRetrieverCriteriaBuilder,
 		stackFrameBuilder,
 		events,
+		steps,
+		failedSteps,
 	)
 }
 